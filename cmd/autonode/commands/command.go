@@ -1,6 +1,15 @@
 package commands
 
-import "github.com/spf13/cobra"
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// MachineReadableAnnotation marks commands whose output is consumed by other programs
+// (e.g. eval'd by the shell), so nothing else may be printed to stdout
+const MachineReadableAnnotation = "autonode/machine-readable"
 
 // Command interface defines a command that can be registered with the CLI
 // Open/Closed Principle: New commands can be added without modifying existing code
@@ -23,3 +32,21 @@ func Register(cmd Command) {
 func GetAll() []Command {
 	return registry
 }
+
+// IsMachineReadable reports whether cmd produced output meant for other programs:
+// it is annotated as machine-readable or was run with --json
+func IsMachineReadable(cmd *cobra.Command) bool {
+	if _, ok := cmd.Annotations[MachineReadableAnnotation]; ok {
+		return true
+	}
+
+	jsonFlag := cmd.Flags().Lookup("json")
+	return jsonFlag != nil && jsonFlag.Changed
+}
+
+// printJSON writes v to w as indented JSON
+func printJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestIsMachineReadable(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		cmd.Flags().Bool("json", false, "")
+		return cmd
+	}
+
+	plain := newCmd()
+
+	withJSON := newCmd()
+	if err := withJSON.Flags().Set("json", "true"); err != nil {
+		t.Fatalf("failed to set flag: %v", err)
+	}
+
+	annotated := &cobra.Command{
+		Use:         "shell",
+		Annotations: map[string]string{MachineReadableAnnotation: "true"},
+	}
+
+	tests := []struct {
+		name string
+		cmd  *cobra.Command
+		want bool
+	}{
+		{"plain output", plain, false},
+		{"--json", withJSON, true},
+		{"annotated command", annotated, true},
+	}
+
+	for _, tt := range tests {
+		if got := IsMachineReadable(tt.cmd); got != tt.want {
+			t.Errorf("%s: IsMachineReadable() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
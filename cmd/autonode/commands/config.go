@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/matutetandil/autonode/internal/core"
 	"github.com/spf13/cobra"
@@ -15,6 +16,9 @@ import (
 type ConfigCommand struct {
 	nodeVersion string
 	npmProfile  string
+	dockerStage string
+	ciMatrix    string
+	autoInstall string
 	show        bool
 	remove      bool
 }
@@ -23,6 +27,17 @@ type ConfigCommand struct {
 type autonodeConfig struct {
 	NodeVersion string `yaml:"nodeVersion,omitempty"`
 	NpmProfile  string `yaml:"npmProfile,omitempty"`
+	DockerStage string `yaml:"dockerStage,omitempty"`
+	CIMatrix    string `yaml:"ciMatrix,omitempty"`
+	AutoInstall string `yaml:"autoInstall,omitempty"`
+}
+
+// ciMatrixPolicies are the accepted values of ciMatrix
+var ciMatrixPolicies = []string{"highest", "lowest", "first", "last"}
+
+// isEmpty reports whether no setting is configured
+func (c *autonodeConfig) isEmpty() bool {
+	return c.NodeVersion == "" && c.NpmProfile == "" && c.DockerStage == "" && c.CIMatrix == "" && c.AutoInstall == ""
 }
 
 // init registers this command automatically when the package is imported
@@ -45,6 +60,9 @@ Examples:
   autonode config --node 18.17.0      # Set specific version
   autonode config --profile work      # Set npm profile
   autonode config --node 20 --profile work  # Set both
+  autonode config --docker-stage build  # Read the version from the "build" Dockerfile stage
+  autonode config --ci-matrix lowest  # Use the lowest version of CI matrices
+  autonode config --auto-install always  # Let the shell hook install missing versions
   autonode config --show              # Show current configuration
   autonode config --remove            # Remove .autonode.yml file
   autonode config --node ""           # Remove only nodeVersion
@@ -54,6 +72,9 @@ Examples:
 
 	cmd.Flags().StringVarP(&c.nodeVersion, "node", "n", "", "Node.js version to use (empty string to remove)")
 	cmd.Flags().StringVarP(&c.npmProfile, "profile", "p", "", "npm profile to use (empty string to remove)")
+	cmd.Flags().StringVar(&c.dockerStage, "docker-stage", "", "Dockerfile stage to read the Node.js version from (empty string for the last node stage)")
+	cmd.Flags().StringVar(&c.ciMatrix, "ci-matrix", "", "CI matrix entry to use: highest, lowest, first or last (empty string for highest)")
+	cmd.Flags().StringVar(&c.autoInstall, "auto-install", "", "What the shell hook does when the version is not installed: never, prompt or always (empty string for the global setting)")
 	cmd.Flags().BoolVarP(&c.show, "show", "s", false, "Show current configuration")
 	cmd.Flags().BoolVarP(&c.remove, "remove", "r", false, "Remove .autonode.yml configuration file")
 
@@ -85,12 +106,28 @@ func (c *ConfigCommand) run(cmd *cobra.Command, args []string) error {
 	// Check if any configuration flag was provided
 	nodeChanged := cmd.Flags().Changed("node")
 	profileChanged := cmd.Flags().Changed("profile")
+	stageChanged := cmd.Flags().Changed("docker-stage")
+	matrixChanged := cmd.Flags().Changed("ci-matrix")
+	autoInstallChanged := cmd.Flags().Changed("auto-install")
 
-	if !nodeChanged && !profileChanged {
+	if !nodeChanged && !profileChanged && !stageChanged && !matrixChanged && !autoInstallChanged {
 		// No flags provided, show help
 		return cmd.Help()
 	}
 
+	c.ciMatrix = strings.ToLower(c.ciMatrix)
+	if matrixChanged && c.ciMatrix != "" && !isCIMatrixPolicy(c.ciMatrix) {
+		return fmt.Errorf("invalid --ci-matrix '%s' (expected one of: %s)", c.ciMatrix, strings.Join(ciMatrixPolicies, ", "))
+	}
+
+	if autoInstallChanged && c.autoInstall != "" {
+		policy, err := core.ParseAutoInstallPolicy(c.autoInstall)
+		if err != nil {
+			return err
+		}
+		c.autoInstall = string(policy)
+	}
+
 	// Load existing config or create new one
 	config, err := c.loadConfig(configPath)
 	if err != nil {
@@ -118,8 +155,38 @@ func (c *ConfigCommand) run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// If both fields are empty, remove the file
-	if config.NodeVersion == "" && config.NpmProfile == "" {
+	if stageChanged {
+		if c.dockerStage == "" {
+			config.DockerStage = ""
+			logger.Info("Removed dockerStage from configuration")
+		} else {
+			config.DockerStage = c.dockerStage
+			logger.Success(fmt.Sprintf("Set dockerStage to '%s'", c.dockerStage))
+		}
+	}
+
+	if matrixChanged {
+		if c.ciMatrix == "" {
+			config.CIMatrix = ""
+			logger.Info("Removed ciMatrix from configuration")
+		} else {
+			config.CIMatrix = c.ciMatrix
+			logger.Success(fmt.Sprintf("Set ciMatrix to '%s'", c.ciMatrix))
+		}
+	}
+
+	if autoInstallChanged {
+		if c.autoInstall == "" {
+			config.AutoInstall = ""
+			logger.Info("Removed autoInstall from configuration")
+		} else {
+			config.AutoInstall = c.autoInstall
+			logger.Success(fmt.Sprintf("Set autoInstall to '%s'", c.autoInstall))
+		}
+	}
+
+	// If all fields are empty, remove the file
+	if config.isEmpty() {
 		if _, err := os.Stat(configPath); err == nil {
 			if err := os.Remove(configPath); err != nil {
 				return fmt.Errorf("failed to remove config file: %w", err)
@@ -146,7 +213,7 @@ func (c *ConfigCommand) showConfig(configPath string, logger core.Logger) error
 	}
 
 	// Check if config file exists but is empty
-	if config.NodeVersion == "" && config.NpmProfile == "" {
+	if config.isEmpty() {
 		logger.Info("No local configuration found.")
 		logger.Info("Use --node <version> or --profile <name> to configure.")
 		return nil
@@ -159,6 +226,15 @@ func (c *ConfigCommand) showConfig(configPath string, logger core.Logger) error
 	if config.NpmProfile != "" {
 		logger.Info(fmt.Sprintf("  npmProfile: %s", config.NpmProfile))
 	}
+	if config.DockerStage != "" {
+		logger.Info(fmt.Sprintf("  dockerStage: %s", config.DockerStage))
+	}
+	if config.CIMatrix != "" {
+		logger.Info(fmt.Sprintf("  ciMatrix: %s", config.CIMatrix))
+	}
+	if config.AutoInstall != "" {
+		logger.Info(fmt.Sprintf("  autoInstall: %s", config.AutoInstall))
+	}
 
 	return nil
 }
@@ -211,3 +287,13 @@ func (c *ConfigCommand) saveConfig(configPath string, config *autonodeConfig, lo
 	logger.Info(fmt.Sprintf("Configuration saved to %s", configPath))
 	return nil
 }
+
+// isCIMatrixPolicy reports whether policy is an accepted ciMatrix value
+func isCIMatrixPolicy(policy string) bool {
+	for _, accepted := range ciMatrixPolicies {
+		if policy == accepted {
+			return true
+		}
+	}
+	return false
+}
@@ -242,6 +242,30 @@ func TestAutonodeConfig_YamlOmitEmpty(t *testing.T) {
 			expectKeys: []string{"npmProfile"},
 			rejectKeys: []string{"nodeVersion"},
 		},
+		{
+			name: "only docker stage",
+			config: autonodeConfig{
+				DockerStage: "build",
+			},
+			expectKeys: []string{"dockerStage"},
+			rejectKeys: []string{"nodeVersion", "npmProfile"},
+		},
+		{
+			name: "only ci matrix",
+			config: autonodeConfig{
+				CIMatrix: "lowest",
+			},
+			expectKeys: []string{"ciMatrix"},
+			rejectKeys: []string{"nodeVersion", "npmProfile", "dockerStage"},
+		},
+		{
+			name: "only auto install",
+			config: autonodeConfig{
+				AutoInstall: "always",
+			},
+			expectKeys: []string{"autoInstall"},
+			rejectKeys: []string{"nodeVersion", "npmProfile", "ciMatrix"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"github.com/matutetandil/autonode/internal/core"
+	"github.com/matutetandil/autonode/internal/detectors"
+	"github.com/matutetandil/autonode/internal/managers"
+	"github.com/matutetandil/autonode/internal/switchers"
+)
+
+// newService wires every detector, manager and switcher into an AutoNodeService
+// Composition root shared by the commands that detect and switch versions
+// Dependency Inversion Principle: We create all dependencies here and inject them
+func newService(logger core.Logger, shell core.ShellExecutor, cache *core.CacheManager) *core.AutoNodeService {
+	// Load global configuration (Node.js mirror, offline mode, autoInstall)
+	globalConfig, _ := core.LoadGlobalConfig(cache)
+
+	// Create Node.js releases client (for Dockerfile codenames, package.json ranges and the built-in installer)
+	releasesClient := core.NewNodeReleasesClient(cache, logger, globalConfig.GetNodeMirror())
+	releasesClient.SetOffline(globalConfig.IsOffline())
+
+	// Create all version detectors
+	// Open/Closed Principle: Adding new detectors doesn't require modifying existing code
+	// Priority order: .autonode.yml (0) > .nvmrc (1) > .node-version (2) > mise.toml (3) > .tool-versions (4) > package.json (5) > Dockerfile (6)
+	// > docker-compose (7) > devcontainer.json (8) > GitHub Actions (9) > .gitlab-ci.yml (10) > .circleci/config.yml (11)
+	detectorsList := []core.VersionDetector{
+		detectors.NewAutonodeYmlVersionDetector(),
+		detectors.NewNvmrcDetector(),
+		detectors.NewNodeVersionDetector(),
+		detectors.NewMiseTomlDetector(),
+		detectors.NewToolVersionsDetector(),
+		detectors.NewPackageJsonDetector(releasesClient),
+		detectors.NewDockerfileDetector(releasesClient),
+		detectors.NewDockerComposeDetector(releasesClient),
+		detectors.NewDevcontainerDetector(releasesClient),
+		detectors.NewGitHubActionsDetector(releasesClient),
+		detectors.NewGitLabCIDetector(releasesClient),
+		detectors.NewCircleCIDetector(releasesClient),
+	}
+
+	// Create all version managers
+	// Open/Closed Principle: Adding new managers doesn't require modifying existing code
+	managersList := []core.VersionManager{
+		managers.NewNvmManager(shell),
+		managers.NewNvsManager(shell),
+		managers.NewVoltaManager(shell),
+		managers.NewFnmManager(shell),
+		managers.NewAsdfManager(shell),
+		managers.NewMiseManager(shell),
+		// Built-in installer goes last: it is always available, so it only wins when nothing else is installed
+		managers.NewStandaloneManager(cache.GetCacheFilePath("versions"), globalConfig.GetNodeMirror(), releasesClient),
+	}
+
+	// Create all profile detectors
+	// Open/Closed Principle: Adding new detectors doesn't require modifying existing code
+	profileDetectorsList := []core.ProfileDetector{
+		detectors.NewAutonodeYmlProfileDetector(),
+		detectors.NewPackageJsonProfileDetector(),
+	}
+
+	// Create all profile switchers
+	// Open/Closed Principle: Adding new switchers doesn't require modifying existing code
+	profileSwitchersList := []core.ProfileSwitcher{
+		switchers.NewNpmrcSwitcher(shell),
+		switchers.NewTsNpmrcSwitcher(shell),
+		switchers.NewRcManagerSwitcher(shell),
+	}
+
+	// Create the main service with all dependencies injected
+	// Dependency Inversion Principle: Service depends on abstractions (interfaces)
+	service := core.NewAutoNodeService(logger, detectorsList, managersList, profileDetectorsList, profileSwitchersList)
+
+	// Package manager detection (packageManager field, .yarnrc.yml yarnPath) and activation
+	service.SetPackageManagerDetectors([]core.PackageManagerDetector{
+		detectors.NewPackageJsonPackageManagerDetector(),
+		detectors.NewYarnrcPackageManagerDetector(),
+	})
+	service.SetPackageManagerSwitchers([]core.PackageManagerSwitcher{
+		switchers.NewCorepackSwitcher(shell),
+	})
+	service.SetShellExecutor(shell)
+
+	// Shell mode skips detection in unchanged projects, keeping the cd hook fast
+	service.SetDetectionCache(core.NewDetectionCache(cache))
+
+	// Aliases such as lts/iron are resolved for managers that don't support them
+	service.SetReleaseIndex(releasesClient)
+
+	// Offline mode reports missing versions instead of downloading them
+	service.SetOffline(globalConfig.IsOffline())
+
+	// The shell hook installs missing versions, asks, or only reports them (autoInstall)
+	service.SetProjectSettingsReader(detectors.NewAutonodeYmlSettingsReader())
+	service.SetAutoInstall(globalConfig.GetAutoInstall())
+	service.SetPrompter(core.NewTerminalPrompter())
+
+	return service
+}
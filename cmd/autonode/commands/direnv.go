@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// DirenvCommand implements the direnv command that activates a version from .envrc
+// Single Responsibility Principle: Only responsible for direnv integration output
+type DirenvCommand struct {
+	stdlib bool
+}
+
+// init registers this command automatically when the package is imported
+func init() {
+	Register(&DirenvCommand{})
+}
+
+// GetCobraCommand returns the cobra command for this command
+func (c *DirenvCommand) GetCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "direnv",
+		Short: "Output .envrc code that activates the project's Node.js version",
+		Long: `Outputs direnv code that puts the project's Node.js version on PATH and sets
+NODE_VERSION. direnv reverts both when you leave the directory, and reloads when
+a version file changes. Nothing is installed; run 'autonode' for that.
+
+Set it up once:
+  autonode direnv --stdlib > ~/.config/direnv/lib/use_autonode.sh
+
+Then add to a project's .envrc:
+  use autonode
+
+The cd hook leaves projects whose .envrc uses autonode to direnv.`,
+		// Output is eval'd by direnv: nothing else may be printed to stdout
+		Annotations: map[string]string{MachineReadableAnnotation: "true"},
+		RunE:        c.run,
+	}
+
+	cmd.Flags().BoolVar(&c.stdlib, "stdlib", false, "Print the use_autonode function for direnv's lib directory")
+
+	return cmd
+}
+
+// run prints the use_autonode function with --stdlib, otherwise the .envrc code for the
+// current directory
+func (c *DirenvCommand) run(cmd *cobra.Command, args []string) error {
+	if c.stdlib {
+		stdlib, err := hookTemplates.ReadFile("hooks/direnv.sh")
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(stdlib)
+		return err
+	}
+
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cache, err := core.NewCacheManager()
+	if err != nil {
+		return fmt.Errorf("failed to create cache manager: %w", err)
+	}
+
+	// direnv shows stderr to the user: keep it quiet and report problems as errors
+	service := newService(core.NewNullLogger(), core.NewExecShell(), cache)
+
+	script, err := service.DirenvScript(projectPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), script)
+	return err
+}
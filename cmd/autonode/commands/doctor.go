@@ -0,0 +1,261 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// DoctorCommand implements the doctor command that explains what autonode sees
+// in the current directory and why the shell hook does (or doesn't) switch versions
+// Single Responsibility Principle: Only responsible for reporting diagnostics
+type DoctorCommand struct {
+	jsonOutput bool
+}
+
+// doctorReport is everything the doctor command reports
+type doctorReport struct {
+	AutonodeVersion string `json:"autonodeVersion"`
+	core.DiagnosticReport
+	ShellHooks []shellHookDiagnostic `json:"shellHooks"`
+	CacheDir   string                `json:"cacheDir"`
+	CacheFiles []cacheFileDiagnostic `json:"cacheFiles"`
+}
+
+// shellHookDiagnostic reports whether a shell's rc file runs the autonode hook
+type shellHookDiagnostic struct {
+	Shell     string `json:"shell"`
+	File      string `json:"file"`
+	Exists    bool   `json:"exists"`
+	Installed bool   `json:"installed"`
+}
+
+// cacheFileDiagnostic describes one file or directory under ~/.autonode
+type cacheFileDiagnostic struct {
+	Name       string    `json:"name"`
+	ModifiedAt time.Time `json:"modifiedAt"`
+	AgeSeconds int64     `json:"ageSeconds"`
+}
+
+// init registers this command automatically when the package is imported
+func init() {
+	Register(&DoctorCommand{})
+}
+
+// GetCobraCommand returns the cobra command for this command
+func (c *DoctorCommand) GetCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose version detection, version managers and shell integration",
+		Long: `Show what autonode sees in the current directory: the result of every version
+and profile detector, which version managers and profile switchers are installed
+and which would be used, whether the shell hook is set up, and the age of the
+cache files in ~/.autonode.
+
+Use --json to get a report you can paste into an issue.`,
+		RunE: c.run,
+	}
+
+	cmd.Flags().BoolVar(&c.jsonOutput, "json", false, "Print the report as JSON")
+
+	return cmd
+}
+
+// run gathers the diagnostics and prints them
+func (c *DoctorCommand) run(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cache, err := core.NewCacheManager()
+	if err != nil {
+		return fmt.Errorf("failed to create cache manager: %w", err)
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	// The service runs silently: the report is the only output
+	service := newService(core.NewNullLogger(), core.NewExecShell(), cache)
+
+	report := doctorReport{
+		AutonodeVersion:  cmd.Root().Version,
+		DiagnosticReport: service.Diagnose(projectPath),
+		ShellHooks:       checkShellHooks(homeDir),
+		CacheDir:         cache.GetCacheDir(),
+		CacheFiles:       listCacheFiles(cache.GetCacheDir(), time.Now()),
+	}
+
+	if c.jsonOutput {
+		return printJSON(cmd.OutOrStdout(), report)
+	}
+
+	printDoctorReport(core.NewConsoleLogger(), report)
+	return nil
+}
+
+// checkShellHooks looks for the autonode hook in the rc file of every supported shell
+func checkShellHooks(homeDir string) []shellHookDiagnostic {
+	var hooks []shellHookDiagnostic
+	for _, rc := range shellRCFiles(homeDir) {
+		hook := shellHookDiagnostic{Shell: rc.Shell, File: rc.File}
+		if data, err := os.ReadFile(rc.File); err == nil {
+			hook.Exists = true
+			hook.Installed = hasShellHook(string(data))
+		}
+		hooks = append(hooks, hook)
+	}
+
+	return hooks
+}
+
+// listCacheFiles returns the entries of the cache directory with their ages, sorted by name
+func listCacheFiles(cacheDir string, now time.Time) []cacheFileDiagnostic {
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		return nil
+	}
+
+	var files []cacheFileDiagnostic
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, cacheFileDiagnostic{
+			Name:       entry.Name(),
+			ModifiedAt: info.ModTime(),
+			AgeSeconds: int64(now.Sub(info.ModTime()).Seconds()),
+		})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	return files
+}
+
+// printDoctorReport prints the report for humans
+func printDoctorReport(logger core.Logger, report doctorReport) {
+	logger.Info(fmt.Sprintf("autonode %s", report.AutonodeVersion))
+	logger.Info(fmt.Sprintf("Project: %s", report.ProjectPath))
+	logger.Info(fmt.Sprintf("Search directories: %s", strings.Join(report.SearchDirectories, ", ")))
+
+	logger.Info("\nVersion detectors:")
+	printDetectors(logger, report.VersionDetectors)
+
+	logger.Info("\nProfile detectors:")
+	printDetectors(logger, report.ProfileDetectors)
+
+	if len(report.PackageManagerDetectors) > 0 {
+		logger.Info("\nPackage manager detectors:")
+		printDetectors(logger, report.PackageManagerDetectors)
+	}
+
+	logger.Info("\nVersion managers:")
+	printComponents(logger, report.VersionManagers)
+
+	logger.Info("\nProfile switchers:")
+	printComponents(logger, report.ProfileSwitchers)
+
+	if len(report.PackageManagerSwitchers) > 0 {
+		logger.Info("\nPackage manager switchers:")
+		printComponents(logger, report.PackageManagerSwitchers)
+	}
+
+	logger.Info("\nResult:")
+	switch {
+	case report.DetectedVersion == "":
+		logger.Warning("No Node.js version found - autonode will not switch here")
+	case report.SelectedManager == "":
+		logger.Error(fmt.Sprintf("Node.js %s detected but no version manager is installed", report.DetectedVersion))
+	case report.VersionInstalled == nil:
+		logger.Warning(fmt.Sprintf("Node.js %s from %s via %s (could not check if installed)",
+			report.DetectedVersion, report.DetectedSource, report.SelectedManager))
+	case *report.VersionInstalled:
+		logger.Success(fmt.Sprintf("Node.js %s from %s via %s (installed)",
+			report.DetectedVersion, report.DetectedSource, report.SelectedManager))
+	default:
+		logger.Warning(fmt.Sprintf("Node.js %s from %s via %s (not installed - run 'autonode' to install it)",
+			report.DetectedVersion, report.DetectedSource, report.SelectedManager))
+	}
+	if report.DetectedProfile != "" {
+		if report.SelectedSwitcher == "" {
+			logger.Warning(fmt.Sprintf("npm profile '%s' detected but no profile switcher is installed", report.DetectedProfile))
+		} else {
+			logger.Success(fmt.Sprintf("npm profile '%s' via %s", report.DetectedProfile, report.SelectedSwitcher))
+		}
+	}
+
+	if report.DetectedPackageManager != "" {
+		if report.SelectedPackageManagerSwitcher == "" {
+			logger.Warning(fmt.Sprintf("Package manager %s from %s detected but corepack is not available",
+				report.DetectedPackageManager, report.DetectedPackageManagerSource))
+		} else {
+			logger.Success(fmt.Sprintf("Package manager %s from %s via %s",
+				report.DetectedPackageManager, report.DetectedPackageManagerSource, report.SelectedPackageManagerSwitcher))
+		}
+	}
+
+	logger.Info("\nShell integration:")
+	hookInstalled := false
+	for _, hook := range report.ShellHooks {
+		switch {
+		case hook.Installed:
+			hookInstalled = true
+			logger.Success(fmt.Sprintf("%s: hook found in %s", hook.Shell, hook.File))
+		case hook.Exists:
+			logger.Info(fmt.Sprintf("  %s: no hook in %s", hook.Shell, hook.File))
+		default:
+			logger.Info(fmt.Sprintf("  %s: %s not found", hook.Shell, hook.File))
+		}
+	}
+	if !hookInstalled {
+		logger.Warning("No shell hook found - versions only switch when you run 'autonode' (set one up with 'autonode init <shell> --install')")
+	}
+
+	logger.Info(fmt.Sprintf("\nCache (%s):", report.CacheDir))
+	if len(report.CacheFiles) == 0 {
+		logger.Info("  (empty)")
+	}
+	for _, file := range report.CacheFiles {
+		age := time.Duration(file.AgeSeconds) * time.Second
+		logger.Info(fmt.Sprintf("  %s: updated %s ago", file.Name, age.Round(time.Second)))
+	}
+}
+
+// printDetectors prints one line per detector outcome
+func printDetectors(logger core.Logger, detectors []core.DetectorDiagnostic) {
+	for _, detector := range detectors {
+		switch {
+		case detector.Error != "":
+			logger.Error(fmt.Sprintf("%s: %s", detector.Name, detector.Error))
+		case detector.Found && detector.Range != "":
+			logger.Success(fmt.Sprintf("%s: %s (range %s) from %s", detector.Name, detector.Value, detector.Range, detector.Source))
+		case detector.Found:
+			logger.Success(fmt.Sprintf("%s: %s from %s", detector.Name, detector.Value, detector.Source))
+		default:
+			logger.Info(fmt.Sprintf("  %s: not found", detector.Name))
+		}
+	}
+}
+
+// printComponents prints whether each manager or switcher is installed and which one is used
+func printComponents(logger core.Logger, components []core.ComponentDiagnostic) {
+	for _, component := range components {
+		switch {
+		case component.Selected:
+			logger.Success(fmt.Sprintf("%s: installed (selected)", component.Name))
+		case component.Installed:
+			logger.Success(fmt.Sprintf("%s: installed", component.Name))
+		default:
+			logger.Info(fmt.Sprintf("  %s: not installed", component.Name))
+		}
+	}
+}
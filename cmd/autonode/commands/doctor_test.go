@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheckShellHooks(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("ZDOTDIR", "")
+
+	// fish is not set up on this machine; zsh has a hook from install.sh, pwsh one from autonode init
+	pwshProfile := shellRCFiles(home)[3].File
+	files := map[string]string{
+		".bashrc":   "export PATH=$HOME/bin:$PATH\n",
+		".zshrc":    "autonode_hook() {\n  eval \"$(autonode shell 2>/dev/null)\"\n}\n",
+		pwshProfile: "Invoke-Expression (& autonode init pwsh | Out-String)\n",
+	}
+
+	for name, content := range files {
+		path := name
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(home, name)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	hooks := checkShellHooks(home)
+
+	want := []shellHookDiagnostic{
+		{Shell: "bash", File: filepath.Join(home, ".bashrc"), Exists: true, Installed: false},
+		{Shell: "zsh", File: filepath.Join(home, ".zshrc"), Exists: true, Installed: true},
+		{Shell: "fish", File: filepath.Join(home, ".config", "fish", "config.fish"), Exists: false, Installed: false},
+		{Shell: "pwsh", File: pwshProfile, Exists: true, Installed: true},
+	}
+	if len(hooks) != len(want) {
+		t.Fatalf("checkShellHooks() = %+v", hooks)
+	}
+	for i := range want {
+		if hooks[i] != want[i] {
+			t.Errorf("hooks[%d] = %+v, want %+v", i, hooks[i], want[i])
+		}
+	}
+}
+
+func TestListCacheFiles(t *testing.T) {
+	cacheDir := t.TempDir()
+	now := time.Now()
+
+	for name, age := range map[string]time.Duration{
+		"node-releases.json": 2 * time.Hour,
+		"config.json":        48 * time.Hour,
+	} {
+		path := filepath.Join(cacheDir, name)
+		if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		modified := now.Add(-age)
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatalf("failed to set mtime: %v", err)
+		}
+	}
+
+	files := listCacheFiles(cacheDir, now)
+
+	if len(files) != 2 {
+		t.Fatalf("listCacheFiles() = %+v", files)
+	}
+	if files[0].Name != "config.json" || files[1].Name != "node-releases.json" {
+		t.Errorf("files not sorted by name: %+v", files)
+	}
+	if files[1].AgeSeconds != int64((2 * time.Hour).Seconds()) {
+		t.Errorf("node-releases.json age = %ds, want %ds", files[1].AgeSeconds, int64((2 * time.Hour).Seconds()))
+	}
+
+	if got := listCacheFiles(filepath.Join(cacheDir, "missing"), now); got != nil {
+		t.Errorf("listCacheFiles(missing) = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// EnvCommand implements the env command that prints the project's Node.js environment
+// Single Responsibility Principle: Only responsible for outputting the resolved environment
+type EnvCommand struct {
+	shell string // Shell to write output for
+}
+
+// init registers this command automatically when the package is imported
+func init() {
+	Register(&EnvCommand{})
+}
+
+// GetCobraCommand returns the cobra command for this command
+func (c *EnvCommand) GetCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Print the environment for the project's Node.js version",
+		Long: `Prints the variables that activate the project's Node.js version: PATH with the
+version's bin directory first, and the variables the version manager would export
+(NVM_BIN and NVM_INC for nvm). Versions installed with nvm, nvs, Volta or the
+built-in installer are located on disk, without sourcing nvm.sh or nvs.sh.
+
+Nothing is installed or switched; evaluate the output to apply it:
+
+  bash, zsh:  eval "$(autonode env)"
+  fish:       autonode env --shell fish | source`,
+		// Output is eval'd by the shell: nothing else may be printed to stdout
+		Annotations: map[string]string{MachineReadableAnnotation: "true"},
+		RunE:        c.run,
+	}
+
+	cmd.Flags().StringVar(&c.shell, "shell", "bash", "Shell to write commands for ("+strings.Join(core.ShellNames, ", ")+")")
+
+	return cmd
+}
+
+// run prints the environment for the project in the current directory
+func (c *EnvCommand) run(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cache, err := core.NewCacheManager()
+	if err != nil {
+		return fmt.Errorf("failed to create cache manager: %w", err)
+	}
+
+	service := newService(core.NewNullLogger(), core.NewExecShell(), cache)
+
+	script, err := service.EnvScript(projectPath, c.shell)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), script)
+	return err
+}
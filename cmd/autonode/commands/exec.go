@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// ExecCommand implements the exec command that runs a command under the project's
+// Node.js version without switching the interactive shell
+// Single Responsibility Principle: Only responsible for running a child process
+type ExecCommand struct{}
+
+// init registers this command automatically when the package is imported
+func init() {
+	Register(&ExecCommand{})
+}
+
+// GetCobraCommand returns the cobra command for this command
+func (c *ExecCommand) GetCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec -- <command> [args...]",
+		Short: "Run a command under the project's Node.js version",
+		Long: `Detects the project's Node.js version (installing it if missing) and runs
+the given command with that version, without changing your shell.
+The command's exit code is passed through.
+
+Examples:
+  autonode exec -- npm test
+  autonode exec node --version`,
+		Args: cobra.MinimumNArgs(1),
+		// Output belongs to the child process: nothing else may be printed to stdout
+		Annotations: map[string]string{MachineReadableAnnotation: "true"},
+		RunE:        c.run,
+	}
+
+	// Everything after the command name belongs to the command, not to autonode
+	cmd.Flags().SetInterspersed(false)
+
+	return cmd
+}
+
+// run prepares the child process through the service and runs it
+func (c *ExecCommand) run(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cache, err := core.NewCacheManager()
+	if err != nil {
+		return fmt.Errorf("failed to create cache manager: %w", err)
+	}
+
+	// Progress messages go to stderr so the child's stdout stays clean
+	service := newService(core.NewStderrConsoleLogger(), core.NewExecShell(), cache)
+
+	child, err := service.PrepareExec(projectPath, args)
+	if err != nil {
+		return err
+	}
+
+	code, err := runChild(child)
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		os.Exit(code)
+	}
+
+	return nil
+}
+
+// terminalDelivered reports whether signals typed at the terminal reach the child without
+// autonode relaying them (replaced in tests)
+var terminalDelivered = inTerminalForeground
+
+// runChild runs cmd, forwarding termination signals to it, and returns its exit code
+// A child killed by a signal reports 128 + the signal number, like a shell does.
+// When the terminal already delivers SIGINT, SIGQUIT and SIGHUP to the child, they are
+// not relayed: a second interrupt makes many tools skip their graceful shutdown.
+func runChild(cmd *exec.Cmd) (int, error) {
+	relayed := map[os.Signal]bool{syscall.SIGTERM: true}
+	if !terminalDelivered() {
+		relayed[syscall.SIGINT] = true
+		relayed[syscall.SIGQUIT] = true
+		relayed[syscall.SIGHUP] = true
+	}
+
+	// autonode must outlive the child to pass its exit code on, so every signal is caught
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT)
+
+	if err := cmd.Start(); err != nil {
+		signal.Stop(signals)
+		return 0, fmt.Errorf("failed to run %s: %w", cmd.Path, err)
+	}
+
+	go func() {
+		for sig := range signals {
+			if relayed[sig] {
+				cmd.Process.Signal(sig)
+			}
+		}
+	}()
+
+	err := cmd.Wait()
+	signal.Stop(signals)
+	close(signals)
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal()), nil
+		}
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestRunChild(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   int
+	}{
+		{"success", "exit 0", 0},
+		{"exit code passed through", "exit 3", 3},
+		{"killed by signal", "kill -TERM $$", 128 + 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := runChild(exec.Command("sh", "-c", tt.script))
+			if err != nil {
+				t.Fatalf("runChild() error = %v", err)
+			}
+			if code != tt.want {
+				t.Errorf("runChild() = %d, want %d", code, tt.want)
+			}
+		})
+	}
+
+	if _, err := runChild(exec.Command("/nonexistent/program")); err == nil {
+		t.Error("runChild() with missing program expected error")
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+
+package commands
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// inTerminalForeground reports whether autonode runs in the foreground process group of
+// its controlling terminal, where Ctrl-C, Ctrl-\ and hangups reach every process of the
+// group, the child included
+func inTerminalForeground() bool {
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return false
+	}
+	defer tty.Close()
+
+	var pgrp int32
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, tty.Fd(), uintptr(syscall.TIOCGPGRP), uintptr(unsafe.Pointer(&pgrp)))
+	return errno == 0 && int(pgrp) == syscall.Getpgrp()
+}
@@ -0,0 +1,59 @@
+//go:build !windows
+
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunChild_TerminalInterrupt(t *testing.T) {
+	tests := []struct {
+		name      string
+		delivered bool // The terminal sends Ctrl-C to the child itself
+		want      int  // Interrupts the child receives from autonode
+	}{
+		{"terminal delivers interrupts", true, 0},
+		{"interrupt sent to autonode only", false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terminalDelivered = func() bool { return tt.delivered }
+			t.Cleanup(func() { terminalDelivered = inTerminalForeground })
+
+			dir := t.TempDir()
+			ready := filepath.Join(dir, "ready")
+			var output strings.Builder
+			child := exec.Command("sh", "-c", `trap 'echo interrupted' INT; touch "$1"; i=0; while [ $i -lt 10 ]; do sleep 0.05; i=$((i+1)); done`, "sh", ready)
+			child.Stdout = &output
+
+			done := make(chan error, 1)
+			go func() {
+				_, err := runChild(child)
+				done <- err
+			}()
+
+			for i := 0; i < 100; i++ {
+				if _, err := os.Stat(ready); err == nil {
+					break
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			// The interrupt autonode receives, as it does from Ctrl-C or kill -INT
+			syscall.Kill(os.Getpid(), syscall.SIGINT)
+
+			if err := <-done; err != nil {
+				t.Fatalf("runChild() error = %v", err)
+			}
+			if got := strings.Count(output.String(), "interrupted"); got != tt.want {
+				t.Errorf("child received %d interrupts from autonode, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package commands
+
+// inTerminalForeground reports whether console signals reach the child directly, which
+// they always do on Windows: Ctrl-C is sent to every process attached to the console
+func inTerminalForeground() bool {
+	return true
+}
@@ -0,0 +1,18 @@
+# AutoNode hook for bash: eval "$(autonode init bash)"
+# Runs before each prompt and switches Node.js version when the directory changed
+_autonode_hook() {
+  local status=$?
+  if [[ "$PWD" != "${_AUTONODE_LAST_PWD-}" ]]; then
+    _AUTONODE_LAST_PWD="$PWD"
+    eval "$(autonode shell --shell bash 2>/dev/null)"
+  fi
+  return $status
+}
+
+if [[ "$(declare -p PROMPT_COMMAND 2>/dev/null)" == "declare -a"* ]]; then
+  if [[ " ${PROMPT_COMMAND[*]} " != *" _autonode_hook "* ]]; then
+    PROMPT_COMMAND=(_autonode_hook "${PROMPT_COMMAND[@]}")
+  fi
+elif [[ ";${PROMPT_COMMAND-};" != *";_autonode_hook;"* ]]; then
+  PROMPT_COMMAND="_autonode_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
@@ -0,0 +1,12 @@
+# AutoNode for direnv: autonode direnv --stdlib > ~/.config/direnv/lib/use_autonode.sh
+# Then add `use autonode` to a project's .envrc
+use_autonode() {
+  if ! has autonode; then
+    log_error "autonode: not found on PATH"
+    return 1
+  fi
+
+  local script
+  script="$(autonode direnv)" || return 1
+  eval "$script"
+}
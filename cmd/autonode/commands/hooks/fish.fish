@@ -0,0 +1,7 @@
+# AutoNode hook for fish: autonode init fish | source
+# Switches Node.js version whenever the directory changes
+function _autonode_hook --on-variable PWD --description 'Switch Node.js version for the current directory'
+    autonode shell --shell fish 2>/dev/null | source
+end
+
+_autonode_hook
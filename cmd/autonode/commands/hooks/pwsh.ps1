@@ -0,0 +1,18 @@
+# AutoNode hook for PowerShell: Invoke-Expression (& autonode init pwsh | Out-String)
+# Switches Node.js version whenever the location changes
+function global:Invoke-AutoNodeHook {
+    autonode shell --shell pwsh 2>$null | Out-String | Invoke-Expression
+}
+
+if (-not $global:AutoNodeHookInstalled) {
+    $global:AutoNodeHookInstalled = $true
+    $global:AutoNodePreviousLocationChangedAction = $ExecutionContext.InvokeCommand.LocationChangedAction
+    $ExecutionContext.InvokeCommand.LocationChangedAction = {
+        if ($global:AutoNodePreviousLocationChangedAction) {
+            & $global:AutoNodePreviousLocationChangedAction @args
+        }
+        Invoke-AutoNodeHook
+    }
+}
+
+Invoke-AutoNodeHook
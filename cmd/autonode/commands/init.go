@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// hookTemplates holds the shell hooks `autonode init` prints, one per shell
+//
+//go:embed hooks
+var hookTemplates embed.FS
+
+// hookBlockMarker starts the block `autonode init --install` writes to a startup file;
+// install.sh used the same line, so its hooks are recognised and replaced
+const hookBlockMarker = "# AutoNode - automatic Node.js version switching"
+
+// legacyHookEnd ends the hook blocks older versions of install.sh wrote
+const legacyHookEnd = "autonode_hook  # Run on shell startup"
+
+// initShells describes every shell `autonode init` supports
+var initShells = map[string]struct {
+	template string // File under hooks/
+	load     string // Line in the startup file that loads the hook
+}{
+	"bash": {"bash.sh", `eval "$(autonode init bash)"`},
+	"zsh":  {"zsh.zsh", `eval "$(autonode init zsh)"`},
+	"fish": {"fish.fish", "autonode init fish | source"},
+	"pwsh": {"pwsh.ps1", "Invoke-Expression (& autonode init pwsh | Out-String)"},
+}
+
+// InitCommand implements the init command that prints the shell hook
+// Single Responsibility Principle: Only responsible for providing and installing shell hooks
+type InitCommand struct {
+	install bool
+}
+
+// init registers this command automatically when the package is imported
+func init() {
+	Register(&InitCommand{})
+}
+
+// GetCobraCommand returns the cobra command for this command
+func (c *InitCommand) GetCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init <bash|zsh|fish|pwsh>",
+		Short: "Print the shell hook that switches versions on cd",
+		Long: `Print the hook that switches Node.js versions whenever you change directory.
+The hook ships with the binary, so 'autonode update' upgrades it too.
+
+Load it from your shell's startup file:
+  bash  (~/.bashrc):      eval "$(autonode init bash)"
+  zsh   (~/.zshrc):       eval "$(autonode init zsh)"
+  fish  (config.fish):    autonode init fish | source
+  pwsh  ($PROFILE):       Invoke-Expression (& autonode init pwsh | Out-String)
+
+With --install, that line is added to the startup file for you. Running it again
+changes nothing; hooks written by older versions of install.sh are replaced.`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"bash", "zsh", "fish", "pwsh"},
+		// Output is eval'd by the shell: nothing else may be printed to stdout
+		Annotations: map[string]string{MachineReadableAnnotation: "true"},
+		RunE:        c.run,
+	}
+
+	cmd.Flags().BoolVar(&c.install, "install", false, "Add the hook to the shell's startup file")
+
+	return cmd
+}
+
+// run prints the hook for the shell, or installs it with --install
+func (c *InitCommand) run(cmd *cobra.Command, args []string) error {
+	shell := args[0]
+	spec, ok := initShells[shell]
+	if !ok {
+		return fmt.Errorf("unsupported shell %q (supported: bash, zsh, fish, pwsh)", shell)
+	}
+
+	if !c.install {
+		hook, err := hookTemplates.ReadFile("hooks/" + spec.template)
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(hook)
+		return err
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	var file string
+	for _, rc := range shellRCFiles(homeDir) {
+		if rc.Shell == shell {
+			file = rc.File
+		}
+	}
+
+	logger := core.NewConsoleLogger()
+	changed, err := installHook(file, spec.load)
+	if err != nil {
+		return fmt.Errorf("failed to install hook in %s: %w", file, err)
+	}
+	if !changed {
+		logger.Success(fmt.Sprintf("Shell integration already configured in %s", file))
+		return nil
+	}
+
+	logger.Success(fmt.Sprintf("Shell integration configured in %s", file))
+	logger.Info("Restart your shell (or open a new terminal) to activate it")
+	return nil
+}
+
+// installHook makes the startup file at path load the hook with the load line: an existing
+// autonode block (including one written by install.sh) is replaced, otherwise the block is
+// appended. Returns false if the file already loads the hook.
+func installHook(path, load string) (bool, error) {
+	// Dotfile managers link startup files: edit the file the link points to
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	content := string(data)
+
+	lines := strings.Split(content, "\n")
+	for _, line := range lines {
+		if strings.TrimSpace(line) == load {
+			return false, nil
+		}
+	}
+
+	block := []string{hookBlockMarker, load}
+	if start := indexOfLine(lines, hookBlockMarker); start >= 0 {
+		// Replace the old block: an install.sh hook ends at its startup call,
+		// a block of ours is the marker and its load line
+		end := start + 1
+		if legacy := indexOfLine(lines[start:], legacyHookEnd); legacy >= 0 {
+			end = start + legacy
+		}
+		if end >= len(lines) {
+			end = len(lines) - 1
+		}
+		lines = append(lines[:start], append(block, lines[end+1:]...)...)
+		content = strings.Join(lines, "\n")
+	} else {
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		if content != "" {
+			content += "\n"
+		}
+		content += strings.Join(block, "\n") + "\n"
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, err
+	}
+	return true, writeFileAtomic(path, []byte(content))
+}
+
+// indexOfLine returns the index of the first line equal to want after trimming spaces, or -1
+func indexOfLine(lines []string, want string) int {
+	for i, line := range lines {
+		if strings.TrimSpace(line) == want {
+			return i
+		}
+	}
+	return -1
+}
+
+// writeFileAtomic replaces the file at path through a temporary file in the same
+// directory, keeping its permissions, so an interrupted write never truncates it
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInitCommand_Templates(t *testing.T) {
+	for shell, spec := range initShells {
+		hook, err := hookTemplates.ReadFile("hooks/" + spec.template)
+		if err != nil {
+			t.Errorf("%s: no embedded hook: %v", shell, err)
+			continue
+		}
+		// The hook must ask for output in its own shell's syntax
+		if want := "autonode shell --shell " + shell; !strings.Contains(string(hook), want) {
+			t.Errorf("%s: hook does not run %q", shell, want)
+		}
+	}
+}
+
+func TestInstallHook(t *testing.T) {
+	load := `eval "$(autonode init bash)"`
+	legacy := `export PATH=$HOME/bin:$PATH
+
+# AutoNode - automatic Node.js version switching
+autonode_hook() {
+  eval "$(autonode shell 2>/dev/null)"
+}
+autonode_cd() {
+  builtin cd "$@" && autonode_hook
+}
+alias cd='autonode_cd'
+autonode_hook  # Run on shell startup
+alias ll='ls -l'
+`
+
+	tests := []struct {
+		name        string
+		content     *string // nil when the file doesn't exist
+		want        string
+		wantChanged bool
+	}{
+		{
+			name:        "missing file",
+			want:        hookBlockMarker + "\n" + load + "\n",
+			wantChanged: true,
+		},
+		{
+			name:        "appended after existing content",
+			content:     ptr("export EDITOR=vim"),
+			want:        "export EDITOR=vim\n\n" + hookBlockMarker + "\n" + load + "\n",
+			wantChanged: true,
+		},
+		{
+			name:    "already installed",
+			content: ptr("export EDITOR=vim\n\n" + hookBlockMarker + "\n" + load + "\n"),
+			want:    "export EDITOR=vim\n\n" + hookBlockMarker + "\n" + load + "\n",
+		},
+		{
+			name:        "install.sh hook replaced",
+			content:     &legacy,
+			want:        "export PATH=$HOME/bin:$PATH\n\n" + hookBlockMarker + "\n" + load + "\nalias ll='ls -l'\n",
+			wantChanged: true,
+		},
+		{
+			name:        "hook for another shell replaced",
+			content:     ptr(hookBlockMarker + "\n" + `eval "$(autonode init zsh)"` + "\n"),
+			want:        hookBlockMarker + "\n" + load + "\n",
+			wantChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "home", ".bashrc")
+			if tt.content != nil {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("failed to create directory: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(*tt.content), 0600); err != nil {
+					t.Fatalf("failed to write .bashrc: %v", err)
+				}
+			}
+
+			changed, err := installHook(path, load)
+			if err != nil {
+				t.Fatalf("installHook() error = %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("installHook() changed = %v, want %v", changed, tt.wantChanged)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read .bashrc: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf(".bashrc =\n%s\nwant\n%s", data, tt.want)
+			}
+
+			// Installing again never changes the file
+			if changed, err := installHook(path, load); err != nil || changed {
+				t.Errorf("second installHook() = %v, %v, want no change", changed, err)
+			}
+
+			if tt.content != nil {
+				if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+					t.Errorf("permissions not kept: %v, %v", info.Mode(), err)
+				}
+			}
+		})
+	}
+}
+
+func TestInstallHook_Symlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles", "zshrc")
+	link := filepath.Join(dir, ".zshrc")
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(target, []byte("setopt autocd\n"), 0644); err != nil {
+		t.Fatalf("failed to write zshrc: %v", err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	if _, err := installHook(link, `eval "$(autonode init zsh)"`); err != nil {
+		t.Fatalf("installHook() error = %v", err)
+	}
+
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf(".zshrc is no longer a symlink")
+	}
+	if data, _ := os.ReadFile(target); !strings.Contains(string(data), "autonode init zsh") {
+		t.Errorf("linked file not updated: %s", data)
+	}
+}
+
+// ptr returns a pointer to s
+func ptr(s string) *string { return &s }
+
+func TestDirenvCommand_Stdlib(t *testing.T) {
+	stdlib, err := hookTemplates.ReadFile("hooks/direnv.sh")
+	if err != nil {
+		t.Fatalf("no embedded direnv function: %v", err)
+	}
+	for _, want := range []string{"use_autonode()", "autonode direnv"} {
+		if !strings.Contains(string(stdlib), want) {
+			t.Errorf("direnv function does not contain %q", want)
+		}
+	}
+}
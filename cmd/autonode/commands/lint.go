@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// LintCommand implements the lint command that checks every version source of the
+// project for conflicts, for use in CI
+// Single Responsibility Principle: Only responsible for reporting (and fixing) conflicts
+type LintCommand struct {
+	fix        bool
+	jsonOutput bool
+}
+
+// init registers this command automatically when the package is imported
+func init() {
+	Register(&LintCommand{})
+}
+
+// GetCobraCommand returns the cobra command for this command
+func (c *LintCommand) GetCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check that all Node.js version sources in the project agree",
+		Long: `Runs every version detector (.autonode.yml, .nvmrc, .node-version, mise.toml,
+.tool-versions, package.json engines.node, Dockerfile, docker-compose, devcontainer.json,
+GitHub Actions, .gitlab-ci.yml, .circleci/config.yml)
+and checks that the versions they declare are compatible with the authoritative one -
+the source autonode would switch to. Each conflict is reported with its file and line,
+and the command exits with a non-zero status when there is any, so it can run in CI.
+
+Use --fix to rewrite the conflicting sources to the authoritative version.`,
+		SilenceUsage: true,
+		RunE:         c.run,
+	}
+
+	cmd.Flags().BoolVar(&c.fix, "fix", false, "Rewrite conflicting sources to the authoritative version")
+	cmd.Flags().BoolVar(&c.jsonOutput, "json", false, "Print the lint report as JSON")
+
+	return cmd
+}
+
+// run lints the project and fails when conflicts remain
+func (c *LintCommand) run(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cache, err := core.NewCacheManager()
+	if err != nil {
+		return fmt.Errorf("failed to create cache manager: %w", err)
+	}
+
+	logger := core.NewConsoleLogger()
+	if c.jsonOutput {
+		logger = core.NewStderrConsoleLogger()
+	}
+
+	service := newService(core.NewNullLogger(), core.NewExecShell(), cache)
+	report := service.Lint(projectPath)
+
+	if c.fix && len(report.Conflicts) > 0 {
+		report = fixConflicts(logger, service, report)
+	}
+
+	if c.jsonOutput {
+		if err := printJSON(cmd.OutOrStdout(), report); err != nil {
+			return err
+		}
+	} else {
+		printLintReport(logger, report)
+	}
+
+	if len(report.Conflicts) > 0 {
+		return fmt.Errorf("%d version conflict(s) found", len(report.Conflicts))
+	}
+	if len(report.Errors) > 0 {
+		return fmt.Errorf("%d version source(s) could not be read", len(report.Errors))
+	}
+	return nil
+}
+
+// fixConflicts rewrites every conflicting source and lints the project again
+// so the returned report only lists the conflicts that could not be fixed
+func fixConflicts(logger core.Logger, service *core.AutoNodeService, report core.LintReport) core.LintReport {
+	for _, conflict := range report.Conflicts {
+		location := relativeLocation(report.ProjectPath, conflict.Source)
+		if err := conflict.Fix(); err != nil {
+			logger.Error(fmt.Sprintf("Could not fix %s: %v", location, err))
+			continue
+		}
+		logger.Success(fmt.Sprintf("Fixed %s: %s -> %s", location, conflict.Source.Spec, conflict.Authority.Version))
+	}
+
+	return service.Lint(report.ProjectPath)
+}
+
+// printLintReport prints the sources and conflicts for humans
+// Paths are shown relative to the project so they are easy to open from CI logs
+func printLintReport(logger core.Logger, report core.LintReport) {
+	for _, failure := range report.Errors {
+		logger.Error(fmt.Sprintf("%s: %s", failure.Detector, failure.Error))
+	}
+
+	authority := report.Authority()
+	if authority == nil {
+		logger.Warning("No Node.js version specification found in project")
+		return
+	}
+
+	logger.Info("Version sources:")
+	for i, source := range report.Sources {
+		note := ""
+		switch {
+		case i == 0:
+			note = " (authoritative)"
+		case !source.Comparable:
+			note = " (not a semver range, skipped)"
+		}
+		logger.Info(fmt.Sprintf("  %s: %s%s", relativeLocation(report.ProjectPath, source), source.Spec, note))
+	}
+
+	if !authority.Comparable {
+		logger.Warning(fmt.Sprintf("%s is not a semver range, so other sources cannot be checked against it", authority.Spec))
+		return
+	}
+
+	if len(report.Conflicts) == 0 {
+		logger.Success(fmt.Sprintf("All version sources agree with %s %s", authority.Detector, authority.Spec))
+		return
+	}
+
+	for _, conflict := range report.Conflicts {
+		logger.Error(fmt.Sprintf("%s: %s %s conflicts with %s %s at %s",
+			relativeLocation(report.ProjectPath, conflict.Source), conflict.Source.Detector, conflict.Source.Spec,
+			conflict.Authority.Detector, conflict.Authority.Spec, relativeLocation(report.ProjectPath, conflict.Authority)))
+	}
+}
+
+// relativeLocation returns the source's "file:line" relative to the project when possible
+func relativeLocation(projectPath string, source core.LintSource) string {
+	if rel, err := filepath.Rel(projectPath, source.Source); err == nil {
+		source.Source = rel
+	}
+	return source.Location()
+}
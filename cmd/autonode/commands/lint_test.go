@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+func TestRelativeLocation(t *testing.T) {
+	tests := []struct {
+		name   string
+		source core.LintSource
+		want   string
+	}{
+		{"inside project", core.LintSource{Source: "/repo/web/.nvmrc", Line: 1}, ".nvmrc:1"},
+		{"parent directory", core.LintSource{Source: "/repo/package.json", Line: 12}, "../package.json:12"},
+		{"unknown line", core.LintSource{Source: "/repo/web/Dockerfile"}, "Dockerfile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := relativeLocation("/repo/web", tt.source); got != tt.want {
+				t.Errorf("relativeLocation() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
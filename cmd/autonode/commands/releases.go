@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// ReleasesCommand implements the releases command for managing the cached Node.js release index
+// Single Responsibility Principle: Only responsible for showing, refreshing and seeding the release cache
+type ReleasesCommand struct {
+	importPath string
+	refresh    bool
+}
+
+// init registers this command automatically when the package is imported
+func init() {
+	Register(&ReleasesCommand{})
+}
+
+// GetCobraCommand returns the cobra command for this command
+func (c *ReleasesCommand) GetCobraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "releases [version]",
+		Short: "Show, query, refresh or seed the cached Node.js release index",
+		Long: `Show, query, refresh or seed the Node.js release index cached in ~/.autonode/node-releases.json.
+
+The index resolves LTS codenames, aliases (lts/*, lts/-1, node) and partial versions
+the same way for every detector and the built-in installer. It is fetched from the Node.js
+mirror (nodeMirror setting, AUTONODE_NODE_MIRROR or NVM_NODEJS_ORG_MIRROR) once a day.
+On machines without network access, seed it from a copy of the mirror's index.json.
+
+Examples:
+  autonode releases                        # Show the mirror and cache status
+  autonode releases lts/-1                 # Show the release an alias or version resolves to
+  autonode releases --refresh              # Fetch index.json from the mirror now
+  autonode releases --import index.json    # Seed the cache from a local index.json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: c.run,
+	}
+
+	cmd.Flags().StringVar(&c.importPath, "import", "", "Seed the cache from a local index.json file")
+	cmd.Flags().BoolVar(&c.refresh, "refresh", false, "Fetch index.json from the mirror now")
+
+	return cmd
+}
+
+// run executes the releases command
+func (c *ReleasesCommand) run(cmd *cobra.Command, args []string) error {
+	logger := core.NewConsoleLogger()
+
+	if c.importPath != "" && c.refresh {
+		return fmt.Errorf("--import and --refresh cannot be used together")
+	}
+
+	cache, err := core.NewCacheManager()
+	if err != nil {
+		return fmt.Errorf("failed to create cache manager: %w", err)
+	}
+
+	globalConfig, _ := core.LoadGlobalConfig(cache)
+	client := core.NewNodeReleasesClient(cache, logger, globalConfig.GetNodeMirror())
+	client.SetOffline(globalConfig.IsOffline())
+
+	switch {
+	case c.importPath != "":
+		count, err := client.ImportReleases(c.importPath)
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", c.importPath, err)
+		}
+		logger.Success(fmt.Sprintf("Imported %d Node.js releases from %s", count, c.importPath))
+		return nil
+	case c.refresh:
+		if err := client.Refresh(); err != nil {
+			return fmt.Errorf("failed to fetch Node.js releases: %w", err)
+		}
+		return nil
+	}
+
+	if len(args) == 1 {
+		return printRelease(logger, client, args[0])
+	}
+
+	printReleasesStatus(logger, client, globalConfig.IsOffline())
+	return nil
+}
+
+// printRelease displays the release spec resolves to, with its support status
+func printRelease(logger core.Logger, client *core.NodeReleasesClient, spec string) error {
+	index, err := client.GetReleaseIndex()
+	if err != nil {
+		return err
+	}
+
+	release, found := index.Resolve(spec)
+	if !found {
+		return fmt.Errorf("no Node.js release matches %s", spec)
+	}
+
+	logger.Success(fmt.Sprintf("%s resolves to Node.js %s (released %s)", spec, release.Number(), release.Date))
+	if codename := release.Codename(); codename != "" {
+		logger.Info(fmt.Sprintf("LTS: %s", codename))
+	}
+	if release.Npm != "" {
+		logger.Info(fmt.Sprintf("npm: %s", release.Npm))
+	}
+	if release.Modules != "" {
+		logger.Info(fmt.Sprintf("Modules ABI: %s", release.Modules))
+	}
+	if update, found := index.SecurityUpdate(release.Number()); found {
+		logger.Warning(fmt.Sprintf("Security fixes are available in Node.js %s", update.Number()))
+	}
+	if eol, found := index.EndOfLife(release.Major()); found {
+		if index.IsEndOfLife(release.Number(), time.Now()) {
+			logger.Warning(fmt.Sprintf("Node.js %d reached end-of-life on %s", release.Major(), eol.Format(time.DateOnly)))
+		} else {
+			logger.Info(fmt.Sprintf("Supported until %s", eol.Format(time.DateOnly)))
+		}
+	}
+
+	return nil
+}
+
+// printReleasesStatus displays the mirror, offline mode and the state of the cache
+func printReleasesStatus(logger core.Logger, client *core.NodeReleasesClient, offline bool) {
+	logger.Info(fmt.Sprintf("Mirror: %s", client.Mirror()))
+	if offline {
+		logger.Info("Offline mode: enabled (the cache is never refreshed)")
+	}
+
+	cached, err := client.CachedReleases()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warning("No cached releases (run with --refresh or --import)")
+		} else {
+			logger.Warning(fmt.Sprintf("Cached releases are unreadable: %v", err))
+		}
+		return
+	}
+
+	logger.Info(fmt.Sprintf("Cached releases: %d (updated %s)", len(cached.Releases), cached.LastUpdated.Format("2006-01-02 15:04")))
+	if latest, found := cached.Releases.Latest(); found {
+		logger.Info(fmt.Sprintf("Latest: %s", latest.Number()))
+	}
+	if lts, found := cached.Releases.LatestLTS(0); found {
+		logger.Info(fmt.Sprintf("Latest LTS: %s (%s)", lts.Number(), lts.Codename()))
+	}
+}
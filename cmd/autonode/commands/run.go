@@ -5,17 +5,15 @@ import (
 	"os"
 
 	"github.com/matutetandil/autonode/internal/core"
-	"github.com/matutetandil/autonode/internal/detectors"
-	"github.com/matutetandil/autonode/internal/managers"
-	"github.com/matutetandil/autonode/internal/switchers"
 	"github.com/spf13/cobra"
 )
 
 // RunCommand implements the main autonode command (detect and switch versions)
 // Single Responsibility Principle: Only responsible for version detection and switching
 type RunCommand struct {
-	checkOnly bool
-	force     bool
+	checkOnly  bool
+	force      bool
+	jsonOutput bool
 }
 
 // init registers this command automatically when the package is imported
@@ -29,12 +27,14 @@ func (c *RunCommand) GetCobraCommand() *cobra.Command {
 		Use:   "autonode",
 		Short: "Automatically detect and switch Node.js versions",
 		Long: `AutoNode detects the required Node.js version from your project
-and automatically switches to it using your installed version manager (nvm, nvs, or volta).`,
+and automatically switches to it using your installed version manager (nvm, nvs, volta, fnm, asdf, or mise),
+or installs Node.js itself into ~/.autonode/versions when none is available.`,
 		RunE: c.run,
 	}
 
 	cmd.Flags().BoolVarP(&c.checkOnly, "check", "c", false, "Only check and display the detected version without switching")
 	cmd.Flags().BoolVarP(&c.force, "force", "f", false, "Force reinstall the version even if already installed")
+	cmd.Flags().BoolVar(&c.jsonOutput, "json", false, "With --check, print the detection result as JSON")
 
 	return cmd
 }
@@ -48,6 +48,10 @@ func (c *RunCommand) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
+	if c.jsonOutput && !c.checkOnly {
+		return fmt.Errorf("--json can only be used with --check")
+	}
+
 	// Create configuration
 	config := core.Config{
 		ProjectPath: projectPath,
@@ -65,46 +69,14 @@ func (c *RunCommand) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create cache manager: %w", err)
 	}
 
-	// Create Node.js releases client (for Dockerfile codename resolution)
-	releasesClient := core.NewNodeReleasesClient(cache, logger)
-
-	// Create all version detectors
-	// Open/Closed Principle: Adding new detectors doesn't require modifying existing code
-	// Priority order: .autonode.yml (0) > .nvmrc (1) > .node-version (2) > package.json (3) > Dockerfile (4)
-	detectorsList := []core.VersionDetector{
-		detectors.NewAutonodeYmlVersionDetector(),
-		detectors.NewNvmrcDetector(),
-		detectors.NewNodeVersionDetector(),
-		detectors.NewPackageJsonDetector(),
-		detectors.NewDockerfileDetector(releasesClient),
-	}
-
-	// Create all version managers
-	// Open/Closed Principle: Adding new managers doesn't require modifying existing code
-	managersList := []core.VersionManager{
-		managers.NewNvmManager(shell),
-		managers.NewNvsManager(shell),
-		managers.NewVoltaManager(shell),
-	}
-
-	// Create all profile detectors
-	// Open/Closed Principle: Adding new detectors doesn't require modifying existing code
-	profileDetectorsList := []core.ProfileDetector{
-		detectors.NewAutonodeYmlProfileDetector(),
-		detectors.NewPackageJsonProfileDetector(),
-	}
-
-	// Create all profile switchers
-	// Open/Closed Principle: Adding new switchers doesn't require modifying existing code
-	profileSwitchersList := []core.ProfileSwitcher{
-		switchers.NewNpmrcSwitcher(shell),
-		switchers.NewTsNpmrcSwitcher(shell),
-		switchers.NewRcManagerSwitcher(shell),
+	// JSON mode: the report is the only output, so the service runs silently
+	if c.jsonOutput {
+		service := newService(core.NewNullLogger(), shell, cache)
+		return printJSON(cmd.OutOrStdout(), core.NewCheckReport(service.Diagnose(projectPath)))
 	}
 
-	// Create the main service with all dependencies injected
-	// Dependency Inversion Principle: Service depends on abstractions (interfaces)
-	service := core.NewAutoNodeService(logger, detectorsList, managersList, profileDetectorsList, profileSwitchersList)
+	// Create the main service with all detectors, managers and switchers injected
+	service := newService(logger, shell, cache)
 
 	// Run the service
 	return service.Run(config)
@@ -2,17 +2,17 @@ package commands
 
 import (
 	"os"
+	"strings"
 
 	"github.com/matutetandil/autonode/internal/core"
-	"github.com/matutetandil/autonode/internal/detectors"
-	"github.com/matutetandil/autonode/internal/managers"
-	"github.com/matutetandil/autonode/internal/switchers"
 	"github.com/spf13/cobra"
 )
 
 // ShellCommand implements the shell integration command
 // Single Responsibility Principle: Only responsible for outputting shell commands for eval
-type ShellCommand struct{}
+type ShellCommand struct {
+	shell string // Shell to write output for
+}
 
 // init registers this command automatically when the package is imported
 func init() {
@@ -21,18 +21,35 @@ func init() {
 
 // GetCobraCommand returns the cobra command for this command
 func (c *ShellCommand) GetCobraCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "shell",
 		Short: "Output shell commands for eval (used by shell integration)",
 		Long: `Outputs shell commands to switch Node.js version.
-Used by the shell integration hook. Usage: eval "$(autonode shell)"`,
-		RunE: c.run,
+Used by the shell integration hook:
+
+  bash, zsh:  eval "$(autonode shell)"
+  fish:       autonode shell --shell fish | source
+  pwsh:       autonode shell --shell pwsh | Out-String | Invoke-Expression
+  elvish:     eval (autonode shell --shell elvish | slurp)
+  nu:         autonode shell --shell nu | from json   (a record of changes for the hook to apply)`,
+		// Output is eval'd by the shell: nothing else may be printed to stdout
+		Annotations: map[string]string{MachineReadableAnnotation: "true"},
+		RunE:        c.run,
 	}
+
+	cmd.Flags().StringVar(&c.shell, "shell", "bash", "Shell to write commands for ("+strings.Join(core.ShellNames, ", ")+")")
+
+	return cmd
 }
 
 // run outputs shell commands for eval integration using AutoNodeService
 // This is used by the shell hook for automatic version switching
 func (c *ShellCommand) run(cmd *cobra.Command, args []string) error {
+	// An unknown shell is a mistake in the hook, not a runtime failure: report it
+	if _, err := core.NewShellSyntax(c.shell); err != nil {
+		return err
+	}
+
 	// Get current working directory
 	projectPath, err := os.Getwd()
 	if err != nil {
@@ -44,6 +61,7 @@ func (c *ShellCommand) run(cmd *cobra.Command, args []string) error {
 	config := core.Config{
 		ProjectPath: projectPath,
 		ShellMode:   true, // This tells the service to output commands instead of executing them
+		Shell:       c.shell,
 	}
 
 	// Dependency Injection: Create all concrete implementations
@@ -58,41 +76,8 @@ func (c *ShellCommand) run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Create Node.js releases client (for Dockerfile codename resolution)
-	releasesClient := core.NewNodeReleasesClient(cache, logger)
-
-	// Create all version detectors
-	// Priority order: .autonode.yml (0) > .nvmrc (1) > .node-version (2) > package.json (3) > Dockerfile (4)
-	detectorsList := []core.VersionDetector{
-		detectors.NewAutonodeYmlVersionDetector(),
-		detectors.NewNvmrcDetector(),
-		detectors.NewNodeVersionDetector(),
-		detectors.NewPackageJsonDetector(),
-		detectors.NewDockerfileDetector(releasesClient),
-	}
-
-	// Create all version managers
-	managersList := []core.VersionManager{
-		managers.NewNvmManager(shell),
-		managers.NewNvsManager(shell),
-		managers.NewVoltaManager(shell),
-	}
-
-	// Create all profile detectors
-	profileDetectorsList := []core.ProfileDetector{
-		detectors.NewAutonodeYmlProfileDetector(),
-		detectors.NewPackageJsonProfileDetector(),
-	}
-
-	// Create all profile switchers
-	profileSwitchersList := []core.ProfileSwitcher{
-		switchers.NewNpmrcSwitcher(shell),
-		switchers.NewTsNpmrcSwitcher(shell),
-		switchers.NewRcManagerSwitcher(shell),
-	}
-
-	// Create the service with all dependencies
-	service := core.NewAutoNodeService(logger, detectorsList, managersList, profileDetectorsList, profileSwitchersList)
+	// Create the main service with all detectors, managers and switchers injected
+	service := newService(logger, shell, cache)
 
 	// Run the service in shell mode (outputs commands, doesn't execute them)
 	return service.Run(config)
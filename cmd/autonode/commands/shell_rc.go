@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// shellHookMarkers are the commands a shell hook runs: `autonode init` for hooks set up
+// with it, `autonode shell` for hooks written by older versions of install.sh
+var shellHookMarkers = []string{"autonode init", "autonode shell"}
+
+// shellRCFile is the startup file a shell reads, where the autonode hook is installed
+type shellRCFile struct {
+	Shell string
+	File  string
+}
+
+// shellRCFiles returns the startup file of every shell `autonode init` supports
+func shellRCFiles(homeDir string) []shellRCFile {
+	zdotdir := os.Getenv("ZDOTDIR")
+	if zdotdir == "" {
+		zdotdir = homeDir
+	}
+
+	// $PROFILE for the current user on the current host
+	pwshProfile := filepath.Join(homeDir, ".config", "powershell", "Microsoft.PowerShell_profile.ps1")
+	if runtime.GOOS == "windows" {
+		pwshProfile = filepath.Join(homeDir, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1")
+	}
+
+	return []shellRCFile{
+		{Shell: "bash", File: filepath.Join(homeDir, ".bashrc")},
+		{Shell: "zsh", File: filepath.Join(zdotdir, ".zshrc")},
+		{Shell: "fish", File: filepath.Join(homeDir, ".config", "fish", "config.fish")},
+		{Shell: "pwsh", File: pwshProfile},
+	}
+}
+
+// hasShellHook reports whether the content of a startup file runs the autonode hook
+func hasShellHook(content string) bool {
+	for _, marker := range shellHookMarkers {
+		if strings.Contains(content, marker) {
+			return true
+		}
+	}
+	return false
+}
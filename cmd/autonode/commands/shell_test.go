@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// shellHookBudget is how long `autonode shell` may take in an unchanged project,
+// since the shell hook runs it on every cd. Checked by BenchmarkShellCommand_UnchangedProject.
+const shellHookBudget = 5 * time.Millisecond
+
+// setupShellHookProject creates a monorepo package in a temporary HOME, makes it the
+// working directory and discards stdout, as the hook sees it after its first switch.
+// Returns the detection cache directory.
+func setupShellHookProject(tb testing.TB) string {
+	tb.Helper()
+
+	home := tb.TempDir()
+	tb.Setenv("HOME", home)
+	// The shell already switched once, so the active version isn't looked up again
+	tb.Setenv(core.PreviousNodeEnvVar, "")
+
+	repo := filepath.Join(home, "repo")
+	pkg := filepath.Join(repo, "packages", "app")
+	files := map[string]string{
+		filepath.Join(repo, ".nvmrc"):                         "20.11.0\n",
+		filepath.Join(repo, "package.json"):                   "{\n  \"packageManager\": \"pnpm@9.1.0\"\n}\n",
+		filepath.Join(repo, ".github", "workflows", "ci.yml"): "jobs: {}\n",
+		filepath.Join(pkg, "package.json"):                    "{\n  \"name\": \"app\"\n}\n",
+		filepath.Join(pkg, "Dockerfile"):                      "FROM node:20-alpine\n",
+		filepath.Join(repo, ".git", "HEAD"):                   "ref: refs/heads/main\n",
+	}
+	// Files and directories are backdated, so the detection cache doesn't consider them racy
+	past := time.Now().Add(-time.Hour)
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			tb.Fatalf("failed to create directory for %s: %v", path, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			tb.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+	filepath.WalkDir(repo, func(path string, _ os.DirEntry, err error) error {
+		if err == nil {
+			err = os.Chtimes(path, past, past)
+		}
+		if err != nil {
+			tb.Fatalf("failed to set mtime of %s: %v", path, err)
+		}
+		return nil
+	})
+
+	wd, err := os.Getwd()
+	if err != nil {
+		tb.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(pkg); err != nil {
+		tb.Fatalf("failed to change directory: %v", err)
+	}
+	tb.Cleanup(func() { os.Chdir(wd) })
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		tb.Fatalf("failed to open %s: %v", os.DevNull, err)
+	}
+	stdout := os.Stdout
+	os.Stdout = devNull
+	tb.Cleanup(func() {
+		os.Stdout = stdout
+		devNull.Close()
+	})
+
+	return filepath.Join(home, ".autonode", core.DetectionCacheDir)
+}
+
+func BenchmarkShellCommand(b *testing.B) {
+	cacheDir := setupShellHookProject(b)
+	command := &ShellCommand{}
+
+	b.Run("cached", func(b *testing.B) {
+		command.run(nil, nil) // Populates the detection cache
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			command.run(nil, nil)
+		}
+	})
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			os.RemoveAll(cacheDir)
+			b.StartTimer()
+			command.run(nil, nil)
+		}
+	})
+}
+
+func TestShellCommand_CachesUnchangedProject(t *testing.T) {
+	cacheDir := setupShellHookProject(t)
+	command := &ShellCommand{}
+
+	if err := command.run(nil, nil); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	// Every detector lists its source files, so the project was cached
+	if entries, err := os.ReadDir(cacheDir); err != nil || len(entries) != 1 {
+		t.Fatalf("detection cache holds %d entries (%v), want 1", len(entries), err)
+	}
+}
+
+// BenchmarkShellCommand_UnchangedProject checks a cached run against shellHookBudget:
+// go test ./cmd/autonode/commands -run '^$' -bench UnchangedProject
+func BenchmarkShellCommand_UnchangedProject(b *testing.B) {
+	setupShellHookProject(b)
+	command := &ShellCommand{}
+
+	command.run(nil, nil) // Populates the detection cache
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		command.run(nil, nil)
+	}
+	perRun := time.Since(start) / time.Duration(b.N)
+	b.StopTimer()
+
+	b.ReportMetric(float64(shellHookBudget.Nanoseconds()), "budget-ns/op")
+	if perRun > shellHookBudget {
+		b.Errorf("autonode shell took %v per run in an unchanged project, budget is %v", perRun, shellHookBudget)
+	}
+}
@@ -48,6 +48,13 @@ func (c *UpdateCommand) run(cmd *cobra.Command, args []string) error {
 	// so we get it from the cobra command which has it set)
 	currentVersion := cmd.Root().Version
 
+	// Updating downloads from GitHub, which offline mode forbids
+	if cache, err := core.NewCacheManager(); err == nil {
+		if globalConfig, _ := core.LoadGlobalConfig(cache); globalConfig.IsOffline() {
+			return fmt.Errorf("cannot update autonode: %w", core.ErrOffline)
+		}
+	}
+
 	logger.Info("Checking for updates...")
 
 	// Fetch latest release info from GitHub API
@@ -17,6 +17,9 @@ var version = "0.7.0"
 // noUpdateCheck disables the automatic update check (set via --no-update-check flag)
 var noUpdateCheck bool
 
+// offline disables every network access (set via --offline flag)
+var offline bool
+
 // GetVersion returns the current version of autonode
 func GetVersion() string {
 	return version
@@ -49,6 +52,8 @@ func main() {
 
 	// Add global flag to disable update check (useful for CI/CD)
 	rootCmd.PersistentFlags().BoolVar(&noUpdateCheck, "no-update-check", false, "Disable automatic update check")
+	// Add global flag to never access the network (air-gapped machines)
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "Never access the network (use cached releases and installed versions only)")
 
 	// Add all other commands as subcommands
 	for _, cmd := range allCommands {
@@ -60,31 +65,26 @@ func main() {
 	}
 
 	// Start async update check before executing command
+	// Flags are only parsed once the command runs, so this happens in the pre-run hook
 	var updateChecker *core.UpdateChecker
-	cache, cacheErr := core.NewCacheManager()
-	if !noUpdateCheck && cacheErr == nil {
-		// Load global config to check if update check is disabled
-		globalConfig, _ := core.LoadGlobalConfig(cache)
-		if !globalConfig.DisableUpdateCheck {
-			updateChecker = core.NewUpdateChecker(cache, version)
-			// Apply custom interval if configured
-			if globalConfig.UpdateCheckIntervalDays > 0 {
-				updateChecker.SetCheckInterval(
-					time.Duration(globalConfig.UpdateCheckIntervalDays) * 24 * time.Hour,
-				)
-			}
-			updateChecker.StartAsyncCheck()
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if offline {
+			// Commands read offline mode from the environment, like the offline setting
+			os.Setenv(core.OfflineEnvVar, "true")
 		}
+		updateChecker = startUpdateCheck()
 	}
 
 	// Execute
-	if err := rootCmd.Execute(); err != nil {
+	executedCmd, err := rootCmd.ExecuteC()
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
 	// Show update notification if available (after command completes)
-	if updateChecker != nil {
+	// Skipped when the output is meant for other programs (shell eval, --json)
+	if updateChecker != nil && !commands.IsMachineReadable(executedCmd) {
 		result := updateChecker.GetResult()
 		if result != nil && result.UpdateAvailable {
 			notifier := core.NewUpdateNotifier(core.NewConsoleLogger())
@@ -92,3 +92,28 @@ func main() {
 		}
 	}
 }
+
+// startUpdateCheck starts the async update check unless it is disabled
+// by --no-update-check, the global configuration or offline mode
+func startUpdateCheck() *core.UpdateChecker {
+	cache, err := core.NewCacheManager()
+	if noUpdateCheck || err != nil {
+		return nil
+	}
+
+	// Load global config to check if update check is disabled
+	globalConfig, _ := core.LoadGlobalConfig(cache)
+	if globalConfig.DisableUpdateCheck || globalConfig.IsOffline() {
+		return nil
+	}
+
+	updateChecker := core.NewUpdateChecker(cache, version)
+	// Apply custom interval if configured
+	if globalConfig.UpdateCheckIntervalDays > 0 {
+		updateChecker.SetCheckInterval(
+			time.Duration(globalConfig.UpdateCheckIntervalDays) * 24 * time.Hour,
+		)
+	}
+	updateChecker.StartAsyncCheck()
+	return updateChecker
+}
@@ -133,6 +133,8 @@ install_binary() {
 }
 
 # Setup shell integration
+# The hooks ship with the binary: 'autonode init <shell> --install' adds one line to the
+# shell's startup file that loads them, so 'autonode update' keeps them current
 setup_shell_integration() {
     echo -e "${BLUE}→ Setting up shell integration...${NC}"
 
@@ -140,14 +142,9 @@ setup_shell_integration() {
     local user_shell=$(basename "$SHELL")
 
     case "$user_shell" in
-        bash)
-            setup_bash
-            ;;
-        zsh)
-            setup_zsh
-            ;;
-        fish)
-            setup_fish
+        bash|zsh|fish|pwsh)
+            "$INSTALL_DIR/autonode" init "$user_shell" --install
+            echo -e "${YELLOW}→ Restart your terminal to activate it${NC}"
             ;;
         *)
             echo -e "${YELLOW}⚠ Unknown shell: $user_shell${NC}"
@@ -158,123 +155,28 @@ setup_shell_integration() {
     esac
 }
 
-# Bash integration
-setup_bash() {
-    local bashrc="$HOME/.bashrc"
-    local hook_marker="# AutoNode - automatic Node.js version switching"
-
-    if grep -q "$hook_marker" "$bashrc" 2>/dev/null; then
-        echo -e "${GREEN}✓ Shell integration already configured in $bashrc${NC}"
-        return
-    fi
-
-    echo -e "${BLUE}→ Adding hook to $bashrc...${NC}"
-
-    cat >> "$bashrc" << 'EOF'
-
-# AutoNode - automatic Node.js version switching
-autonode_hook() {
-  eval "$(autonode shell 2>/dev/null)"
-}
-autonode_cd() {
-  builtin cd "$@" && autonode_hook
-}
-alias cd='autonode_cd'
-autonode_hook  # Run on shell startup
-EOF
-
-    echo -e "${GREEN}✓ Shell integration configured${NC}"
-    echo -e "${YELLOW}→ Run: source ~/.bashrc  (or restart your terminal)${NC}"
-}
-
-# Zsh integration
-setup_zsh() {
-    local zshrc="$HOME/.zshrc"
-    local hook_marker="# AutoNode - automatic Node.js version switching"
-
-    if grep -q "$hook_marker" "$zshrc" 2>/dev/null; then
-        echo -e "${GREEN}✓ Shell integration already configured in $zshrc${NC}"
-        return
-    fi
-
-    echo -e "${BLUE}→ Adding hook to $zshrc...${NC}"
-
-    cat >> "$zshrc" << 'EOF'
-
-# AutoNode - automatic Node.js version switching
-autonode_hook() {
-  eval "$(autonode shell 2>/dev/null)"
-}
-autonode_cd() {
-  builtin cd "$@" && autonode_hook
-}
-alias cd='autonode_cd'
-autonode_hook  # Run on shell startup
-EOF
-
-    echo -e "${GREEN}✓ Shell integration configured${NC}"
-    echo -e "${YELLOW}→ Run: source ~/.zshrc  (or restart your terminal)${NC}"
-}
-
-# Fish integration
-setup_fish() {
-    local fish_config="$HOME/.config/fish/config.fish"
-    local hook_marker="# AutoNode - automatic Node.js version switching"
-
-    mkdir -p "$(dirname "$fish_config")"
-
-    if grep -q "$hook_marker" "$fish_config" 2>/dev/null; then
-        echo -e "${GREEN}✓ Shell integration already configured in $fish_config${NC}"
-        return
-    fi
-
-    echo -e "${BLUE}→ Adding hook to $fish_config...${NC}"
-
-    cat >> "$fish_config" << 'EOF'
-
-# AutoNode - automatic Node.js version switching
-function autonode_hook
-    autonode shell 2>/dev/null | source
-end
-function cd
-    builtin cd $argv; and autonode_hook
-end
-autonode_hook  # Run on shell startup
-EOF
-
-    echo -e "${GREEN}✓ Shell integration configured${NC}"
-    echo -e "${YELLOW}→ Run: source ~/.config/fish/config.fish  (or restart your terminal)${NC}"
-}
-
 # Show manual instructions
 show_manual_instructions() {
     cat << 'EOF'
 
 Manual Setup Instructions:
 --------------------------
-Add the following to your shell configuration file:
+Add the line for your shell to its configuration file
+(or run: autonode init <bash|zsh|fish|pwsh> --install):
 
 For Bash (~/.bashrc):
-  autonode_hook() {
-    eval "$(autonode shell 2>/dev/null)"
-  }
-  autonode_cd() {
-    builtin cd "$@" && autonode_hook
-  }
-  alias cd='autonode_cd'
-  autonode_hook
+  eval "$(autonode init bash)"
 
 For Zsh (~/.zshrc):
-  Same as Bash
+  eval "$(autonode init zsh)"
 
 For Fish (~/.config/fish/config.fish):
-  function autonode_hook
-    autonode shell 2>/dev/null | source
-  end
-  function cd
-    builtin cd $argv; and autonode_hook
-  end
-  autonode_hook
+  autonode init fish | source
+
+For PowerShell ($PROFILE):
+  Invoke-Expression (& autonode init pwsh | Out-String)
+
+For Nushell and Elvish, see "Shell Support" in docs/configuration.md.
 
 EOF
 }
@@ -0,0 +1,12 @@
+package core
+
+// ActiveProfileReader is an optional interface for profile switchers that can
+// report which npm profile is currently active.
+// It lets shell mode restore the previous profile when leaving a project.
+//
+// Interface Segregation Principle: Kept separate from ProfileSwitcher because not
+// every profile tool exposes the active profile
+type ActiveProfileReader interface {
+	// GetActiveProfile returns the active profile name, or empty string if none is active
+	GetActiveProfile() (string, error)
+}
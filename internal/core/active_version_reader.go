@@ -0,0 +1,13 @@
+package core
+
+// ActiveVersionReader is an optional interface for version managers that can tell, from
+// the calling shell's environment alone, which Node.js version they made active.
+// Shell mode uses it to skip switching when the requested version is already active.
+//
+// Interface Segregation Principle: Kept separate from VersionManager because not
+// every manager leaves a trace of the active version in the environment
+type ActiveVersionReader interface {
+	// GetActiveVersion returns the version active in the calling shell (without "v" prefix),
+	// or empty string if it can't be told without running commands
+	GetActiveVersion() string
+}
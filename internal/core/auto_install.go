@@ -0,0 +1,36 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AutoInstallPolicy is what shell mode does when the Node.js version it is about to switch
+// to is not installed
+type AutoInstallPolicy string
+
+const (
+	// AutoInstallNever switches without checking, leaving a missing version to the manager
+	AutoInstallNever AutoInstallPolicy = "never"
+	// AutoInstallPrompt asks whether to install on a terminal, and prints a hint otherwise
+	AutoInstallPrompt AutoInstallPolicy = "prompt"
+	// AutoInstallAlways installs the version before switching to it
+	AutoInstallAlways AutoInstallPolicy = "always"
+
+	// DefaultAutoInstall is the policy used when none is configured
+	DefaultAutoInstall = AutoInstallNever
+)
+
+// AutoInstallPolicies are the accepted values of autoInstall
+var AutoInstallPolicies = []AutoInstallPolicy{AutoInstallNever, AutoInstallPrompt, AutoInstallAlways}
+
+// ParseAutoInstallPolicy returns the policy named by value (case-insensitive)
+func ParseAutoInstallPolicy(value string) (AutoInstallPolicy, error) {
+	policy := AutoInstallPolicy(strings.ToLower(strings.TrimSpace(value)))
+	for _, accepted := range AutoInstallPolicies {
+		if policy == accepted {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("invalid autoInstall '%s' (expected one of: never, prompt, always)", value)
+}
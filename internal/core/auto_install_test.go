@@ -0,0 +1,208 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// installingManager is a VersionManager test double that tracks installed versions
+type installingManager struct {
+	stubManager
+	installed map[string]bool
+}
+
+func (m *installingManager) IsVersionInstalled(version string) (bool, error) {
+	return m.installed[version], nil
+}
+
+func (m *installingManager) InstallVersion(version string) error {
+	m.installed[version] = true
+	return nil
+}
+
+// stubPrompter is a Prompter test double that answers every question the same way
+type stubPrompter struct {
+	answer    bool
+	questions []string
+	messages  []string
+}
+
+func (p *stubPrompter) Notify(message string) { p.messages = append(p.messages, message) }
+func (p *stubPrompter) Confirm(question string) bool {
+	p.questions = append(p.questions, question)
+	return p.answer
+}
+
+// fixedSettingsReader is a ProjectSettingsReader test double with the same settings everywhere
+type fixedSettingsReader struct {
+	settings ProjectSettings
+}
+
+func (r *fixedSettingsReader) ReadSettings(string) (ProjectSettings, bool, error) {
+	return r.settings, r.settings != (ProjectSettings{}), nil
+}
+
+func TestAutoNodeService_RunShellMode_AutoInstall(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(project, ".nvmrc"), []byte("20.11.0"), 0644); err != nil {
+		t.Fatalf("failed to write .nvmrc: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		global        AutoInstallPolicy
+		project       AutoInstallPolicy
+		answer        bool
+		offline       bool
+		declined      string
+		wantInstalled bool
+		wantSwitch    bool
+		wantQuestion  bool
+		wantMessage   string
+	}{
+		{
+			name:       "never switches without checking",
+			global:     AutoInstallNever,
+			wantSwitch: true,
+		},
+		{
+			name:          "prompt installs when the user agrees",
+			global:        AutoInstallPrompt,
+			answer:        true,
+			wantQuestion:  true,
+			wantSwitch:    true,
+			wantInstalled: true,
+			wantMessage:   "installing Node.js 20.11.0",
+		},
+		{
+			name:         "prompt reports the version when the user declines",
+			global:       AutoInstallPrompt,
+			wantQuestion: true,
+			wantMessage:  "Node.js 20.11.0 is not installed, run autonode",
+		},
+		{
+			name:     "prompt doesn't ask again in the same shell",
+			global:   AutoInstallPrompt,
+			declined: "20.11.0",
+		},
+		{
+			name:          "always installs without asking",
+			global:        AutoInstallAlways,
+			wantSwitch:    true,
+			wantInstalled: true,
+			wantMessage:   "installing Node.js 20.11.0",
+		},
+		{
+			name:        "offline reports the version",
+			global:      AutoInstallAlways,
+			offline:     true,
+			wantMessage: "offline mode",
+		},
+		{
+			name:          "project policy wins over the global one",
+			global:        AutoInstallNever,
+			project:       AutoInstallAlways,
+			wantSwitch:    true,
+			wantInstalled: true,
+			wantMessage:   "installing Node.js 20.11.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &installingManager{stubManager: stubManager{name: "nvm"}, installed: map[string]bool{}}
+			prompter := &stubPrompter{answer: tt.answer}
+			service := NewAutoNodeService(
+				NewNullLogger(),
+				[]VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}},
+				[]VersionManager{manager},
+				nil, nil,
+			)
+			service.SetAutoInstall(tt.global)
+			service.SetProjectSettingsReader(&fixedSettingsReader{settings: ProjectSettings{AutoInstall: tt.project}})
+			service.SetPrompter(prompter)
+			service.SetOffline(tt.offline)
+
+			t.Setenv(PreviousNodeEnvVar, "18.17.0")
+			t.Setenv(NodeBinEnvVar, "")
+			t.Setenv(DeclinedInstallEnvVar, tt.declined)
+			unsetEnv(t, "NVM_BIN")
+
+			output := captureStdout(t, func() {
+				service.runShellMode(Config{ProjectPath: project, ShellMode: true})
+			})
+
+			if manager.installed["20.11.0"] != tt.wantInstalled {
+				t.Errorf("installed = %v, want %v", manager.installed["20.11.0"], tt.wantInstalled)
+			}
+			if switched := strings.Contains(output, "nvm use 20.11.0"); switched != tt.wantSwitch {
+				t.Errorf("switched = %v, want %v:\n%s", switched, tt.wantSwitch, output)
+			}
+			if asked := len(prompter.questions) > 0; asked != tt.wantQuestion {
+				t.Errorf("asked = %v, want %v", asked, tt.wantQuestion)
+			}
+			messages := strings.Join(prompter.messages, "\n")
+			if tt.wantMessage == "" && messages != "" || !strings.Contains(messages, tt.wantMessage) {
+				t.Errorf("messages = %q, want %q", messages, tt.wantMessage)
+			}
+			if declined := strings.Contains(output, DeclinedInstallEnvVar); declined != (!tt.wantSwitch && tt.declined == "") {
+				t.Errorf("output records the declined version = %v:\n%s", declined, output)
+			}
+		})
+	}
+}
+
+// locatedManager is a VersionManager test double that finds every version on disk
+// and fails the test if asked whether one is installed
+type locatedManager struct {
+	dirManager
+	t *testing.T
+}
+
+func (m *locatedManager) IsVersionInstalled(version string) (bool, error) {
+	m.t.Errorf("IsVersionInstalled(%q) called for a version found on disk", version)
+	return false, nil
+}
+
+func TestAutoNodeService_EnsureVersionInstalled_FoundOnDisk(t *testing.T) {
+	manager := &locatedManager{dirManager: dirManager{stubManager: stubManager{name: "nvm"}, root: "/versions"}, t: t}
+	service := NewAutoNodeService(NewNullLogger(), nil, []VersionManager{manager}, nil, nil)
+	service.SetPrompter(&stubPrompter{})
+
+	for _, policy := range []AutoInstallPolicy{AutoInstallPrompt, AutoInstallAlways} {
+		out, _ := NewShellSyntax("bash")
+		result := DetectionResult{Found: true, Version: "20.11.0"}
+		if _, ok := service.ensureVersionInstalled(out, manager, result, policy, ShellState{}); !ok {
+			t.Errorf("ensureVersionInstalled() with %s = false, want true", policy)
+		}
+	}
+}
+
+func TestParseAutoInstallPolicy(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    AutoInstallPolicy
+		wantErr bool
+	}{
+		{"never", AutoInstallNever, false},
+		{"prompt", AutoInstallPrompt, false},
+		{" Always ", AutoInstallAlways, false},
+		{"", "", true},
+		{"yes", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAutoInstallPolicy(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseAutoInstallPolicy(%q) = %q, %v, want %q (error: %v)", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -32,6 +32,11 @@ func NewCacheManager() (*CacheManager, error) {
 	}, nil
 }
 
+// GetCacheDir returns the cache directory (~/.autonode)
+func (c *CacheManager) GetCacheDir() string {
+	return c.cacheDir
+}
+
 // GetCacheFilePath returns the full path to a cache file
 func (c *CacheManager) GetCacheFilePath(filename string) string {
 	return filepath.Join(c.cacheDir, filename)
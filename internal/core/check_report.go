@@ -0,0 +1,70 @@
+package core
+
+// CheckSchemaVersion is the version of the CheckReport JSON schema.
+// It only changes when fields are renamed, removed or change meaning;
+// adding fields keeps the same version.
+const CheckSchemaVersion = 1
+
+// CheckReport is the machine-readable result of `autonode --check --json`
+// Single Responsibility Principle: Only holds the detection result consumed by tooling
+type CheckReport struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	ProjectPath   string `json:"projectPath"`
+	// Found is true when a Node.js version was detected
+	Found bool `json:"found"`
+	// Version is the detected version, resolved to an installed or released version when it was a range
+	Version string `json:"version"`
+	// Range is the original version range when Version was resolved from one
+	Range string `json:"range"`
+	// Source is the absolute path of the file the version came from
+	Source string `json:"source"`
+	// Profile is the detected npm profile
+	Profile string `json:"profile"`
+	// ProfileSource is where the npm profile came from
+	ProfileSource string `json:"profileSource"`
+	// PackageManager is the pinned package manager, "<name>@<version>" (empty if none)
+	PackageManager string `json:"packageManager"`
+	// PackageManagerSource is the file the package manager came from
+	PackageManagerSource string `json:"packageManagerSource"`
+	// Manager is the version manager that would switch versions (empty if none is installed)
+	Manager string `json:"manager"`
+	// Installed reports whether Manager has Version installed (null if unknown)
+	Installed *bool `json:"installed"`
+	// Errors lists the detectors that failed to read their source
+	Errors []CheckError `json:"errors"`
+}
+
+// CheckError is a detector that failed while checking the project
+type CheckError struct {
+	Detector string `json:"detector"`
+	Error    string `json:"error"`
+}
+
+// NewCheckReport builds the check report from a diagnostic report
+func NewCheckReport(diagnostics DiagnosticReport) CheckReport {
+	report := CheckReport{
+		SchemaVersion:        CheckSchemaVersion,
+		ProjectPath:          diagnostics.ProjectPath,
+		Found:                diagnostics.DetectedVersion != "",
+		Version:              diagnostics.DetectedVersion,
+		Range:                diagnostics.DetectedRange,
+		Source:               diagnostics.DetectedSource,
+		Profile:              diagnostics.DetectedProfile,
+		ProfileSource:        diagnostics.DetectedProfileSource,
+		PackageManager:       diagnostics.DetectedPackageManager,
+		PackageManagerSource: diagnostics.DetectedPackageManagerSource,
+		Manager:              diagnostics.SelectedManager,
+		Installed:            diagnostics.VersionInstalled,
+		Errors:               []CheckError{},
+	}
+
+	for _, detectors := range [][]DetectorDiagnostic{diagnostics.VersionDetectors, diagnostics.ProfileDetectors, diagnostics.PackageManagerDetectors} {
+		for _, detector := range detectors {
+			if detector.Error != "" {
+				report.Errors = append(report.Errors, CheckError{Detector: detector.Name, Error: detector.Error})
+			}
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestNewCheckReport(t *testing.T) {
+	installed := true
+	diagnostics := DiagnosticReport{
+		ProjectPath: "/work/app",
+		VersionDetectors: []DetectorDiagnostic{
+			{Name: ".nvmrc", Found: true, Value: "20.11.0", Source: "/work/app/.nvmrc"},
+			{Name: "package.json", Error: "failed to parse package.json"},
+		},
+		ProfileDetectors: []DetectorDiagnostic{
+			{Name: ".autonode.yml", Error: "failed to parse .autonode.yml"},
+		},
+		PackageManagerDetectors: []DetectorDiagnostic{
+			{Name: "package.json", Found: true, Value: "pnpm@9.1.0", Source: "/work/app/package.json"},
+		},
+		DetectedVersion:              "20.11.0",
+		DetectedSource:               "/work/app/.nvmrc",
+		DetectedProfile:              "work",
+		DetectedProfileSource:        "package.json",
+		DetectedPackageManager:       "pnpm@9.1.0",
+		DetectedPackageManagerSource: "/work/app/package.json",
+		SelectedManager:              "nvm",
+		VersionInstalled:             &installed,
+	}
+
+	report := NewCheckReport(diagnostics)
+
+	want := CheckReport{
+		SchemaVersion:        CheckSchemaVersion,
+		ProjectPath:          "/work/app",
+		Found:                true,
+		Version:              "20.11.0",
+		Source:               "/work/app/.nvmrc",
+		Profile:              "work",
+		ProfileSource:        "package.json",
+		PackageManager:       "pnpm@9.1.0",
+		PackageManagerSource: "/work/app/package.json",
+		Manager:              "nvm",
+		Installed:            &installed,
+		Errors: []CheckError{
+			{Detector: "package.json", Error: "failed to parse package.json"},
+			{Detector: ".autonode.yml", Error: "failed to parse .autonode.yml"},
+		},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("NewCheckReport() = %+v, want %+v", report, want)
+	}
+}
+
+// TestCheckReport_Schema guards the JSON field names tooling relies on:
+// changing them requires bumping CheckSchemaVersion
+func TestCheckReport_Schema(t *testing.T) {
+	data, err := json.Marshal(NewCheckReport(DiagnosticReport{ProjectPath: "/work/app"}))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	var keys []string
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	wantKeys := []string{
+		"errors", "found", "installed", "manager", "packageManager", "packageManagerSource",
+		"profile", "profileSource", "projectPath", "range", "schemaVersion", "source", "version",
+	}
+	if !reflect.DeepEqual(keys, wantKeys) {
+		t.Errorf("JSON fields = %v, want %v", keys, wantKeys)
+	}
+
+	if fields["schemaVersion"] != float64(1) {
+		t.Errorf("schemaVersion = %v, want 1", fields["schemaVersion"])
+	}
+	if fields["installed"] != nil {
+		t.Errorf("installed = %v, want null when unknown", fields["installed"])
+	}
+	if errs, ok := fields["errors"].([]interface{}); !ok || len(errs) != 0 {
+		t.Errorf("errors = %v, want empty array", fields["errors"])
+	}
+}
@@ -6,5 +6,6 @@ type Config struct {
 	ProjectPath string
 	CheckOnly   bool
 	Force       bool
-	ShellMode   bool // When true, outputs shell commands instead of executing them
+	ShellMode   bool   // When true, outputs shell commands instead of executing them
+	Shell       string // Shell the ShellMode output is for (bash, zsh, fish, pwsh, nu, elvish); bash when empty
 }
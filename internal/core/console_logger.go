@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/fatih/color"
 )
@@ -9,34 +10,42 @@ import (
 // ConsoleLogger implements the Logger interface using colored console output
 // Single Responsibility Principle: Only responsible for logging to console
 // Dependency Inversion Principle: Depends on Logger interface, can be swapped with other implementations
-type ConsoleLogger struct{}
+type ConsoleLogger struct {
+	out io.Writer
+}
 
-// NewConsoleLogger creates a new ConsoleLogger instance
+// NewConsoleLogger creates a new ConsoleLogger instance that writes to stdout
 func NewConsoleLogger() *ConsoleLogger {
-	return &ConsoleLogger{}
+	return &ConsoleLogger{out: color.Output}
+}
+
+// NewStderrConsoleLogger creates a ConsoleLogger that writes to stderr,
+// for commands whose stdout belongs to something else (e.g. a child process)
+func NewStderrConsoleLogger() *ConsoleLogger {
+	return &ConsoleLogger{out: color.Error}
 }
 
 // Info logs an informational message in cyan
 func (l *ConsoleLogger) Info(message string) {
 	cyan := color.New(color.FgCyan)
-	cyan.Println(message)
+	cyan.Fprintln(l.out, message)
 }
 
 // Success logs a success message in green
 func (l *ConsoleLogger) Success(message string) {
 	green := color.New(color.FgGreen)
-	green.Println("✓", message)
+	green.Fprintln(l.out, "✓", message)
 }
 
 // Error logs an error message in red
 func (l *ConsoleLogger) Error(message string) {
 	red := color.New(color.FgRed)
-	red.Println("✗", message)
+	red.Fprintln(l.out, "✗", message)
 }
 
 // Warning logs a warning message in yellow
 func (l *ConsoleLogger) Warning(message string) {
 	yellow := color.New(color.FgYellow)
-	fmt.Print("⚠ ")
-	yellow.Println(message)
+	fmt.Fprint(l.out, "⚠ ")
+	yellow.Fprintln(l.out, message)
 }
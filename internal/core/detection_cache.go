@@ -0,0 +1,182 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// DetectionCacheDir is the directory under ~/.autonode holding one detection per project directory
+	DetectionCacheDir = "detection-cache"
+	// DetectionCacheTTL bounds how long a detection is trusted. Source files are fingerprinted,
+	// but the installed tools and Node.js versions it also depends on are not.
+	DetectionCacheTTL = time.Hour
+	// maxDetectionCacheEntries is the number of entries above which expired ones are removed
+	maxDetectionCacheEntries = 512
+)
+
+// DetectionCache remembers what shell mode detected for each directory, together with
+// fingerprints (mtime, size, inode) of every file the detectors consulted, so repeated
+// runs in an unchanged project skip parsing files and looking for installed tools.
+// Single Responsibility Principle: Only responsible for storing and validating detections
+type DetectionCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// detectionCacheEntry is the file stored for one directory
+type detectionCacheEntry struct {
+	Dir       string            `json:"dir"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Detection ProjectDetection  `json:"detection"`
+	Files     []fileFingerprint `json:"files"`
+}
+
+// fileFingerprint identifies the state of a file; a missing file is fingerprinted too,
+// so creating it invalidates the entry
+type fileFingerprint struct {
+	Path    string `json:"path"`
+	Exists  bool   `json:"exists"`
+	ModTime int64  `json:"modTime,omitempty"` // Nanoseconds since the Unix epoch
+	Size    int64  `json:"size,omitempty"`
+	Inode   uint64 `json:"inode,omitempty"` // 0 where the platform has no inodes
+}
+
+// NewDetectionCache creates a new DetectionCache stored in ~/.autonode/detection-cache
+func NewDetectionCache(cache *CacheManager) *DetectionCache {
+	return &DetectionCache{
+		dir: cache.GetCacheFilePath(DetectionCacheDir),
+		ttl: DetectionCacheTTL,
+		now: time.Now,
+	}
+}
+
+// Lookup returns the detection stored for dir if it is younger than the TTL and none
+// of the files it was detected from changed since
+func (c *DetectionCache) Lookup(dir string) (ProjectDetection, bool) {
+	data, err := os.ReadFile(c.entryPath(dir))
+	if err != nil {
+		return ProjectDetection{}, false
+	}
+
+	var entry detectionCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Dir != dir {
+		return ProjectDetection{}, false
+	}
+
+	if age := c.now().Sub(entry.CreatedAt); age < 0 || age >= c.ttl {
+		return ProjectDetection{}, false
+	}
+
+	// A file modified within a second of the entry being written may change again
+	// without its mtime moving on filesystems with coarse timestamps
+	racy := entry.CreatedAt.Add(-time.Second).UnixNano()
+
+	for _, stored := range entry.Files {
+		if stored.Exists && stored.ModTime >= racy {
+			return ProjectDetection{}, false
+		}
+		if fingerprintFile(stored.Path) != stored {
+			return ProjectDetection{}, false
+		}
+	}
+
+	return entry.Detection, true
+}
+
+// Store saves the detection for dir along with fingerprints of the given files
+// The entry is written to a temporary file and renamed, so concurrent shells never read a partial entry
+func (c *DetectionCache) Store(dir string, detection ProjectDetection, files []string) error {
+	entry := detectionCacheEntry{
+		Dir:       dir,
+		CreatedAt: c.now(),
+		Detection: detection,
+	}
+
+	seen := make(map[string]bool)
+	for _, path := range files {
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		entry.Files = append(entry.Files, fingerprintFile(path))
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), c.entryPath(dir)); err != nil {
+		return err
+	}
+
+	c.prune()
+	return nil
+}
+
+// Clear removes every stored detection (e.g. after installing a Node.js version, which
+// can change the version a range resolves to)
+func (c *DetectionCache) Clear() error {
+	return os.RemoveAll(c.dir)
+}
+
+// prune removes expired entries once the cache holds more than maxDetectionCacheEntries
+func (c *DetectionCache) prune() {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil || len(entries) <= maxDetectionCacheEntries {
+		return
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err == nil && c.now().Sub(info.ModTime()) >= c.ttl {
+			os.Remove(filepath.Join(c.dir, entry.Name()))
+		}
+	}
+}
+
+// entryPath returns the file storing the detection for dir
+func (c *DetectionCache) entryPath(dir string) string {
+	sum := sha256.Sum256([]byte(dir))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+// fingerprintFile returns the current fingerprint of path
+func fingerprintFile(path string) fileFingerprint {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileFingerprint{Path: path}
+	}
+
+	return fileFingerprint{
+		Path:    path,
+		Exists:  true,
+		ModTime: info.ModTime().UnixNano(),
+		Size:    info.Size(),
+		Inode:   fileInode(info),
+	}
+}
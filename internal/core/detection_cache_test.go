@@ -0,0 +1,146 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDetectionCache_Lookup(t *testing.T) {
+	detection := ProjectDetection{
+		Version: DetectionResult{Found: true, Version: "20.11.0", Source: ".nvmrc", Line: 1},
+		Manager: "nvm",
+	}
+
+	// Files are written well before the entry so they are never racy
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		change func(t *testing.T, project string, cache *DetectionCache)
+		want   bool
+	}{
+		{
+			name:   "unchanged project",
+			change: func(*testing.T, string, *DetectionCache) {},
+			want:   true,
+		},
+		{
+			name: "modified file",
+			change: func(t *testing.T, project string, _ *DetectionCache) {
+				writeTestFile(t, filepath.Join(project, ".nvmrc"), "22.12.0", past.Add(time.Minute))
+			},
+			want: false,
+		},
+		{
+			name: "created file",
+			change: func(t *testing.T, project string, _ *DetectionCache) {
+				writeTestFile(t, filepath.Join(project, ".node-version"), "18", past)
+			},
+			want: false,
+		},
+		{
+			name: "removed file",
+			change: func(t *testing.T, project string, _ *DetectionCache) {
+				os.Remove(filepath.Join(project, ".nvmrc"))
+			},
+			want: false,
+		},
+		{
+			name: "replaced file with same mtime and size",
+			change: func(t *testing.T, project string, _ *DetectionCache) {
+				path := filepath.Join(project, ".nvmrc")
+				writeTestFile(t, path+".new", "20.11.1", past)
+				if err := os.Rename(path+".new", path); err != nil {
+					t.Fatalf("failed to replace .nvmrc: %v", err)
+				}
+			},
+			want: false,
+		},
+		{
+			name: "expired entry",
+			change: func(_ *testing.T, _ string, cache *DetectionCache) {
+				cache.now = func() time.Time { return time.Now().Add(DetectionCacheTTL) }
+			},
+			want: false,
+		},
+		{
+			name: "cleared",
+			change: func(t *testing.T, _ string, cache *DetectionCache) {
+				if err := cache.Clear(); err != nil {
+					t.Fatalf("Clear() error = %v", err)
+				}
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := t.TempDir()
+			writeTestFile(t, filepath.Join(project, ".nvmrc"), "20.11.0", past)
+
+			cache := NewDetectionCache(&CacheManager{cacheDir: t.TempDir()})
+			files := []string{filepath.Join(project, ".nvmrc"), filepath.Join(project, ".node-version")}
+			if err := cache.Store(project, detection, files); err != nil {
+				t.Fatalf("Store() error = %v", err)
+			}
+
+			tt.change(t, project, cache)
+
+			got, ok := cache.Lookup(project)
+			if ok != tt.want {
+				t.Fatalf("Lookup() ok = %v, want %v", ok, tt.want)
+			}
+			if ok && got != detection {
+				t.Errorf("Lookup() = %+v, want %+v", got, detection)
+			}
+		})
+	}
+}
+
+func TestDetectionCache_RacyFile(t *testing.T) {
+	project := t.TempDir()
+	path := filepath.Join(project, ".nvmrc")
+	writeTestFile(t, path, "20", time.Now())
+
+	cache := NewDetectionCache(&CacheManager{cacheDir: t.TempDir()})
+	if err := cache.Store(project, ProjectDetection{Manager: "nvm"}, []string{path}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	// The file could still change within its mtime granularity: the entry is not trusted
+	if _, ok := cache.Lookup(project); ok {
+		t.Error("Lookup() trusted an entry for a file modified as it was written")
+	}
+}
+
+func TestDetectionCache_KeyedByDirectory(t *testing.T) {
+	cache := NewDetectionCache(&CacheManager{cacheDir: t.TempDir()})
+	for _, dir := range []string{"/work/a", "/work/b"} {
+		if err := cache.Store(dir, ProjectDetection{Manager: dir}, nil); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+	}
+
+	for _, dir := range []string{"/work/a", "/work/b"} {
+		if got, ok := cache.Lookup(dir); !ok || got.Manager != dir {
+			t.Errorf("Lookup(%q) = %+v, %v", dir, got, ok)
+		}
+	}
+	if _, ok := cache.Lookup("/work/c"); ok {
+		t.Error("Lookup() found an entry for a directory never stored")
+	}
+}
+
+// writeTestFile writes content to path and sets its modification time
+func writeTestFile(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("failed to set mtime of %s: %v", path, err)
+	}
+}
@@ -6,4 +6,6 @@ type DetectionResult struct {
 	Found   bool
 	Version string
 	Source  string
+	Range   string // Original version range when Version was resolved from one (e.g. ">=18 <21")
+	Line    int    // 1-based line of the version in Source (0 if unknown)
 }
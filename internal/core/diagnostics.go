@@ -0,0 +1,188 @@
+package core
+
+// DiagnosticReport describes how the service sees a project: what every detector
+// found, which managers and switchers are available, and which ones would be used.
+// Single Responsibility Principle: Only holds diagnostic data
+type DiagnosticReport struct {
+	ProjectPath       string                `json:"projectPath"`
+	SearchDirectories []string              `json:"searchDirectories"`
+	VersionDetectors  []DetectorDiagnostic  `json:"versionDetectors"`
+	ProfileDetectors  []DetectorDiagnostic  `json:"profileDetectors"`
+	VersionManagers   []ComponentDiagnostic `json:"versionManagers"`
+	ProfileSwitchers  []ComponentDiagnostic `json:"profileSwitchers"`
+	// Package manager detectors and switchers are omitted when the service has none
+	PackageManagerDetectors []DetectorDiagnostic  `json:"packageManagerDetectors,omitempty"`
+	PackageManagerSwitchers []ComponentDiagnostic `json:"packageManagerSwitchers,omitempty"`
+	// DetectedVersion is the version the detector chain resolves to (empty if none)
+	DetectedVersion string `json:"detectedVersion,omitempty"`
+	// DetectedRange is the version range the detected version was resolved from (empty if none)
+	DetectedRange string `json:"detectedRange,omitempty"`
+	// DetectedSource is the file the detected version came from
+	DetectedSource string `json:"detectedSource,omitempty"`
+	// DetectedProfile is the npm profile the detector chain resolves to (empty if none)
+	DetectedProfile string `json:"detectedProfile,omitempty"`
+	// DetectedProfileSource is where the detected npm profile came from
+	DetectedProfileSource string `json:"detectedProfileSource,omitempty"`
+	// DetectedPackageManager is the pinned package manager, "<name>@<version>" (empty if none)
+	DetectedPackageManager string `json:"detectedPackageManager,omitempty"`
+	// DetectedPackageManagerSource is the file the package manager came from
+	DetectedPackageManagerSource string `json:"detectedPackageManagerSource,omitempty"`
+	// SelectedManager is the version manager that would be used (empty if none is installed)
+	SelectedManager string `json:"selectedManager,omitempty"`
+	// VersionInstalled reports whether the selected manager has the detected version
+	// (nil when there is no version or manager, or the check failed)
+	VersionInstalled *bool `json:"versionInstalled,omitempty"`
+	// SelectedSwitcher is the profile switcher that would be used (empty if none is installed)
+	SelectedSwitcher string `json:"selectedSwitcher,omitempty"`
+	// SelectedPackageManagerSwitcher is the tool that would activate the package manager (empty if none is installed)
+	SelectedPackageManagerSwitcher string `json:"selectedPackageManagerSwitcher,omitempty"`
+}
+
+// DetectorDiagnostic is the outcome of one detector for the project
+// The detector is tried in each search directory until it finds something or fails
+type DetectorDiagnostic struct {
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+	Found    bool   `json:"found"`
+	Value    string `json:"value,omitempty"` // Detected version, profile name or package manager
+	Range    string `json:"range,omitempty"`
+	Source   string `json:"source,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ComponentDiagnostic reports whether a version manager or profile switcher is installed
+type ComponentDiagnostic struct {
+	Name      string `json:"name"`
+	Installed bool   `json:"installed"`
+	Selected  bool   `json:"selected"`
+}
+
+// Diagnose inspects the project without switching anything
+// Runs every detector (not just until the first match) so the report shows what each one sees
+func (s *AutoNodeService) Diagnose(projectPath string) DiagnosticReport {
+	dirs := searchDirectories(projectPath)
+	report := DiagnosticReport{
+		ProjectPath:       projectPath,
+		SearchDirectories: dirs,
+	}
+
+	for _, detector := range s.detectors {
+		diagnostic := DetectorDiagnostic{Name: detector.GetSourceName(), Priority: detector.GetPriority()}
+		for _, dir := range dirs {
+			result, err := detector.Detect(dir)
+			if err != nil {
+				diagnostic.Error = err.Error()
+				break
+			}
+			if result.Found {
+				diagnostic.Found = true
+				diagnostic.Value = result.Version
+				diagnostic.Range = result.Range
+				diagnostic.Source = result.Source
+				break
+			}
+		}
+		report.VersionDetectors = append(report.VersionDetectors, diagnostic)
+	}
+
+	for _, detector := range s.profileDetectors {
+		diagnostic := DetectorDiagnostic{Name: detector.GetSourceName(), Priority: detector.GetPriority()}
+		for _, dir := range dirs {
+			result, err := detector.Detect(dir)
+			if err != nil {
+				diagnostic.Error = err.Error()
+				break
+			}
+			if result.Found {
+				diagnostic.Found = true
+				diagnostic.Value = result.ProfileName
+				diagnostic.Source = result.Source
+				break
+			}
+		}
+		report.ProfileDetectors = append(report.ProfileDetectors, diagnostic)
+	}
+
+	for _, detector := range s.packageManagerDetectors {
+		diagnostic := DetectorDiagnostic{Name: detector.GetSourceName(), Priority: detector.GetPriority()}
+		for _, dir := range dirs {
+			result, err := detector.Detect(dir)
+			if err != nil {
+				diagnostic.Error = err.Error()
+				break
+			}
+			if result.Found {
+				diagnostic.Found = true
+				diagnostic.Value = result.Reference()
+				diagnostic.Source = result.Source
+				break
+			}
+		}
+		report.PackageManagerDetectors = append(report.PackageManagerDetectors, diagnostic)
+	}
+
+	var selected VersionManager
+	for _, manager := range s.managers {
+		installed := manager.IsInstalled()
+		isSelected := installed && selected == nil
+		if isSelected {
+			selected = manager
+			report.SelectedManager = manager.GetName()
+		}
+		report.VersionManagers = append(report.VersionManagers, ComponentDiagnostic{
+			Name:      manager.GetName(),
+			Installed: installed,
+			Selected:  isSelected,
+		})
+	}
+
+	for _, switcher := range s.profileSwitchers {
+		installed := switcher.IsInstalled()
+		isSelected := installed && report.SelectedSwitcher == ""
+		if isSelected {
+			report.SelectedSwitcher = switcher.GetName()
+		}
+		report.ProfileSwitchers = append(report.ProfileSwitchers, ComponentDiagnostic{
+			Name:      switcher.GetName(),
+			Installed: installed,
+			Selected:  isSelected,
+		})
+	}
+
+	for _, switcher := range s.packageManagerSwitchers {
+		installed := switcher.IsInstalled()
+		isSelected := installed && report.SelectedPackageManagerSwitcher == ""
+		if isSelected {
+			report.SelectedPackageManagerSwitcher = switcher.GetName()
+		}
+		report.PackageManagerSwitchers = append(report.PackageManagerSwitchers, ComponentDiagnostic{
+			Name:      switcher.GetName(),
+			Installed: installed,
+			Selected:  isSelected,
+		})
+	}
+
+	if result, err := s.detectVersion(projectPath); err == nil && result.Found {
+		report.DetectedSource = result.Source
+		report.DetectedRange = result.Range
+		if selected != nil {
+			result = s.resolveDetectedVersion(selected, result)
+			if installed, err := selected.IsVersionInstalled(result.Version); err == nil {
+				report.VersionInstalled = &installed
+			}
+		}
+		report.DetectedVersion = result.Version
+	}
+
+	if result, err := s.detectProfile(projectPath); err == nil && result.Found {
+		report.DetectedProfile = result.ProfileName
+		report.DetectedProfileSource = result.Source
+	}
+
+	if result, err := s.detectPackageManager(projectPath); err == nil && result.Found {
+		report.DetectedPackageManager = result.Reference()
+		report.DetectedPackageManagerSource = result.Source
+	}
+
+	return report
+}
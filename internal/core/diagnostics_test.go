@@ -0,0 +1,129 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// failingDetector is a VersionDetector test double that always fails
+type failingDetector struct{}
+
+func (d *failingDetector) Detect(string) (DetectionResult, error) {
+	return DetectionResult{}, errors.New("invalid file")
+}
+func (d *failingDetector) GetPriority() int      { return 9 }
+func (d *failingDetector) GetSourceName() string { return "broken" }
+
+// missingManager is a VersionManager test double that is not installed
+type missingManager struct {
+	stubManager
+}
+
+func (m *missingManager) IsInstalled() bool { return false }
+
+func TestAutoNodeService_Diagnose(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	subdir := filepath.Join(project, "src")
+	if err := os.MkdirAll(subdir, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", subdir, err)
+	}
+	if err := os.WriteFile(filepath.Join(project, ".nvmrc"), []byte("20.11.0"), 0644); err != nil {
+		t.Fatalf("failed to write .nvmrc: %v", err)
+	}
+
+	service := NewAutoNodeService(
+		NewNullLogger(),
+		[]VersionDetector{
+			&fileDetector{fileName: ".node-version", priority: 2},
+			&fileDetector{fileName: ".nvmrc", priority: 1},
+			&failingDetector{},
+		},
+		[]VersionManager{
+			&missingManager{stubManager{name: "nvs"}},
+			&stubManager{name: "nvm"},
+			&stubManager{name: "volta"},
+		},
+		nil, nil,
+	)
+
+	report := service.Diagnose(subdir)
+
+	if len(report.SearchDirectories) != 3 || report.SearchDirectories[0] != subdir {
+		t.Errorf("SearchDirectories = %v", report.SearchDirectories)
+	}
+
+	// Detectors are reported in priority order, each with its own outcome
+	wantDetectors := []DetectorDiagnostic{
+		{Name: ".nvmrc", Priority: 1, Found: true, Value: "20.11.0", Source: filepath.Join(project, ".nvmrc")},
+		{Name: ".node-version", Priority: 2},
+		{Name: "broken", Priority: 9, Error: "invalid file"},
+	}
+	if len(report.VersionDetectors) != len(wantDetectors) {
+		t.Fatalf("VersionDetectors = %+v", report.VersionDetectors)
+	}
+	for i, want := range wantDetectors {
+		if report.VersionDetectors[i] != want {
+			t.Errorf("VersionDetectors[%d] = %+v, want %+v", i, report.VersionDetectors[i], want)
+		}
+	}
+
+	wantManagers := []ComponentDiagnostic{
+		{Name: "nvs", Installed: false, Selected: false},
+		{Name: "nvm", Installed: true, Selected: true},
+		{Name: "volta", Installed: true, Selected: false},
+	}
+	for i, want := range wantManagers {
+		if report.VersionManagers[i] != want {
+			t.Errorf("VersionManagers[%d] = %+v, want %+v", i, report.VersionManagers[i], want)
+		}
+	}
+
+	if report.SelectedManager != "nvm" {
+		t.Errorf("SelectedManager = %q, want nvm", report.SelectedManager)
+	}
+	if report.DetectedVersion != "20.11.0" {
+		t.Errorf("DetectedVersion = %q, want 20.11.0", report.DetectedVersion)
+	}
+	if report.VersionInstalled == nil || !*report.VersionInstalled {
+		t.Errorf("VersionInstalled = %v, want true", report.VersionInstalled)
+	}
+	if report.SelectedSwitcher != "" || report.DetectedProfile != "" {
+		t.Errorf("unexpected profile diagnostics: %+v", report)
+	}
+}
+
+func TestAutoNodeService_Diagnose_ResolvesAlias(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", project, err)
+	}
+	if err := os.WriteFile(filepath.Join(project, ".nvmrc"), []byte("lts/iron"), 0644); err != nil {
+		t.Fatalf("failed to write .nvmrc: %v", err)
+	}
+
+	// volta doesn't understand lts/iron, so doctor reports the version it is asked for
+	service := NewAutoNodeService(
+		NewNullLogger(),
+		[]VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}},
+		[]VersionManager{&stubManager{name: "volta"}},
+		nil, nil,
+	)
+	service.SetReleaseIndex(&fixedReleaseIndex{index: testIndex})
+
+	report := service.Diagnose(project)
+
+	if report.DetectedVersion != "20.18.1" {
+		t.Errorf("DetectedVersion = %q, want 20.18.1", report.DetectedVersion)
+	}
+	if report.VersionDetectors[0].Value != "lts/iron" {
+		t.Errorf("VersionDetectors[0].Value = %q, want the alias as written", report.VersionDetectors[0].Value)
+	}
+}
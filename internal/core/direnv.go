@@ -0,0 +1,80 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NodeVersionEnvVar holds the Node.js version `autonode direnv` activated
+const NodeVersionEnvVar = "NODE_VERSION"
+
+// direnvMarkers are the .envrc contents that hand a project's version to `autonode direnv`
+var direnvMarkers = []string{"use autonode", "use_autonode", "autonode direnv"}
+
+// DirenvScript returns .envrc code that activates the project's Node.js version under
+// direnv: watch_file for every version source, so direnv reloads when one changes, then
+// PATH_add for the version's bin directory and NODE_VERSION. direnv diffs the environment
+// itself and reverts the diff on leaving, so unlike shell mode no state is recorded.
+// Nothing is installed: a missing version is reported with log_error.
+func (s *AutoNodeService) DirenvScript(projectPath string) (string, error) {
+	dir, err := filepath.Abs(projectPath)
+	if err != nil {
+		dir = projectPath
+	}
+
+	var script scriptLines
+	if files, ok := s.sourceFiles(dir); ok && len(files) > 0 {
+		// Several detectors read the same file (package.json, .autonode.yml)
+		seen := make(map[string]bool)
+		var quoted []string
+		for _, file := range files {
+			if !seen[file] {
+				seen[file] = true
+				quoted = append(quoted, shellQuote(file))
+			}
+		}
+		script.add("watch_file %s", strings.Join(quoted, " "))
+	}
+
+	manager, result, err := s.resolveProjectVersion(dir)
+	if err != nil {
+		return "", err
+	}
+
+	installed, err := manager.IsVersionInstalled(result.Version)
+	if err == nil && !installed {
+		script.add("log_error %s", shellQuote(fmt.Sprintf("autonode: Node.js %s is not installed, run autonode to install it", result.Version)))
+		return script.String(), nil
+	}
+
+	bin, err := nodeBinDir(manager, result.Version)
+	if err != nil {
+		return "", fmt.Errorf("failed to locate Node.js %s with %s: %w", result.Version, manager.GetName(), err)
+	}
+
+	script.add("PATH_add %s", shellQuote(bin))
+	script.add("export %s=%s", NodeVersionEnvVar, shellQuote(result.Version))
+
+	return script.String(), nil
+}
+
+// direnvManaged reports whether the .envrc nearest to projectPath activates the version
+// with `autonode direnv`. The cd hook leaves such projects to direnv: both run on every
+// directory change, in an order that depends on the shell.
+func direnvManaged(projectPath string) bool {
+	for _, dir := range searchDirectories(projectPath) {
+		data, err := os.ReadFile(filepath.Join(dir, ".envrc"))
+		if err != nil {
+			continue
+		}
+		for _, marker := range direnvMarkers {
+			if strings.Contains(string(data), marker) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
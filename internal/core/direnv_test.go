@@ -0,0 +1,113 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// emptyManager is a VersionManager test double with no Node.js versions installed
+type emptyManager struct {
+	stubManager
+}
+
+func (m *emptyManager) IsVersionInstalled(string) (bool, error) { return false, nil }
+
+func TestAutoNodeService_DirenvScript(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(project, ".nvmrc"), []byte("20.11.0"), 0644); err != nil {
+		t.Fatalf("failed to write .nvmrc: %v", err)
+	}
+	detectors := []VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}}
+
+	tests := []struct {
+		name    string
+		manager VersionManager
+		want    []string
+	}{
+		{
+			name:    "installed version",
+			manager: &dirManager{stubManager: stubManager{name: "autonode"}, root: "/home/user/.autonode/versions"},
+			want: []string{
+				"'" + filepath.Join(project, ".nvmrc") + "'",
+				"PATH_add '/home/user/.autonode/versions/20.11.0/bin'",
+				"export NODE_VERSION='20.11.0'",
+			},
+		},
+		{
+			name:    "missing version",
+			manager: &emptyManager{stubManager: stubManager{name: "autonode"}},
+			want: []string{
+				"'" + filepath.Join(project, ".nvmrc") + "'",
+				"log_error 'autonode: Node.js 20.11.0 is not installed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewAutoNodeService(NewNullLogger(), detectors, []VersionManager{tt.manager}, nil, nil)
+
+			script, err := service.DirenvScript(project)
+			if err != nil {
+				t.Fatalf("DirenvScript() error = %v", err)
+			}
+			// direnv reloads when a version file changes
+			if !strings.HasPrefix(script, "watch_file ") {
+				t.Errorf("script does not start with watch_file:\n%s", script)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(script, want) {
+					t.Errorf("script does not contain %q:\n%s", want, script)
+				}
+			}
+		})
+	}
+
+	t.Run("no version file", func(t *testing.T) {
+		service := NewAutoNodeService(NewNullLogger(), detectors, []VersionManager{&stubManager{name: "autonode"}}, nil, nil)
+		if _, err := service.DirenvScript(tempHome); err == nil {
+			t.Error("DirenvScript() expected error without a version file")
+		}
+	})
+}
+
+func TestAutoNodeService_RunShellMode_LeavesDirenvProjects(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	nested := filepath.Join(project, "src")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("failed to create dirs: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(project, ".nvmrc"), []byte("20.11.0"), 0644); err != nil {
+		t.Fatalf("failed to write .nvmrc: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(project, ".envrc"), []byte("dotenv\nuse autonode\n"), 0644); err != nil {
+		t.Fatalf("failed to write .envrc: %v", err)
+	}
+
+	service := NewAutoNodeService(
+		NewNullLogger(),
+		[]VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}},
+		[]VersionManager{&dirManager{stubManager: stubManager{name: "autonode"}, root: "/home/user/.autonode/versions"}},
+		nil, nil,
+	)
+	t.Setenv(PreviousNodeEnvVar, "")
+	t.Setenv(NodeBinEnvVar, "")
+
+	output := captureStdout(t, func() {
+		service.runShellMode(Config{ProjectPath: nested, ShellMode: true})
+	})
+	if output != "" {
+		t.Errorf("shell mode output in a direnv project:\n%s", output)
+	}
+}
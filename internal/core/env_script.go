@@ -0,0 +1,68 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// EnvScript returns commands, in the syntax of shell, that put the project's Node.js version
+// first on PATH along with the variables its manager's own switch exports (nvm's NVM_BIN and
+// NVM_INC). Versions the manager locates on disk are read from there, so nvm.sh and nvs.sh
+// are never sourced; otherwise node is asked where it runs from through the manager.
+// Nothing is installed: a missing version is an error.
+func (s *AutoNodeService) EnvScript(projectPath, shell string) (string, error) {
+	out, err := NewShellSyntax(shell)
+	if err != nil {
+		return "", err
+	}
+
+	manager, result, err := s.resolveProjectVersion(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	installed, err := manager.IsVersionInstalled(result.Version)
+	if err == nil && !installed {
+		return "", fmt.Errorf("Node.js %s is not installed (run 'autonode' to install it)", result.Version)
+	}
+
+	state := LoadShellState()
+	if !emitInstallDir(out, manager, result.Version, state) {
+		bin, err := nodeBinDir(manager, result.Version)
+		if err != nil {
+			return "", fmt.Errorf("failed to locate Node.js %s with %s: %w", result.Version, manager.GetName(), err)
+		}
+		emitBinOnPath(out, bin, state)
+		emitManagerEnv(out, manager, filepath.Dir(bin))
+	}
+
+	return out.String(), nil
+}
+
+// resolveProjectVersion detects the project's Node.js version and the manager to use it
+// with, resolving aliases and partial versions the way a switch would
+func (s *AutoNodeService) resolveProjectVersion(projectPath string) (VersionManager, DetectionResult, error) {
+	dir, err := filepath.Abs(projectPath)
+	if err != nil {
+		dir = projectPath
+	}
+
+	result, err := s.detectVersion(dir)
+	if err != nil {
+		return nil, result, err
+	}
+	if !result.Found {
+		return nil, result, fmt.Errorf("no Node.js version specification found in project")
+	}
+
+	manager, err := s.findVersionManager()
+	if err != nil {
+		return nil, result, err
+	}
+
+	result, err = s.resolveVersionAlias(manager, result)
+	if err != nil {
+		return nil, result, err
+	}
+	return manager, s.preferInstalledVersion(manager, result), nil
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAutoNodeService_EnvScript(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+	t.Setenv(NodeBinEnvVar, "")
+	t.Setenv("PATH", "/usr/bin")
+
+	project := filepath.Join(tempHome, "project")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(project, ".nvmrc"), []byte("20.11.0"), 0644); err != nil {
+		t.Fatalf("failed to write .nvmrc: %v", err)
+	}
+	detectors := []VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}}
+
+	tests := []struct {
+		name    string
+		manager VersionManager
+		shell   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "located on disk",
+			manager: &dirManager{stubManager: stubManager{name: "nvm"}, root: "/home/user/.nvm/versions/node"},
+			shell:   "bash",
+			want: []string{
+				"export PATH='/home/user/.nvm/versions/node/20.11.0/bin:/usr/bin'",
+				"export NVM_BIN='/home/user/.nvm/versions/node/20.11.0/bin'",
+			},
+		},
+		{
+			name:    "located through the manager",
+			manager: &shellManager{stubManager{name: "fnm"}},
+			shell:   "fish",
+			want:    []string{"set -gx PATH '/opt/node/20.11.0/bin' '/usr/bin'"},
+		},
+		{
+			name:    "not installed",
+			manager: &emptyManager{stubManager: stubManager{name: "nvm"}},
+			shell:   "bash",
+			wantErr: true,
+		},
+		{
+			name:    "unknown shell",
+			manager: &dirManager{stubManager: stubManager{name: "nvm"}, root: "/home/user/.nvm/versions/node"},
+			shell:   "tcsh",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewAutoNodeService(NewNullLogger(), detectors, []VersionManager{tt.manager}, nil, nil)
+
+			script, err := service.EnvScript(project, tt.shell)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("EnvScript() = %q, want error", script)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EnvScript() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(script, want) {
+					t.Errorf("script does not contain %q:\n%s", want, script)
+				}
+			}
+		})
+	}
+}
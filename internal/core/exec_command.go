@@ -0,0 +1,89 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// PrepareExec detects the project's Node.js version, installs it if missing and returns
+// a command that runs args under that version, wired to this process's stdin/stdout/stderr.
+// The calling shell is left untouched: only the child process sees the version.
+func (s *AutoNodeService) PrepareExec(projectPath string, args []string) (*exec.Cmd, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no command to run")
+	}
+
+	result, err := s.detectVersion(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Found {
+		return nil, fmt.Errorf("no Node.js version specification found in project")
+	}
+
+	manager, err := s.findVersionManager()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err = s.resolveVersionAlias(manager, result)
+	if err != nil {
+		return nil, err
+	}
+	result = s.preferInstalledVersion(manager, result)
+
+	installed, err := manager.IsVersionInstalled(result.Version)
+	if err != nil {
+		s.logger.Warning(fmt.Sprintf("Could not check if version is installed: %v", err))
+	}
+	if !installed {
+		s.logger.Info(fmt.Sprintf("Installing Node.js %s...", result.Version))
+		if err := s.installVersion(manager, result.Version); err != nil {
+			return nil, fmt.Errorf("failed to install Node.js %s: %w", result.Version, err)
+		}
+		// A partial version or range now resolves to the version just installed
+		result = s.preferInstalledVersion(manager, result)
+	}
+
+	cmd, err := versionCommand(manager, result.Version, args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd, nil
+}
+
+// versionCommand returns a command that runs args under an installed version of the manager
+func versionCommand(manager VersionManager, version string, args []string) (*exec.Cmd, error) {
+	switch m := manager.(type) {
+	case VersionExecutor:
+		execArgs, err := m.ExecArgs(version, args)
+		if err != nil {
+			return nil, err
+		}
+		return exec.Command(execArgs[0], execArgs[1:]...), nil
+	case InstallDirResolver:
+		dir, err := m.ResolveInstallDir(version)
+		if err != nil {
+			return nil, err
+		}
+		bin := filepath.Join(dir, "bin")
+		// Programs of this version ("node", "npm", ...) win over anything else on PATH
+		name := args[0]
+		if filepath.Base(name) == name {
+			if _, err := os.Stat(filepath.Join(bin, name)); err == nil {
+				name = filepath.Join(bin, name)
+			}
+		}
+		cmd := exec.Command(name, args[1:]...)
+		cmd.Env = append(os.Environ(), "PATH="+bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+		return cmd, nil
+	default:
+		return nil, fmt.Errorf("%s cannot run commands under a specific Node.js version", manager.GetName())
+	}
+}
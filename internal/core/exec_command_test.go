@@ -0,0 +1,118 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// execManager is a VersionManager test double that runs commands through a wrapper
+type execManager struct {
+	stubManager
+	installed   bool
+	installedAs string
+}
+
+func (m *execManager) IsVersionInstalled(string) (bool, error) { return m.installed, nil }
+
+func (m *execManager) InstallVersion(version string) error {
+	m.installed = true
+	m.installedAs = version
+	return nil
+}
+
+func (m *execManager) ExecArgs(version string, args []string) ([]string, error) {
+	return append([]string{"wrapper", "--version=" + version}, args...), nil
+}
+
+func TestAutoNodeService_PrepareExec(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", project, err)
+	}
+	if err := os.WriteFile(filepath.Join(project, ".nvmrc"), []byte("20.11.0"), 0644); err != nil {
+		t.Fatalf("failed to write .nvmrc: %v", err)
+	}
+
+	detectors := []VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}}
+
+	t.Run("manager wrapper installs missing version", func(t *testing.T) {
+		manager := &execManager{stubManager: stubManager{name: "nvm"}}
+		service := NewAutoNodeService(NewNullLogger(), detectors, []VersionManager{manager}, nil, nil)
+
+		cmd, err := service.PrepareExec(project, []string{"npm", "test"})
+		if err != nil {
+			t.Fatalf("PrepareExec() error = %v", err)
+		}
+
+		if manager.installedAs != "20.11.0" {
+			t.Errorf("installed %q, want 20.11.0", manager.installedAs)
+		}
+		if want := []string{"wrapper", "--version=20.11.0", "npm", "test"}; !reflect.DeepEqual(cmd.Args, want) {
+			t.Errorf("Args = %q, want %q", cmd.Args, want)
+		}
+		if cmd.Stdin != os.Stdin || cmd.Stdout != os.Stdout || cmd.Stderr != os.Stderr {
+			t.Error("command is not wired to the standard streams")
+		}
+	})
+
+	t.Run("offline mode does not install", func(t *testing.T) {
+		manager := &execManager{stubManager: stubManager{name: "nvm"}}
+		service := NewAutoNodeService(NewNullLogger(), detectors, []VersionManager{manager}, nil, nil)
+		service.SetOffline(true)
+
+		if _, err := service.PrepareExec(project, []string{"npm", "test"}); !errors.Is(err, ErrOffline) {
+			t.Errorf("PrepareExec() error = %v, want %v", err, ErrOffline)
+		}
+		if manager.installed {
+			t.Error("offline mode installed a version")
+		}
+	})
+
+	t.Run("install directory goes first on PATH", func(t *testing.T) {
+		root := filepath.Join(tempHome, "versions")
+		bin := filepath.Join(root, "20.11.0", "bin")
+		if err := os.MkdirAll(bin, 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", bin, err)
+		}
+		if err := os.WriteFile(filepath.Join(bin, "node"), nil, 0755); err != nil {
+			t.Fatalf("failed to write node: %v", err)
+		}
+		t.Setenv("PATH", "/usr/bin")
+
+		manager := &dirManager{stubManager: stubManager{name: "autonode"}, root: root}
+		service := NewAutoNodeService(NewNullLogger(), detectors, []VersionManager{manager}, nil, nil)
+
+		cmd, err := service.PrepareExec(project, []string{"node", "--version"})
+		if err != nil {
+			t.Fatalf("PrepareExec() error = %v", err)
+		}
+
+		if cmd.Path != filepath.Join(bin, "node") {
+			t.Errorf("Path = %q, want %q", cmd.Path, filepath.Join(bin, "node"))
+		}
+		if last := cmd.Env[len(cmd.Env)-1]; last != "PATH="+bin+string(os.PathListSeparator)+"/usr/bin" {
+			t.Errorf("PATH = %q", last)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		service := NewAutoNodeService(NewNullLogger(), detectors, []VersionManager{&stubManager{name: "plain"}}, nil, nil)
+
+		if _, err := service.PrepareExec(project, nil); err == nil {
+			t.Error("PrepareExec() without command expected error")
+		}
+		if _, err := service.PrepareExec(tempHome, []string{"node"}); err == nil || !strings.Contains(err.Error(), "no Node.js version") {
+			t.Errorf("PrepareExec() without version error = %v", err)
+		}
+		if _, err := service.PrepareExec(project, []string{"node"}); err == nil || !strings.Contains(err.Error(), "cannot run commands") {
+			t.Errorf("PrepareExec() with unsupported manager error = %v", err)
+		}
+	})
+}
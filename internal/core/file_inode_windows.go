@@ -0,0 +1,10 @@
+//go:build windows
+
+package core
+
+import "os"
+
+// fileInode returns 0: Windows has no inode numbers, so fingerprints rely on mtime and size
+func fileInode(info os.FileInfo) uint64 {
+	return 0
+}
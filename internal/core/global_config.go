@@ -2,14 +2,28 @@ package core
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
+	"strconv"
+	"strings"
 )
 
 const (
 	// GlobalConfigFile is the filename for global configuration
 	GlobalConfigFile = "config.json"
+	// DefaultNodeMirror is the Node.js distribution site used to download Node.js
+	DefaultNodeMirror = "https://nodejs.org/dist"
+	// NodeMirrorEnvVar overrides the configured Node.js distribution mirror
+	NodeMirrorEnvVar = "AUTONODE_NODE_MIRROR"
+	// NvmMirrorEnvVar is nvm's mirror variable, used when autonode has no mirror configured
+	NvmMirrorEnvVar = "NVM_NODEJS_ORG_MIRROR"
+	// OfflineEnvVar enables (or, set to false, disables) offline mode, overriding the offline setting
+	OfflineEnvVar = "AUTONODE_OFFLINE"
 )
 
+// ErrOffline is returned instead of accessing the network when offline mode is enabled
+var ErrOffline = errors.New("offline mode is enabled")
+
 // GlobalConfig represents the global autonode configuration stored in ~/.autonode/config.json
 // Single Responsibility Principle: Only holds global configuration data
 type GlobalConfig struct {
@@ -17,6 +31,13 @@ type GlobalConfig struct {
 	DisableUpdateCheck bool `json:"disableUpdateCheck,omitempty"`
 	// UpdateCheckInterval is the interval between update checks in days (default: 7)
 	UpdateCheckIntervalDays int `json:"updateCheckIntervalDays,omitempty"`
+	// NodeMirror is the base URL of the Node.js distribution mirror (default: https://nodejs.org/dist)
+	NodeMirror string `json:"nodeMirror,omitempty"`
+	// Offline never accesses the network: only cached release data and installed versions are used
+	Offline bool `json:"offline,omitempty"`
+	// AutoInstall is what the shell hook does when a project's version is not installed:
+	// never, prompt (default) or always
+	AutoInstall string `json:"autoInstall,omitempty"`
 }
 
 // LoadGlobalConfig loads the global configuration from ~/.autonode/config.json
@@ -38,6 +59,41 @@ func LoadGlobalConfig(cache *CacheManager) (*GlobalConfig, error) {
 	return config, nil
 }
 
+// GetNodeMirror returns the Node.js distribution mirror to download from, without trailing slash
+// Priority: AUTONODE_NODE_MIRROR environment variable > nodeMirror setting >
+// NVM_NODEJS_ORG_MIRROR environment variable > nodejs.org
+func (c *GlobalConfig) GetNodeMirror() string {
+	mirror := os.Getenv(NodeMirrorEnvVar)
+	if mirror == "" {
+		mirror = c.NodeMirror
+	}
+	if mirror == "" {
+		mirror = os.Getenv(NvmMirrorEnvVar)
+	}
+	if mirror == "" {
+		mirror = DefaultNodeMirror
+	}
+	return strings.TrimRight(mirror, "/")
+}
+
+// IsOffline reports whether offline mode is enabled
+// Priority: AUTONODE_OFFLINE environment variable (set by --offline) > offline setting
+func (c *GlobalConfig) IsOffline() bool {
+	if offline, err := strconv.ParseBool(os.Getenv(OfflineEnvVar)); err == nil {
+		return offline
+	}
+	return c.Offline
+}
+
+// GetAutoInstall returns the autoInstall policy, DefaultAutoInstall when it is not set or invalid
+func (c *GlobalConfig) GetAutoInstall() AutoInstallPolicy {
+	policy, err := ParseAutoInstallPolicy(c.AutoInstall)
+	if err != nil {
+		return DefaultAutoInstall
+	}
+	return policy
+}
+
 // SaveGlobalConfig saves the global configuration to ~/.autonode/config.json
 func SaveGlobalConfig(cache *CacheManager, config *GlobalConfig) error {
 	data, err := json.MarshalIndent(config, "", "  ")
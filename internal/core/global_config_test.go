@@ -149,3 +149,76 @@ func TestGlobalConfig_OmitEmpty(t *testing.T) {
 		t.Errorf("Empty config should serialize to {}, got: %s", jsonStr)
 	}
 }
+
+func TestGlobalConfig_GetNodeMirror(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		env        string
+		nvmEnv     string
+		want       string
+	}{
+		{"default", "", "", "", DefaultNodeMirror},
+		{"configured", "https://mirror.example.com/node/", "", "", "https://mirror.example.com/node"},
+		{"env overrides config", "https://mirror.example.com/node", "http://localhost:8080", "", "http://localhost:8080"},
+		{"nvm mirror", "", "", "https://npmmirror.com/mirrors/node/", "https://npmmirror.com/mirrors/node"},
+		{"config overrides nvm mirror", "https://mirror.example.com/node", "", "https://npmmirror.com/mirrors/node", "https://mirror.example.com/node"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(NodeMirrorEnvVar, tt.env)
+			t.Setenv(NvmMirrorEnvVar, tt.nvmEnv)
+			config := &GlobalConfig{NodeMirror: tt.configured}
+
+			if got := config.GetNodeMirror(); got != tt.want {
+				t.Errorf("GetNodeMirror() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobalConfig_IsOffline(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured bool
+		env        string
+		want       bool
+	}{
+		{"default", false, "", false},
+		{"configured", true, "", true},
+		{"env enables", false, "1", true},
+		{"env disables", true, "false", false},
+		{"invalid env ignored", true, "maybe", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(OfflineEnvVar, tt.env)
+			config := &GlobalConfig{Offline: tt.configured}
+
+			if got := config.IsOffline(); got != tt.want {
+				t.Errorf("IsOffline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobalConfig_GetAutoInstall(t *testing.T) {
+	tests := []struct {
+		configured string
+		want       AutoInstallPolicy
+	}{
+		{"", AutoInstallNever},
+		{"always", AutoInstallAlways},
+		{"Prompt", AutoInstallPrompt},
+		{"sometimes", AutoInstallNever},
+	}
+
+	for _, tt := range tests {
+		config := &GlobalConfig{AutoInstall: tt.configured}
+		if got := config.GetAutoInstall(); got != tt.want {
+			t.Errorf("GetAutoInstall() with %q = %q, want %q", tt.configured, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package core
+
+// InstallDirResolver is an optional interface for version managers that can locate
+// the directory a Node.js version is installed in (the directory containing bin/node).
+// Shell mode uses it to put that version's bin directory on PATH directly.
+//
+// Interface Segregation Principle: Kept separate from VersionManager so managers
+// that switch versions through their own commands don't have to implement it
+type InstallDirResolver interface {
+	// ResolveInstallDir returns the install directory for an installed version,
+	// or an error if the version is not installed
+	ResolveInstallDir(version string) (string, error)
+}
@@ -0,0 +1,12 @@
+package core
+
+// InstalledVersionLister is an optional interface for version managers that can
+// enumerate the Node.js versions they have installed.
+// It lets the service prefer an installed version when resolving a version range.
+//
+// Interface Segregation Principle: Kept separate from VersionManager so managers
+// that cannot list versions cheaply don't have to implement it
+type InstalledVersionLister interface {
+	// ListInstalledVersions returns installed versions without "v" prefix (e.g., "20.11.0")
+	ListInstalledVersions() ([]string, error)
+}
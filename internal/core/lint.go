@@ -0,0 +1,141 @@
+package core
+
+import (
+	"fmt"
+)
+
+// LintReport is the result of checking every version source of a project against the others
+// Single Responsibility Principle: Only holds lint results
+type LintReport struct {
+	ProjectPath string `json:"projectPath"`
+	// Sources lists every source that declares a version, in the order the service
+	// looks at them; the first one is authoritative
+	Sources []LintSource `json:"sources"`
+	// Conflicts lists the sources whose version can never satisfy the authoritative one
+	Conflicts []LintConflict `json:"conflicts"`
+	// Errors lists the detectors that failed to read their source
+	Errors []CheckError `json:"errors"`
+}
+
+// LintSource is a version declared by one detector
+type LintSource struct {
+	Detector string `json:"detector"`
+	Source   string `json:"source"`
+	Line     int    `json:"line,omitempty"`
+	// Spec is the version or range as declared (e.g. "18", ">=20")
+	Spec string `json:"spec"`
+	// Version is the version the spec resolves to
+	Version string `json:"version"`
+	// Comparable is false when Spec is not a semver range (e.g. "lts/iron", "node")
+	Comparable bool `json:"comparable"`
+
+	detector VersionDetector
+	result   DetectionResult
+	versions *VersionRange
+}
+
+// LintConflict is a source that disagrees with the authoritative source
+type LintConflict struct {
+	Source    LintSource `json:"source"`
+	Authority LintSource `json:"authority"`
+}
+
+// Location returns "file:line", or just the file when the line is unknown
+func (s LintSource) Location() string {
+	if s.Line > 0 {
+		return fmt.Sprintf("%s:%d", s.Source, s.Line)
+	}
+	return s.Source
+}
+
+// Authority returns the source the service would switch to (nil if no source declares a version)
+func (r LintReport) Authority() *LintSource {
+	if len(r.Sources) == 0 {
+		return nil
+	}
+	return &r.Sources[0]
+}
+
+// Lint runs every version detector (not just until the first match) and checks that
+// the versions they find can be satisfied together with the authoritative one, the
+// source detectVersion would pick. Sources are compared as semver ranges: ".nvmrc: 18"
+// conflicts with "engines.node: >=20", "20" and ">=18 <21" don't.
+func (s *AutoNodeService) Lint(projectPath string) LintReport {
+	report := LintReport{
+		ProjectPath: projectPath,
+		Conflicts:   []LintConflict{},
+		Errors:      []CheckError{},
+	}
+
+	// Same order as detectVersion: nearest directory first, then detector priority.
+	// Each detector contributes its nearest source only.
+	done := make(map[VersionDetector]bool)
+	for _, dir := range searchDirectories(projectPath) {
+		for _, detector := range s.detectors {
+			if done[detector] {
+				continue
+			}
+
+			result, err := detector.Detect(dir)
+			if err != nil {
+				done[detector] = true
+				report.Errors = append(report.Errors, CheckError{Detector: detector.GetSourceName(), Error: err.Error()})
+				continue
+			}
+			if !result.Found {
+				continue
+			}
+
+			done[detector] = true
+			report.Sources = append(report.Sources, newLintSource(detector, result))
+		}
+	}
+
+	authority := report.Authority()
+	if authority == nil || !authority.Comparable {
+		return report
+	}
+
+	for _, source := range report.Sources[1:] {
+		if source.Comparable && !source.versions.Intersects(authority.versions) {
+			report.Conflicts = append(report.Conflicts, LintConflict{Source: source, Authority: *authority})
+		}
+	}
+
+	return report
+}
+
+// newLintSource describes a detection result, parsing its spec as a range when possible
+func newLintSource(detector VersionDetector, result DetectionResult) LintSource {
+	spec := result.Range
+	if spec == "" {
+		spec = result.Version
+	}
+
+	source := LintSource{
+		Detector: detector.GetSourceName(),
+		Source:   result.Source,
+		Line:     result.Line,
+		Spec:     spec,
+		Version:  result.Version,
+		detector: detector,
+		result:   result,
+	}
+
+	if versions, err := ParseVersionRange(spec); err == nil {
+		source.Comparable = true
+		source.versions = versions
+	}
+
+	return source
+}
+
+// Fix rewrites the conflicting source to the authoritative version
+// Fails when the source's detector can't edit its file
+func (c LintConflict) Fix() error {
+	fixer, ok := c.Source.detector.(VersionSourceFixer)
+	if !ok {
+		return fmt.Errorf("%s cannot be rewritten automatically", c.Source.Detector)
+	}
+	return fixer.FixVersion(c.Source.result, c.Authority.Version)
+}
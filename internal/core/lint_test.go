@@ -0,0 +1,138 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fixableDetector is a fileDetector test double that can rewrite its file
+type fixableDetector struct {
+	fileDetector
+}
+
+func (d *fixableDetector) FixVersion(result DetectionResult, version string) error {
+	return os.WriteFile(result.Source, []byte(version), 0644)
+}
+
+func TestAutoNodeService_Lint(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	subdir := filepath.Join(project, "web")
+	if err := os.MkdirAll(subdir, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", subdir, err)
+	}
+
+	files := map[string]string{
+		filepath.Join(subdir, ".nvmrc"):          "20",
+		filepath.Join(project, ".nvmrc"):         "16",
+		filepath.Join(project, ".node-version"):  "18",
+		filepath.Join(project, "engines"):        ">=18 <21",
+		filepath.Join(project, ".tool-versions"): "lts/iron",
+		filepath.Join(project, "Dockerfile"):     "22",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	service := NewAutoNodeService(
+		NewNullLogger(),
+		[]VersionDetector{
+			&fileDetector{fileName: ".nvmrc", priority: 1},
+			&fixableDetector{fileDetector{fileName: ".node-version", priority: 2}},
+			&fileDetector{fileName: ".tool-versions", priority: 3},
+			&fileDetector{fileName: "engines", priority: 4},
+			&fileDetector{fileName: "Dockerfile", priority: 5},
+			&failingDetector{},
+		},
+		nil, nil, nil,
+	)
+
+	report := service.Lint(subdir)
+
+	// The nearest .nvmrc is authoritative and hides the one in the parent directory
+	wantSources := []string{".nvmrc", ".node-version", ".tool-versions", "engines", "Dockerfile"}
+	if len(report.Sources) != len(wantSources) {
+		t.Fatalf("Sources = %+v", report.Sources)
+	}
+	for i, name := range wantSources {
+		if report.Sources[i].Detector != name {
+			t.Errorf("Sources[%d] = %s, want %s", i, report.Sources[i].Detector, name)
+		}
+	}
+	if authority := report.Authority(); authority.Source != filepath.Join(subdir, ".nvmrc") {
+		t.Errorf("Authority() = %s", authority.Source)
+	}
+	if report.Sources[2].Comparable {
+		t.Error("lts/iron should not be comparable")
+	}
+
+	// "engines" (>=18 <21) accepts 20, the alias is skipped
+	wantConflicts := []string{".node-version", "Dockerfile"}
+	if len(report.Conflicts) != len(wantConflicts) {
+		t.Fatalf("Conflicts = %+v", report.Conflicts)
+	}
+	for i, name := range wantConflicts {
+		if report.Conflicts[i].Source.Detector != name {
+			t.Errorf("Conflicts[%d] = %s, want %s", i, report.Conflicts[i].Source.Detector, name)
+		}
+	}
+
+	if len(report.Errors) != 1 || report.Errors[0].Detector != "broken" {
+		t.Errorf("Errors = %+v", report.Errors)
+	}
+
+	// Fixing rewrites sources whose detector supports it
+	if err := report.Conflicts[0].Fix(); err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(project, ".node-version")); string(content) != "20" {
+		t.Errorf(".node-version = %q, want 20", content)
+	}
+	if err := report.Conflicts[1].Fix(); err == nil {
+		t.Error("Fix() expected error for a detector that cannot rewrite its source")
+	}
+
+	if report := service.Lint(subdir); len(report.Conflicts) != 1 {
+		t.Errorf("Conflicts after fix = %+v", report.Conflicts)
+	}
+}
+
+func TestAutoNodeService_Lint_AliasAuthority(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	for name, content := range map[string]string{".nvmrc": "lts/iron", ".node-version": "18"} {
+		if err := os.WriteFile(filepath.Join(tempHome, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	service := NewAutoNodeService(
+		NewNullLogger(),
+		[]VersionDetector{
+			&fileDetector{fileName: ".nvmrc", priority: 1},
+			&fileDetector{fileName: ".node-version", priority: 2},
+		},
+		nil, nil, nil,
+	)
+
+	// Nothing can be compared against an alias
+	report := service.Lint(tempHome)
+	if len(report.Sources) != 2 || len(report.Conflicts) != 0 {
+		t.Errorf("Lint() = %+v", report)
+	}
+}
+
+func TestLintSource_Location(t *testing.T) {
+	if got := (LintSource{Source: "/p/.nvmrc", Line: 1}).Location(); got != "/p/.nvmrc:1" {
+		t.Errorf("Location() = %q", got)
+	}
+	if got := (LintSource{Source: "/p/.nvmrc"}).Location(); got != "/p/.nvmrc" {
+		t.Errorf("Location() = %q", got)
+	}
+}
@@ -5,79 +5,149 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const (
-	nodeReleasesURL   = "https://nodejs.org/dist/index.json"
-	cacheFileName     = "node-releases.json"
-	cacheMaxAge       = 24 * time.Hour // Refresh cache daily
+	cacheFileName       = "node-releases.json"
+	cacheMaxAge         = 24 * time.Hour // Refresh cache daily
+	nodeReleasesTimeout = 10 * time.Second
 )
 
 // NodeRelease represents a single Node.js release from the API
 type NodeRelease struct {
-	Version string      `json:"version"`
-	Date    string      `json:"date"`
-	LTS     interface{} `json:"lts"` // Can be false (bool) or "Codename" (string)
+	Version  string      `json:"version"`
+	Date     string      `json:"date"`
+	LTS      interface{} `json:"lts"`      // Can be false (bool) or "Codename" (string)
+	Npm      string      `json:"npm"`      // Bundled npm version (empty for the oldest releases)
+	Security bool        `json:"security"` // The release fixes security issues
+	Modules  string      `json:"modules"`  // NODE_MODULE_VERSION, the ABI of native addons
+}
+
+// Number returns the release version without "v" prefix
+func (r NodeRelease) Number() string {
+	return strings.TrimPrefix(r.Version, "v")
+}
+
+// Major returns the major version number (-1 if the version is malformed)
+func (r NodeRelease) Major() int {
+	major, err := strconv.Atoi(strings.SplitN(r.Number(), ".", 2)[0])
+	if err != nil {
+		return -1
+	}
+	return major
+}
+
+// Codename returns the lowercase LTS codename, or empty string for releases outside an LTS line
+func (r NodeRelease) Codename() string {
+	codename, _ := r.LTS.(string)
+	return strings.ToLower(codename)
 }
 
 // NodeReleasesCache is the cached data structure
 type NodeReleasesCache struct {
-	CodenameToVersion map[string]string `json:"codename_to_version"`
-	LastUpdated       time.Time         `json:"last_updated"`
+	Releases    ReleaseIndex `json:"releases"`
+	LastUpdated time.Time    `json:"last_updated"`
 }
 
 // NodeReleasesClient fetches and caches Node.js release information
 type NodeReleasesClient struct {
-	cache  *CacheManager
-	logger Logger
+	cache   *CacheManager
+	logger  Logger
+	mirror  string
+	client  *http.Client
+	offline bool // Never fetch: the cache is used whatever its age
 }
 
-// NewNodeReleasesClient creates a new NodeReleasesClient instance
-func NewNodeReleasesClient(cache *CacheManager, logger Logger) *NodeReleasesClient {
+// NewNodeReleasesClient creates a new NodeReleasesClient instance that fetches
+// index.json from mirror (e.g. https://nodejs.org/dist)
+func NewNodeReleasesClient(cache *CacheManager, logger Logger, mirror string) *NodeReleasesClient {
 	return &NodeReleasesClient{
 		cache:  cache,
 		logger: logger,
+		mirror: strings.TrimRight(mirror, "/"),
+		client: &http.Client{Timeout: nodeReleasesTimeout},
 	}
 }
 
-// GetVersionForCodename returns the major version for a given LTS codename
-// Returns empty string if not found
-func (c *NodeReleasesClient) GetVersionForCodename(codename string) (string, error) {
-	codename = strings.ToLower(codename)
+// SetOffline enables offline mode: releases are only read from the cache, even when
+// it is older than a day, and the mirror is never contacted
+func (c *NodeReleasesClient) SetOffline(offline bool) {
+	c.offline = offline
+}
 
+// GetReleaseIndex returns every published Node.js release, newest first
+// The cache is refreshed once a day; an outdated cache is used when the mirror can't be
+// reached, and always in offline mode
+func (c *NodeReleasesClient) GetReleaseIndex() (ReleaseIndex, error) {
 	// Try to load from cache first
-	cached, err := c.loadFromCache()
-	if err == nil && cached != nil {
-		if version, found := cached.CodenameToVersion[codename]; found {
-			return version, nil
-		}
+	cached, err := c.loadFromCache(c.offline)
+	if err == nil && len(cached.Releases) > 0 {
+		return cached.Releases, nil
 	}
 
-	// Cache miss or invalid - fetch from API
+	// Cache miss, invalid, or written by an older version without the release list
 	if err := c.refreshCache(); err != nil {
-		// If refresh fails, return error
-		return "", fmt.Errorf("failed to fetch Node.js releases: %w", err)
+		// An outdated cache beats failing when the mirror can't be reached
+		if stale, staleErr := c.loadFromCache(true); staleErr == nil && len(stale.Releases) > 0 {
+			return stale.Releases, nil
+		}
+		return nil, fmt.Errorf("failed to fetch Node.js releases: %w", err)
 	}
 
-	// Try again from fresh cache
-	cached, err = c.loadFromCache()
+	cached, err = c.loadFromCache(true)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if version, found := cached.CodenameToVersion[codename]; found {
-		return version, nil
+	return cached.Releases, nil
+}
+
+// Refresh fetches index.json from the mirror and updates the cache, whatever its age
+func (c *NodeReleasesClient) Refresh() error {
+	return c.refreshCache()
+}
+
+// CachedReleases returns the cached release data, even if it is older than a day
+func (c *NodeReleasesClient) CachedReleases() (*NodeReleasesCache, error) {
+	return c.loadFromCache(true)
+}
+
+// Mirror returns the distribution mirror index.json is fetched from
+func (c *NodeReleasesClient) Mirror() string {
+	return c.mirror
+}
+
+// ImportReleases seeds the cache from a local copy of index.json (e.g. on machines
+// without network access) and returns the number of releases it lists
+func (c *NodeReleasesClient) ImportReleases(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
 	}
 
-	return "", fmt.Errorf("codename '%s' not found", codename)
+	releases, err := parseReleases(data)
+	if err != nil {
+		return 0, err
+	}
+	if len(releases) == 0 {
+		return 0, fmt.Errorf("%s lists no Node.js releases", path)
+	}
+
+	if err := c.storeReleases(releases); err != nil {
+		return 0, err
+	}
+	return len(releases), nil
 }
 
 // loadFromCache loads the cache if valid, returns nil if invalid or not found
-func (c *NodeReleasesClient) loadFromCache() (*NodeReleasesCache, error) {
+// With allowStale, a cache older than cacheMaxAge is still returned
+func (c *NodeReleasesClient) loadFromCache(allowStale bool) (*NodeReleasesCache, error) {
 	// Check if cache is valid (exists and not too old)
-	if !c.cache.IsCacheValid(cacheFileName, cacheMaxAge) {
+	if !allowStale && !c.cache.IsCacheValid(cacheFileName, cacheMaxAge) {
 		return nil, fmt.Errorf("cache invalid or expired")
 	}
 
@@ -89,59 +159,41 @@ func (c *NodeReleasesClient) loadFromCache() (*NodeReleasesCache, error) {
 	return &cached, nil
 }
 
-// refreshCache fetches fresh data from Node.js API and updates cache
+// refreshCache fetches fresh data from the mirror and updates cache
 func (c *NodeReleasesClient) refreshCache() error {
-	c.logger.Info("Fetching Node.js releases from API...")
+	if c.offline {
+		return ErrOffline
+	}
+
+	c.logger.Info(fmt.Sprintf("Fetching Node.js releases from %s...", c.mirror))
 
-	// Fetch from Node.js API
+	// Fetch from the mirror's index.json
 	releases, err := c.fetchReleases()
 	if err != nil {
 		return err
 	}
 
-	// Build codename to version map
-	codenameMap := make(map[string]string)
-
-	for _, release := range releases {
-		// Check if this is an LTS release with a codename
-		if ltsCodename, isLTS := release.LTS.(string); isLTS {
-			// Extract major version from "v20.11.0" -> "20"
-			version := strings.TrimPrefix(release.Version, "v")
-			parts := strings.Split(version, ".")
-			if len(parts) > 0 {
-				majorVersion := parts[0]
-				codenameKey := strings.ToLower(ltsCodename)
-
-				// Keep the latest version for each codename
-				// (API is sorted newest first, so first occurrence is latest)
-				if _, exists := codenameMap[codenameKey]; !exists {
-					codenameMap[codenameKey] = majorVersion
-				}
-			}
-		}
-	}
-
-	// Save to cache
-	cached := NodeReleasesCache{
-		CodenameToVersion: codenameMap,
-		LastUpdated:       time.Now(),
-	}
-
-	if err := c.cache.WriteCache(cacheFileName, cached); err != nil {
+	if err := c.storeReleases(releases); err != nil {
 		return err
 	}
 
-	c.logger.Success(fmt.Sprintf("Node.js releases cache updated (%d codenames)", len(codenameMap)))
+	c.logger.Success(fmt.Sprintf("Node.js releases cache updated (%d releases)", len(releases)))
 	return nil
 }
 
-// fetchReleases fetches the releases list from Node.js API
-func (c *NodeReleasesClient) fetchReleases() ([]NodeRelease, error) {
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+// storeReleases caches a release list (newest first, as index.json sorts it)
+func (c *NodeReleasesClient) storeReleases(releases []NodeRelease) error {
+	cached := NodeReleasesCache{
+		Releases:    releases,
+		LastUpdated: time.Now(),
 	}
 
-	resp, err := client.Get(nodeReleasesURL)
+	return c.cache.WriteCache(cacheFileName, cached)
+}
+
+// fetchReleases fetches the releases list from the mirror
+func (c *NodeReleasesClient) fetchReleases() ([]NodeRelease, error) {
+	resp, err := c.client.Get(c.mirror + "/index.json")
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
@@ -156,8 +208,13 @@ func (c *NodeReleasesClient) fetchReleases() ([]NodeRelease, error) {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
+	return parseReleases(body)
+}
+
+// parseReleases parses the contents of an index.json file
+func parseReleases(data []byte) ([]NodeRelease, error) {
 	var releases []NodeRelease
-	if err := json.Unmarshal(body, &releases); err != nil {
+	if err := json.Unmarshal(data, &releases); err != nil {
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
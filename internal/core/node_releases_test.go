@@ -0,0 +1,166 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testReleasesIndex is a minimal index.json, newest release first
+const testReleasesIndex = `[
+  {"version": "v22.12.0", "date": "2024-12-03", "npm": "10.9.0", "lts": "Jod", "security": false, "modules": "127"},
+  {"version": "v21.7.3", "date": "2024-04-10", "npm": "10.5.0", "lts": false, "security": true, "modules": "120"},
+  {"version": "v20.18.1", "date": "2024-11-20", "npm": "10.8.2", "lts": "Iron", "security": false, "modules": "115"},
+  {"version": "v20.18.0", "date": "2024-10-03", "npm": "10.8.2", "lts": "Iron", "security": false, "modules": "115"}
+]`
+
+// newTestReleasesClient returns a client for a mirror serving testReleasesIndex,
+// and a pointer to the number of requests the mirror received
+func newTestReleasesClient(t *testing.T) (*NodeReleasesClient, *atomic.Int32) {
+	t.Helper()
+
+	requests := &atomic.Int32{}
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/dist/index.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(testReleasesIndex))
+	}))
+	t.Cleanup(mirror.Close)
+
+	cache := &CacheManager{cacheDir: t.TempDir()}
+	return NewNodeReleasesClient(cache, NewNullLogger(), mirror.URL+"/dist/"), requests
+}
+
+// ageReleasesCache makes the cached releases older than cacheMaxAge
+func ageReleasesCache(t *testing.T, client *NodeReleasesClient) {
+	t.Helper()
+	old := time.Now().Add(-2 * cacheMaxAge)
+	if err := os.Chtimes(client.cache.GetCacheFilePath(cacheFileName), old, old); err != nil {
+		t.Fatalf("failed to age cache: %v", err)
+	}
+}
+
+func TestNodeReleasesClient_FetchesFromMirror(t *testing.T) {
+	client, requests := newTestReleasesClient(t)
+
+	index, err := client.GetReleaseIndex()
+	want := []string{"22.12.0", "21.7.3", "20.18.1", "20.18.0"}
+	if err != nil || !reflect.DeepEqual(index.Versions(), want) {
+		t.Fatalf("GetReleaseIndex() = %v, %v, want %v", index.Versions(), err, want)
+	}
+
+	// Every field of index.json is kept
+	wantRelease := NodeRelease{Version: "v21.7.3", Date: "2024-04-10", LTS: false, Npm: "10.5.0", Security: true, Modules: "120"}
+	if index[1] != wantRelease {
+		t.Errorf("index[1] = %+v, want %+v", index[1], wantRelease)
+	}
+
+	if _, err := client.GetReleaseIndex(); err != nil {
+		t.Fatalf("GetReleaseIndex() error = %v", err)
+	}
+
+	if requests.Load() != 1 {
+		t.Errorf("mirror received %d requests, want 1 (then served from cache)", requests.Load())
+	}
+}
+
+func TestNodeReleasesClient_Offline(t *testing.T) {
+	t.Run("no cache", func(t *testing.T) {
+		client, requests := newTestReleasesClient(t)
+		client.SetOffline(true)
+
+		if _, err := client.GetReleaseIndex(); !errors.Is(err, ErrOffline) {
+			t.Errorf("GetReleaseIndex() error = %v, want %v", err, ErrOffline)
+		}
+		if requests.Load() != 0 {
+			t.Errorf("offline client sent %d requests", requests.Load())
+		}
+	})
+
+	t.Run("outdated cache is used", func(t *testing.T) {
+		client, requests := newTestReleasesClient(t)
+		if err := client.Refresh(); err != nil {
+			t.Fatalf("Refresh() error = %v", err)
+		}
+		ageReleasesCache(t, client)
+		client.SetOffline(true)
+
+		if index, err := client.GetReleaseIndex(); err != nil || len(index) != 4 {
+			t.Errorf("GetReleaseIndex() = %d releases, %v, want 4", len(index), err)
+		}
+		if requests.Load() != 1 {
+			t.Errorf("mirror received %d requests, want 1 (before going offline)", requests.Load())
+		}
+	})
+}
+
+func TestNodeReleasesClient_StaleCacheWhenMirrorFails(t *testing.T) {
+	client, _ := newTestReleasesClient(t)
+	if err := client.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	ageReleasesCache(t, client)
+
+	// The mirror is unreachable from now on
+	client.mirror = "http://127.0.0.1:0"
+
+	if index, err := client.GetReleaseIndex(); err != nil || len(index) != 4 {
+		t.Errorf("GetReleaseIndex() = %d releases, %v, want 4 from the outdated cache", len(index), err)
+	}
+}
+
+func TestNodeReleasesClient_ImportReleases(t *testing.T) {
+	client, requests := newTestReleasesClient(t)
+	client.SetOffline(true)
+
+	dir := t.TempDir()
+	index := filepath.Join(dir, "index.json")
+	if err := os.WriteFile(index, []byte(testReleasesIndex), 0644); err != nil {
+		t.Fatalf("failed to write index.json: %v", err)
+	}
+
+	count, err := client.ImportReleases(index)
+	if err != nil || count != 4 {
+		t.Fatalf("ImportReleases() = %d, %v, want 4", count, err)
+	}
+	if index, err := client.GetReleaseIndex(); err != nil || len(index) != 4 {
+		t.Errorf("GetReleaseIndex() = %d releases, %v, want 4", len(index), err)
+	}
+	if requests.Load() != 0 {
+		t.Errorf("import sent %d requests", requests.Load())
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte("[]"), 0644); err != nil {
+		t.Fatalf("failed to write invalid.json: %v", err)
+	}
+	if _, err := client.ImportReleases(invalid); err == nil {
+		t.Error("ImportReleases() expected error for an index without releases")
+	}
+}
+
+func TestNodeReleasesClient_RefreshesCacheWithoutReleases(t *testing.T) {
+	client, requests := newTestReleasesClient(t)
+
+	// Written by a version that only cached codenames and version numbers
+	legacy := `{"codename_to_version": {"iron": "20"}, "versions": ["20.18.1"]}`
+	if err := os.WriteFile(client.cache.GetCacheFilePath(cacheFileName), []byte(legacy), 0644); err != nil {
+		t.Fatalf("failed to write cache: %v", err)
+	}
+
+	if index, err := client.GetReleaseIndex(); err != nil || len(index) != 4 {
+		t.Errorf("GetReleaseIndex() = %d releases, %v, want 4", len(index), err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("mirror received %d requests, want 1", requests.Load())
+	}
+}
@@ -0,0 +1,32 @@
+package core
+
+// PackageManagerDetectionResult represents the package manager a project pins
+// (e.g. "packageManager": "pnpm@9.1.0" in package.json).
+//
+// This type adheres to the Single Responsibility Principle (SRP) by only
+// representing detection results for package manager configuration.
+type PackageManagerDetectionResult struct {
+	// Found indicates whether a package manager was detected
+	Found bool
+
+	// Name is the package manager (e.g., "pnpm", "yarn", "npm")
+	Name string
+
+	// Version is the pinned version of the package manager (e.g., "9.1.0")
+	Version string
+
+	// Hash is the optional integrity hash after the version (e.g., "sha512.abc..."), empty if none
+	Hash string
+
+	// Source is the file the package manager was detected from
+	Source string
+}
+
+// Reference returns the package manager as written in package.json: "<name>@<version>[+<hash>]"
+func (r PackageManagerDetectionResult) Reference() string {
+	reference := r.Name + "@" + r.Version
+	if r.Hash != "" {
+		reference += "+" + r.Hash
+	}
+	return reference
+}
@@ -0,0 +1,22 @@
+package core
+
+// PackageManagerDetector defines the interface for detecting the package manager
+// (pnpm, yarn, npm) a project pins.
+//
+// This interface adheres to:
+// - Interface Segregation Principle (ISP): Small, focused interface
+// - Open/Closed Principle (OCP): New detectors can be added without modifying existing code
+// - Liskov Substitution Principle (LSP): All implementations are interchangeable
+type PackageManagerDetector interface {
+	// Detect searches for a pinned package manager in the given project path
+	// and returns a PackageManagerDetectionResult indicating whether one was found.
+	Detect(projectPath string) (PackageManagerDetectionResult, error)
+
+	// GetPriority returns the priority of this detector.
+	// Lower numbers indicate higher priority.
+	GetPriority() int
+
+	// GetSourceName returns a human-readable name of the source this detector checks
+	// (e.g., "package.json")
+	GetSourceName() string
+}
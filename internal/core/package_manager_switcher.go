@@ -0,0 +1,23 @@
+package core
+
+// PackageManagerSwitcher defines the interface for activating the package manager
+// (pnpm, yarn) a project pins for the active Node.js version, using a tool such as Corepack.
+//
+// This interface adheres to:
+// - Interface Segregation Principle (ISP): Small, focused interface
+// - Open/Closed Principle (OCP): New package manager switchers can be added without modifying existing code
+// - Liskov Substitution Principle (LSP): All implementations are interchangeable
+// - Dependency Inversion Principle (DIP): High-level code depends on this abstraction
+type PackageManagerSwitcher interface {
+	// GetName returns the name of the tool (e.g., "corepack")
+	GetName() string
+
+	// IsInstalled checks if the tool is installed and available
+	IsInstalled() bool
+
+	// ActivationCommands returns the commands (program first) that activate the package
+	// manager. The service runs them under the project's Node.js version, or emits them
+	// in shell mode, so they apply to the Node.js version just switched to.
+	// Returns an error if the tool can't manage this package manager.
+	ActivationCommands(packageManager PackageManagerDetectionResult) ([][]string, error)
+}
@@ -0,0 +1,26 @@
+package core
+
+// ProjectDetection is everything shell mode detects for a directory: the version, npm
+// profile and package manager it pins, and the names of the installed tools selected
+// to switch them. It is what the detection cache stores between shell hook runs.
+// Single Responsibility Principle: Only responsible for holding a project's detection data
+type ProjectDetection struct {
+	// Version is the detected Node.js version, already resolved against installed versions
+	Version DetectionResult `json:"version"`
+	// Manager is the name of the installed version manager (empty if none is installed)
+	Manager string `json:"manager,omitempty"`
+
+	// Profile is the detected npm profile
+	Profile ProfileDetectionResult `json:"profile"`
+	// ProfileSwitcher is the name of the installed profile switcher (empty if none is installed)
+	ProfileSwitcher string `json:"profileSwitcher,omitempty"`
+
+	// PackageManager is the pinned package manager (not found if the pin is invalid)
+	PackageManager PackageManagerDetectionResult `json:"packageManager"`
+	// PackageManagerSwitcher is the name of the installed package manager switcher
+	// (only looked up when a package manager is pinned)
+	PackageManagerSwitcher string `json:"packageManagerSwitcher,omitempty"`
+
+	// Settings are the project's settings (.autonode.yml)
+	Settings ProjectSettings `json:"settings"`
+}
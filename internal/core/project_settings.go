@@ -0,0 +1,10 @@
+package core
+
+// ProjectSettings are the per-project settings that change how autonode behaves in a
+// project, rather than which version it uses
+// Single Responsibility Principle: Only responsible for holding project settings
+type ProjectSettings struct {
+	// AutoInstall is what shell mode does when the project's version is not installed
+	// (empty to use the global setting)
+	AutoInstall AutoInstallPolicy `json:"autoInstall,omitempty"`
+}
@@ -0,0 +1,11 @@
+package core
+
+// ProjectSettingsReader reads the per-project settings kept in a directory (.autonode.yml)
+// The nearest directory with settings wins, the same way version files are found.
+//
+// Interface Segregation Principle: Kept separate from the detector interfaces, since
+// settings don't select a version
+type ProjectSettingsReader interface {
+	// ReadSettings returns the settings kept in projectPath; found is false if it has none
+	ReadSettings(projectPath string) (settings ProjectSettings, found bool, err error)
+}
@@ -0,0 +1,12 @@
+package core
+
+// Prompter talks to the user while shell mode's output is being evaluated by the shell,
+// so nothing can be written to stdout. Shell mode uses it to offer installing a missing version.
+//
+// Interface Segregation Principle: Kept separate from Logger, which shell mode silences
+type Prompter interface {
+	// Notify shows a one-line message
+	Notify(message string)
+	// Confirm asks a yes/no question; false when the user says no or nobody can answer
+	Confirm(question string) bool
+}
@@ -0,0 +1,193 @@
+package core
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ltsCodenames lists the codenames of the Node.js LTS lines, oldest first
+var ltsCodenames = []string{
+	"argon", "boron", "carbon", "dubnium", "erbium", "fermium",
+	"gallium", "hydrogen", "iron", "jod", "krypton", "lithium",
+}
+
+// versionAliasPattern matches the aliases ReleaseIndex.Resolve understands: nvm's lts/*, lts/-1
+// and lts/<codename>, the words node, stable, latest, current and lts, and bare LTS codenames.
+// Other words (iojs, system, ...) are left to the version managers that define them.
+var versionAliasPattern = regexp.MustCompile(`(?i)^(lts/(\*|-\d+|[a-z]+)|node|stable|latest|current|lts|` +
+	strings.Join(ltsCodenames, "|") + `)$`)
+
+// eolOverrides lists version lines whose end-of-life was moved from the regular schedule
+var eolOverrides = map[int]string{
+	8:  "2019-12-31", // Aligned with OpenSSL 1.0.2
+	16: "2023-09-11", // Aligned with OpenSSL 1.1.1
+}
+
+// IsVersionAlias reports whether spec names a version by alias (e.g. "lts/iron", "lts/*",
+// "node", "stable", "iron") rather than by number or range
+func IsVersionAlias(spec string) bool {
+	return versionAliasPattern.MatchString(strings.TrimSpace(spec))
+}
+
+// ReleaseIndex is the list of published Node.js releases, newest first, as index.json sorts it
+// Single Responsibility Principle: Only responsible for querying release data; fetching and
+// caching it is NodeReleasesClient's job
+type ReleaseIndex []NodeRelease
+
+// Versions returns every release version without "v" prefix, newest first
+func (idx ReleaseIndex) Versions() []string {
+	versions := make([]string, 0, len(idx))
+	for _, release := range idx {
+		versions = append(versions, release.Number())
+	}
+	return versions
+}
+
+// Latest returns the newest release
+func (idx ReleaseIndex) Latest() (NodeRelease, bool) {
+	if len(idx) == 0 {
+		return NodeRelease{}, false
+	}
+	return idx[0], true
+}
+
+// LatestLTS returns the newest release of an LTS line: the newest one for offset 0,
+// the line before it for offset 1 (nvm's "lts/-1"), and so on
+func (idx ReleaseIndex) LatestLTS(offset int) (NodeRelease, bool) {
+	seen := make(map[string]bool)
+	for _, release := range idx {
+		codename := release.Codename()
+		if codename == "" || seen[codename] {
+			continue
+		}
+		if len(seen) == offset {
+			return release, true
+		}
+		seen[codename] = true
+	}
+	return NodeRelease{}, false
+}
+
+// ForCodename returns the newest release of the LTS line with the given codename (case-insensitive)
+func (idx ReleaseIndex) ForCodename(codename string) (NodeRelease, bool) {
+	codename = strings.ToLower(codename)
+	for _, release := range idx {
+		if codename != "" && release.Codename() == codename {
+			return release, true
+		}
+	}
+	return NodeRelease{}, false
+}
+
+// Find returns the release with exactly the given version ("20.11.0" or "v20.11.0")
+func (idx ReleaseIndex) Find(version string) (NodeRelease, bool) {
+	version = strings.TrimPrefix(version, "v")
+	for _, release := range idx {
+		if release.Number() == version {
+			return release, true
+		}
+	}
+	return NodeRelease{}, false
+}
+
+// Resolve returns the newest release matching spec, the way version managers read it:
+//   - "node", "latest", "current" and "stable" name the newest release
+//   - "lts" and "lts/*" name the newest LTS release, "lts/-1" the LTS line before it
+//   - "lts/iron" and "iron" name the newest release of that LTS line
+//   - versions, partial versions and ranges ("20.11.0", "20", "20.x", ">=18 <21")
+//     name the newest release they match
+func (idx ReleaseIndex) Resolve(spec string) (NodeRelease, bool) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+
+	switch {
+	case spec == "node" || spec == "latest" || spec == "current" || spec == "stable":
+		return idx.Latest()
+	case spec == "lts" || spec == "lts/*":
+		return idx.LatestLTS(0)
+	case strings.HasPrefix(spec, "lts/-"):
+		offset, err := strconv.Atoi(strings.TrimPrefix(spec, "lts/-"))
+		if err != nil || offset < 0 {
+			return NodeRelease{}, false
+		}
+		return idx.LatestLTS(offset)
+	case strings.HasPrefix(spec, "lts/"):
+		return idx.ForCodename(strings.TrimPrefix(spec, "lts/"))
+	}
+
+	if release, found := idx.ForCodename(spec); found {
+		return release, true
+	}
+
+	versionRange, err := ParseVersionRange(spec)
+	if err != nil {
+		return NodeRelease{}, false
+	}
+	match, found := versionRange.MaxSatisfying(idx.Versions())
+	if !found {
+		return NodeRelease{}, false
+	}
+	return idx.Find(match)
+}
+
+// EndOfLife returns the date support for a major version line ends, following the Node.js
+// release schedule: even-numbered lines are supported until April 30 three years after their
+// first release, odd-numbered lines until June 1 of the year after theirs.
+// Returns false if the index has no release of that line.
+func (idx ReleaseIndex) EndOfLife(major int) (time.Time, bool) {
+	if date, found := eolOverrides[major]; found {
+		eol, err := time.Parse(time.DateOnly, date)
+		return eol, err == nil
+	}
+
+	// The oldest release of the line comes last
+	var first time.Time
+	for _, release := range idx {
+		if release.Major() != major {
+			continue
+		}
+		if date, err := time.Parse(time.DateOnly, release.Date); err == nil {
+			first = date
+		}
+	}
+	if first.IsZero() {
+		return time.Time{}, false
+	}
+
+	if major%2 == 0 {
+		return time.Date(first.Year()+3, time.April, 30, 0, 0, 0, 0, time.UTC), true
+	}
+	return time.Date(first.Year()+1, time.June, 1, 0, 0, 0, 0, time.UTC), true
+}
+
+// IsEndOfLife reports whether the line of version is no longer supported at now
+// Versions of unknown lines are not reported
+func (idx ReleaseIndex) IsEndOfLife(version string, now time.Time) bool {
+	eol, found := idx.EndOfLife(NodeRelease{Version: version}.Major())
+	return found && !now.Before(eol)
+}
+
+// SecurityUpdate returns the newest release of version's line when a release newer
+// than version in that line fixes security issues
+func (idx ReleaseIndex) SecurityUpdate(version string) (NodeRelease, bool) {
+	current, err := ParseSemVer(version)
+	if err != nil {
+		return NodeRelease{}, false
+	}
+
+	var newest NodeRelease
+	security := false
+	for _, release := range idx {
+		v, err := ParseSemVer(release.Number())
+		if err != nil || v.Major != current.Major || v.Compare(current) <= 0 {
+			continue
+		}
+		if newest.Version == "" {
+			newest = release
+		}
+		security = security || release.Security
+	}
+
+	return newest, security
+}
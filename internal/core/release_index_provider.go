@@ -0,0 +1,11 @@
+package core
+
+// ReleaseIndexProvider supplies the index of published Node.js releases
+// Implemented by NodeReleasesClient, which caches the mirror's index.json
+//
+// Dependency Inversion Principle: Consumers depend on this abstraction, so tests
+// can provide a fixed index without network access
+type ReleaseIndexProvider interface {
+	// GetReleaseIndex returns every published release, newest first
+	GetReleaseIndex() (ReleaseIndex, error)
+}
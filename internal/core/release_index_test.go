@@ -0,0 +1,146 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+// testIndex is a release index, newest first, as index.json lists it
+var testIndex = ReleaseIndex{
+	{Version: "v23.3.0", Date: "2024-11-20", LTS: false},
+	{Version: "v22.12.0", Date: "2024-12-03", LTS: "Jod", Npm: "10.9.0", Modules: "127"},
+	{Version: "v22.0.0", Date: "2024-04-24", LTS: false},
+	{Version: "v21.7.3", Date: "2024-04-10", LTS: false},
+	{Version: "v21.0.0", Date: "2023-10-17", LTS: false},
+	{Version: "v20.18.1", Date: "2024-11-20", LTS: "Iron"},
+	{Version: "v20.18.0", Date: "2024-10-03", LTS: "Iron", Security: true},
+	{Version: "v20.17.0", Date: "2024-08-21", LTS: "Iron"},
+	{Version: "v20.0.0", Date: "2023-04-18", LTS: false},
+	{Version: "v18.20.5", Date: "2024-11-12", LTS: "Hydrogen"},
+	{Version: "v18.0.0", Date: "2022-04-19", LTS: false},
+	{Version: "v16.20.2", Date: "2023-08-08", LTS: "Gallium"},
+	{Version: "v16.0.0", Date: "2021-04-20", LTS: false},
+}
+
+func TestReleaseIndex_Resolve(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string // Empty if nothing matches
+	}{
+		{"node", "23.3.0"},
+		{"latest", "23.3.0"},
+		{"current", "23.3.0"},
+		{"lts", "22.12.0"},
+		{"lts/*", "22.12.0"},
+		{"lts/-1", "20.18.1"},
+		{"lts/-2", "18.20.5"},
+		{"lts/-9", ""},
+		{"lts/iron", "20.18.1"},
+		{"LTS/Hydrogen", "18.20.5"},
+		{"gallium", "16.20.2"},
+		{"lts/argon", ""},
+		{"20", "20.18.1"},
+		{"v20", "20.18.1"},
+		{"20.17", "20.17.0"},
+		{"20.x", "20.18.1"},
+		{"21.7.3", "21.7.3"},
+		{">=18 <21", "20.18.1"},
+		{"^19", ""},
+		{"bookworm", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			release, found := testIndex.Resolve(tt.spec)
+			if found != (tt.want != "") || release.Number() != tt.want {
+				t.Errorf("Resolve(%q) = %q, %v, want %q", tt.spec, release.Number(), found, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseIndex_EndOfLife(t *testing.T) {
+	tests := []struct {
+		major int
+		want  string // Empty if the line is unknown
+	}{
+		{22, "2027-04-30"},
+		{21, "2024-06-01"},
+		{20, "2026-04-30"},
+		{18, "2025-04-30"},
+		{16, "2023-09-11"},
+		{19, ""},
+	}
+
+	for _, tt := range tests {
+		eol, found := testIndex.EndOfLife(tt.major)
+		if got := eol.Format(time.DateOnly); found != (tt.want != "") || (found && got != tt.want) {
+			t.Errorf("EndOfLife(%d) = %s, %v, want %q", tt.major, got, found, tt.want)
+		}
+	}
+
+	now := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	for version, want := range map[string]bool{"22.12.0": false, "21.7.3": true, "v18.20.5": true, "20.0.0": false, "19.0.0": false} {
+		if got := testIndex.IsEndOfLife(version, now); got != want {
+			t.Errorf("IsEndOfLife(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestReleaseIndex_SecurityUpdate(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string // Empty if no newer release of the line fixes security issues
+	}{
+		{"20.17.0", "20.18.1"},
+		{"20.18.0", ""},
+		{"20.18.1", ""},
+		{"22.0.0", ""},
+	}
+
+	for _, tt := range tests {
+		release, found := testIndex.SecurityUpdate(tt.version)
+		if found != (tt.want != "") || (found && release.Number() != tt.want) {
+			t.Errorf("SecurityUpdate(%q) = %q, %v, want %q", tt.version, release.Number(), found, tt.want)
+		}
+	}
+}
+
+func TestNodeRelease_Fields(t *testing.T) {
+	release, _ := testIndex.Find("22.12.0")
+	if release.Number() != "22.12.0" || release.Major() != 22 || release.Codename() != "jod" {
+		t.Errorf("Number(), Major(), Codename() = %q, %d, %q", release.Number(), release.Major(), release.Codename())
+	}
+	if release.Npm != "10.9.0" || release.Modules != "127" {
+		t.Errorf("Npm, Modules = %q, %q", release.Npm, release.Modules)
+	}
+	if codename := (NodeRelease{Version: "v23.3.0", LTS: false}).Codename(); codename != "" {
+		t.Errorf("Codename() = %q for a release outside an LTS line", codename)
+	}
+}
+
+func TestIsVersionAlias(t *testing.T) {
+	tests := map[string]bool{
+		"lts/*":    true,
+		"lts/-1":   true,
+		"lts/iron": true,
+		"node":     true,
+		"stable":   true,
+		"Iron":     true,
+		"20":       false,
+		"v20.11.0": false,
+		"20.x":     false,
+		">=18":     false,
+		"lts/20":   false,
+		"iojs":     false,
+		"system":   false,
+		"unstable": false,
+		"foo":      false,
+	}
+
+	for spec, want := range tests {
+		if got := IsVersionAlias(spec); got != want {
+			t.Errorf("IsVersionAlias(%q) = %v, want %v", spec, got, want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// searchDirectories returns the directories to scan for version and profile files,
+// starting at projectPath and walking up toward the filesystem root.
+// The walk stops after the repository root (a directory containing .git) or the
+// user's home directory, whichever comes first, so settings from unrelated parent
+// directories are never picked up.
+func searchDirectories(projectPath string) []string {
+	dir, err := filepath.Abs(projectPath)
+	if err != nil {
+		return []string{projectPath}
+	}
+
+	homeDir, _ := os.UserHomeDir()
+
+	var dirs []string
+	for {
+		dirs = append(dirs, dir)
+
+		if isRepositoryRoot(dir) || (homeDir != "" && dir == filepath.Clean(homeDir)) {
+			break
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break // Reached filesystem root
+		}
+		dir = parent
+	}
+
+	return dirs
+}
+
+// isRepositoryRoot reports whether dir is the root of a git repository
+// .git is a directory in regular clones and a file in worktrees and submodules
+func isRepositoryRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
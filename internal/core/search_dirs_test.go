@@ -0,0 +1,58 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSearchDirectories(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	repo := filepath.Join(tempHome, "projects", "app")
+	nested := filepath.Join(repo, "src", "components")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("failed to create dirs: %v", err)
+	}
+
+	t.Run("stops at home directory", func(t *testing.T) {
+		got := searchDirectories(nested)
+		want := []string{
+			nested,
+			filepath.Join(repo, "src"),
+			repo,
+			filepath.Join(tempHome, "projects"),
+			tempHome,
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("searchDirectories() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("stops at repository root", func(t *testing.T) {
+		if err := os.Mkdir(filepath.Join(repo, ".git"), 0755); err != nil {
+			t.Fatalf("failed to create .git: %v", err)
+		}
+		defer os.RemoveAll(filepath.Join(repo, ".git"))
+
+		got := searchDirectories(nested)
+		want := []string{nested, filepath.Join(repo, "src"), repo}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("searchDirectories() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("git worktree file marks root", func(t *testing.T) {
+		if err := os.WriteFile(filepath.Join(repo, ".git"), []byte("gitdir: /elsewhere"), 0644); err != nil {
+			t.Fatalf("failed to create .git file: %v", err)
+		}
+		defer os.Remove(filepath.Join(repo, ".git"))
+
+		got := searchDirectories(repo)
+		if !reflect.DeepEqual(got, []string{repo}) {
+			t.Errorf("searchDirectories() = %v, want [%s]", got, repo)
+		}
+	})
+}
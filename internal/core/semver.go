@@ -0,0 +1,574 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SemVer represents a parsed semantic version (major.minor.patch[-prerelease])
+// Single Responsibility Principle: Only responsible for holding and comparing version numbers
+type SemVer struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease []string
+}
+
+// ParseSemVer parses a full version like "20.11.0", "v20.11.0" or "1.2.3-rc.1"
+// Build metadata ("+build") is accepted and ignored
+func ParseSemVer(version string) (SemVer, error) {
+	p, err := parsePartialVersion(version)
+	if err != nil {
+		return SemVer{}, err
+	}
+	if p.parts < 3 {
+		return SemVer{}, fmt.Errorf("incomplete version '%s'", version)
+	}
+	return p.fill(), nil
+}
+
+// String formats the version without a "v" prefix
+func (v SemVer) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 if v is lower than, equal to or greater than other
+// Prerelease versions have lower precedence than the associated normal version
+func (v SemVer) Compare(other SemVer) int {
+	if c := compareInt(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// sameTuple reports whether both versions share major, minor and patch
+func (v SemVer) sameTuple(other SemVer) bool {
+	return v.Major == other.Major && v.Minor == other.Minor && v.Patch == other.Patch
+}
+
+// IsExactVersion reports whether spec names a single full version (e.g. "18.17.0", "v20.1.0")
+// rather than a range or a partial version
+func IsExactVersion(spec string) bool {
+	spec = strings.TrimSpace(spec)
+	spec = strings.TrimPrefix(spec, "=")
+	_, err := ParseSemVer(spec)
+	return err == nil
+}
+
+// VersionRange is an npm-compatible semver range such as ">=18 <21", "^20.5 || ^22"
+// or "16 - 18". It supports comparators, caret, tilde, hyphen and x-ranges.
+// Single Responsibility Principle: Only responsible for matching versions against a range
+type VersionRange struct {
+	raw  string
+	sets []comparatorSet
+}
+
+// comparatorSet is one "||" alternative: all comparators must match
+type comparatorSet struct {
+	comparators []comparator
+	floor       string // lowest version the set allows, as written (used when no release index is available)
+	floorVer    SemVer
+}
+
+// comparator is a primitive "<op> <version>" test
+type comparator struct {
+	op      string // one of <, <=, >, >=, =
+	version SemVer
+	// explicit marks comparators written by the user (not synthesized bounds);
+	// only those can opt a prerelease version into the range
+	explicit bool
+}
+
+// partialVersion is a possibly incomplete version like "20", "20.x" or "*"
+type partialVersion struct {
+	major, minor, patch int
+	parts               int // number of concrete (non-wildcard) components
+	prerelease          []string
+}
+
+var (
+	// operatorSpacing collapses "> = 18" style spacing between an operator and its version
+	operatorSpacing = regexp.MustCompile(`(<=|>=|~>|<|>|=|~|\^)\s+`)
+	partialPattern  = regexp.MustCompile(`^v?(\d+|[xX*])(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+)
+
+// ParseVersionRange parses an npm-style version range
+func ParseVersionRange(spec string) (*VersionRange, error) {
+	r := &VersionRange{raw: strings.TrimSpace(spec)}
+
+	for _, alternative := range strings.Split(r.raw, "||") {
+		set, err := parseComparatorSet(strings.TrimSpace(alternative))
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range '%s': %w", spec, err)
+		}
+		r.sets = append(r.sets, set)
+	}
+
+	return r, nil
+}
+
+// String returns the range as originally written
+func (r *VersionRange) String() string {
+	return r.raw
+}
+
+// Contains reports whether version satisfies the range
+func (r *VersionRange) Contains(version SemVer) bool {
+	for _, set := range r.sets {
+		if set.matches(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxSatisfying returns the highest of versions that satisfies the range
+// Invalid entries are ignored. The result is formatted without a "v" prefix.
+func (r *VersionRange) MaxSatisfying(versions []string) (string, bool) {
+	var best SemVer
+	found := false
+
+	for _, candidate := range versions {
+		v, err := ParseSemVer(candidate)
+		if err != nil || !r.Contains(v) {
+			continue
+		}
+		if !found || v.Compare(best) > 0 {
+			best = v
+			found = true
+		}
+	}
+
+	if !found {
+		return "", false
+	}
+	return best.String(), true
+}
+
+// MinVersion returns the lower bound of the highest alternative, as written in the range
+// (e.g. ">=18 <21" -> "18", "^20.5 || ^22" -> "22"). It is used as a best-effort
+// answer when no release index is available. Returns empty string for unbounded ranges.
+func (r *VersionRange) MinVersion() string {
+	best := ""
+	var bestVer SemVer
+
+	for _, set := range r.sets {
+		if set.floor == "" {
+			continue
+		}
+		if best == "" || set.floorVer.Compare(bestVer) > 0 {
+			best = set.floor
+			bestVer = set.floorVer
+		}
+	}
+
+	return best
+}
+
+// Intersects reports whether some version satisfies both ranges
+// (e.g. "18" and ">=20" don't intersect, "20" and ">=18 <21" do).
+// Prerelease versions are not considered.
+func (r *VersionRange) Intersects(other *VersionRange) bool {
+	for _, a := range r.sets {
+		for _, b := range other.sets {
+			combined := comparatorSet{}
+			combined.add(a.comparators...)
+			combined.add(b.comparators...)
+			if combined.satisfiable() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// satisfiable reports whether the tightest lower bound of the set lies below its tightest upper bound
+func (s comparatorSet) satisfiable() bool {
+	var lower, upper *comparator
+	for i := range s.comparators {
+		c := &s.comparators[i]
+		if c.op != "<" && c.op != "<=" {
+			if lower == nil || tighterLower(c, lower) {
+				lower = c
+			}
+		}
+		if c.op != ">" && c.op != ">=" {
+			if upper == nil || tighterUpper(c, upper) {
+				upper = c
+			}
+		}
+	}
+
+	if lower == nil || upper == nil {
+		return true
+	}
+
+	cmp := lower.version.Compare(upper.version)
+	if cmp != 0 {
+		return cmp < 0
+	}
+	return lower.op != ">" && upper.op != "<"
+}
+
+// tighterLower reports whether lower bound a excludes more versions than b
+func tighterLower(a, b *comparator) bool {
+	if cmp := a.version.Compare(b.version); cmp != 0 {
+		return cmp > 0
+	}
+	return a.op == ">"
+}
+
+// tighterUpper reports whether upper bound a excludes more versions than b
+func tighterUpper(a, b *comparator) bool {
+	if cmp := a.version.Compare(b.version); cmp != 0 {
+		return cmp < 0
+	}
+	return a.op == "<"
+}
+
+// matches checks every comparator of the set, applying npm's prerelease rule:
+// a prerelease version only matches if a comparator explicitly names a prerelease
+// of the same major.minor.patch
+func (s comparatorSet) matches(version SemVer) bool {
+	for _, c := range s.comparators {
+		if !c.matches(version) {
+			return false
+		}
+	}
+
+	if len(version.Prerelease) == 0 {
+		return true
+	}
+
+	for _, c := range s.comparators {
+		if c.explicit && len(c.version.Prerelease) > 0 && c.version.sameTuple(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches tests a single comparator
+func (c comparator) matches(version SemVer) bool {
+	cmp := version.Compare(c.version)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return cmp == 0
+	}
+}
+
+// parseComparatorSet parses one "||" alternative into primitive comparators
+func parseComparatorSet(expr string) (comparatorSet, error) {
+	expr = operatorSpacing.ReplaceAllString(expr, "$1")
+	tokens := strings.Fields(expr)
+
+	var set comparatorSet
+
+	// Hyphen range: "1.2.3 - 2.3.4"
+	if len(tokens) == 3 && tokens[1] == "-" {
+		low, err := parsePartialVersion(tokens[0])
+		if err != nil {
+			return set, err
+		}
+		high, err := parsePartialVersion(tokens[2])
+		if err != nil {
+			return set, err
+		}
+		set.add(lowerBound(low)...)
+		set.add(upperBound(high)...)
+		set.setFloor(low, false)
+		return set, nil
+	}
+
+	// Empty expression means "any version"
+	if len(tokens) == 0 {
+		tokens = []string{"*"}
+	}
+
+	for _, token := range tokens {
+		op, rest := splitOperator(token)
+		p, err := parsePartialVersion(rest)
+		if err != nil {
+			return set, err
+		}
+
+		switch op {
+		case "", "=":
+			set.add(lowerBound(p)...)
+			set.add(upperBound(p)...)
+			set.setFloor(p, false)
+		case "~", "~>":
+			set.add(lowerBound(p)...)
+			set.add(tildeUpperBound(p)...)
+			set.setFloor(p, false)
+		case "^":
+			set.add(lowerBound(p)...)
+			set.add(caretUpperBound(p)...)
+			set.setFloor(p, false)
+		case ">=":
+			set.add(lowerBound(p)...)
+			set.setFloor(p, false)
+		case ">":
+			set.add(greaterThan(p)...)
+			set.setFloor(p, true)
+		case "<":
+			set.add(lessThan(p)...)
+		case "<=":
+			set.add(upperBound(p)...)
+		default:
+			return set, fmt.Errorf("unknown operator '%s'", op)
+		}
+	}
+
+	return set, nil
+}
+
+// add appends comparators to the set
+func (s *comparatorSet) add(comparators ...comparator) {
+	s.comparators = append(s.comparators, comparators...)
+}
+
+// setFloor records p as the set's lower bound if it is higher than the current one.
+// When exclusive is true (">" operator) the next version above p is used instead.
+func (s *comparatorSet) setFloor(p partialVersion, exclusive bool) {
+	if p.parts == 0 {
+		return
+	}
+
+	if exclusive {
+		switch p.parts {
+		case 1:
+			p.major++
+		case 2:
+			p.minor++
+		default:
+			p.patch++
+			p.prerelease = nil
+		}
+	}
+
+	v := p.fill()
+	if s.floor == "" || v.Compare(s.floorVer) > 0 {
+		s.floor = p.String()
+		s.floorVer = v
+	}
+}
+
+// splitOperator separates a comparator token into operator and version
+func splitOperator(token string) (string, string) {
+	for _, op := range []string{"<=", ">=", "~>", "<", ">", "=", "~", "^"} {
+		if strings.HasPrefix(token, op) {
+			return op, strings.TrimPrefix(token, op)
+		}
+	}
+	return "", token
+}
+
+// lowerBound returns ">= p" with missing components filled with zeros
+func lowerBound(p partialVersion) []comparator {
+	if p.parts == 0 {
+		return []comparator{{op: ">=", version: SemVer{}}}
+	}
+	return []comparator{{op: ">=", version: p.fill(), explicit: p.parts == 3}}
+}
+
+// upperBound returns "<= p", where a partial p includes its whole line ("20" -> "<21.0.0-0")
+func upperBound(p partialVersion) []comparator {
+	switch p.parts {
+	case 0:
+		return nil
+	case 1:
+		return []comparator{below(p.major+1, 0, 0)}
+	case 2:
+		return []comparator{below(p.major, p.minor+1, 0)}
+	default:
+		return []comparator{{op: "<=", version: p.fill(), explicit: true}}
+	}
+}
+
+// tildeUpperBound allows patch-level changes ("~1.2.3" -> "<1.3.0-0", "~1" -> "<2.0.0-0")
+func tildeUpperBound(p partialVersion) []comparator {
+	switch p.parts {
+	case 0:
+		return nil
+	case 1:
+		return []comparator{below(p.major+1, 0, 0)}
+	default:
+		return []comparator{below(p.major, p.minor+1, 0)}
+	}
+}
+
+// caretUpperBound allows changes that do not modify the left-most non-zero component
+func caretUpperBound(p partialVersion) []comparator {
+	switch {
+	case p.parts == 0:
+		return nil
+	case p.parts == 1 || p.major > 0:
+		return []comparator{below(p.major+1, 0, 0)}
+	case p.parts == 2 || p.minor > 0:
+		return []comparator{below(0, p.minor+1, 0)}
+	default:
+		return []comparator{below(0, 0, p.patch+1)}
+	}
+}
+
+// greaterThan returns "> p", where a partial p excludes its whole line (">20" -> ">=21.0.0")
+func greaterThan(p partialVersion) []comparator {
+	switch p.parts {
+	case 0:
+		return []comparator{below(0, 0, 0)} // matches nothing
+	case 1:
+		return []comparator{{op: ">=", version: SemVer{Major: p.major + 1}}}
+	case 2:
+		return []comparator{{op: ">=", version: SemVer{Major: p.major, Minor: p.minor + 1}}}
+	default:
+		return []comparator{{op: ">", version: p.fill(), explicit: true}}
+	}
+}
+
+// lessThan returns "< p", where a partial p excludes its whole line ("<20" -> "<20.0.0-0")
+func lessThan(p partialVersion) []comparator {
+	if p.parts == 0 {
+		return []comparator{below(0, 0, 0)} // matches nothing
+	}
+	if p.parts < 3 {
+		v := p.fill()
+		return []comparator{below(v.Major, v.Minor, v.Patch)}
+	}
+	return []comparator{{op: "<", version: p.fill(), explicit: true}}
+}
+
+// below returns "< major.minor.patch-0", which excludes every prerelease of that version too
+func below(major, minor, patch int) comparator {
+	return comparator{op: "<", version: SemVer{Major: major, Minor: minor, Patch: patch, Prerelease: []string{"0"}}}
+}
+
+// parsePartialVersion parses "20", "20.x", "20.11", "v20.11.0", "*" or "" into a partialVersion
+func parsePartialVersion(version string) (partialVersion, error) {
+	version = strings.TrimSpace(version)
+	version = strings.TrimPrefix(version, "=")
+	if version == "" {
+		return partialVersion{}, nil
+	}
+
+	matches := partialPattern.FindStringSubmatch(version)
+	if matches == nil {
+		return partialVersion{}, fmt.Errorf("invalid version '%s'", version)
+	}
+
+	var p partialVersion
+	for i, component := range matches[1:4] {
+		if component == "" || isWildcard(component) {
+			break
+		}
+		n, err := strconv.Atoi(component)
+		if err != nil {
+			return partialVersion{}, fmt.Errorf("invalid version '%s'", version)
+		}
+		switch i {
+		case 0:
+			p.major = n
+		case 1:
+			p.minor = n
+		case 2:
+			p.patch = n
+		}
+		p.parts++
+	}
+
+	if matches[4] != "" && p.parts == 3 {
+		p.prerelease = strings.Split(matches[4], ".")
+	}
+
+	return p, nil
+}
+
+// fill converts a partial version to a full one, filling missing components with zeros
+func (p partialVersion) fill() SemVer {
+	return SemVer{Major: p.major, Minor: p.minor, Patch: p.patch, Prerelease: p.prerelease}
+}
+
+// String formats only the concrete components ("20", "20.5", "20.5.1")
+func (p partialVersion) String() string {
+	switch p.parts {
+	case 0:
+		return ""
+	case 1:
+		return strconv.Itoa(p.major)
+	case 2:
+		return fmt.Sprintf("%d.%d", p.major, p.minor)
+	default:
+		return p.fill().String()
+	}
+}
+
+// isWildcard reports whether a version component is x, X or *
+func isWildcard(component string) bool {
+	return component == "x" || component == "X" || component == "*"
+}
+
+// compareInt compares two integers
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease compares prerelease identifiers following semver precedence rules
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		ai, aErr := strconv.Atoi(a[i])
+		bi, bErr := strconv.Atoi(b[i])
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareInt(ai, bi); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			return -1 // numeric identifiers sort before alphanumeric ones
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareInt(len(a), len(b))
+}
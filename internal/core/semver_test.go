@@ -0,0 +1,246 @@
+package core
+
+import "testing"
+
+func TestParseSemVer(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"20.11.0", "20.11.0", false},
+		{"v20.11.0", "20.11.0", false},
+		{"1.2.3-rc.1", "1.2.3-rc.1", false},
+		{"1.2.3+build.5", "1.2.3", false},
+		{"20", "", true},
+		{"20.x", "", true},
+		{"lts/iron", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSemVer(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSemVer(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("ParseSemVer(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemVer_Compare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"2.0.0", "1.9.9", 1},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-1", "1.0.0-alpha", -1},
+		{"1.0.0-beta.11", "1.0.0-beta.2", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, _ := ParseSemVer(tt.a)
+			b, _ := ParseSemVer(tt.b)
+			if got := a.Compare(b); got != tt.want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionRange_Contains(t *testing.T) {
+	tests := []struct {
+		rangeSpec string
+		version   string
+		want      bool
+	}{
+		// Comparators
+		{">=18 <21", "20.18.1", true},
+		{">=18 <21", "21.0.0", false},
+		{">=18 <21", "17.9.0", false},
+		{">= 18.0.0", "18.0.0", true},
+		{">20", "20.99.0", false},
+		{">20", "21.0.0", true},
+		{"<=20.5", "20.5.9", true},
+		{"<=20.5", "20.6.0", false},
+		{"=18.17.0", "18.17.0", true},
+
+		// Caret
+		{"^20.5", "20.5.0", true},
+		{"^20.5", "20.4.9", false},
+		{"^20.5", "21.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.4", false},
+
+		// Tilde
+		{"~16.20.0", "16.20.2", true},
+		{"~16.20.0", "16.21.0", false},
+		{"~16", "16.99.0", true},
+
+		// X-ranges and partial versions
+		{"20", "20.11.1", true},
+		{"20.x", "21.0.0", false},
+		{"20.11.*", "20.11.5", true},
+		{"*", "4.0.0", true},
+		{"", "4.0.0", true},
+
+		// Hyphen ranges
+		{"16.0.0 - 18.0.0", "18.0.0", true},
+		{"16.0.0 - 18.0.0", "18.0.1", false},
+		{"16 - 18", "18.20.5", true},
+
+		// Alternatives
+		{"^20.5 || ^22", "22.12.0", true},
+		{"^20.5 || ^22", "21.7.3", false},
+
+		// Prereleases only match when explicitly named
+		{">=20", "21.0.0-rc.1", false},
+		{">=21.0.0-rc.0", "21.0.0-rc.1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rangeSpec+" contains "+tt.version, func(t *testing.T) {
+			r, err := ParseVersionRange(tt.rangeSpec)
+			if err != nil {
+				t.Fatalf("ParseVersionRange(%q) error = %v", tt.rangeSpec, err)
+			}
+			v, err := ParseSemVer(tt.version)
+			if err != nil {
+				t.Fatalf("ParseSemVer(%q) error = %v", tt.version, err)
+			}
+			if got := r.Contains(v); got != tt.want {
+				t.Errorf("Contains(%s) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionRange_MaxSatisfying(t *testing.T) {
+	versions := []string{"v24.1.0", "v22.12.0", "v21.7.3", "v20.18.1", "v20.5.0", "v18.20.5", "v16.20.2"}
+
+	tests := []struct {
+		rangeSpec string
+		want      string
+		wantFound bool
+	}{
+		{">=18 <21", "20.18.1", true},
+		{"^20.5 || ^22", "22.12.0", true},
+		{"~16.20.0", "16.20.2", true},
+		{">=16", "24.1.0", true},
+		{"<16", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rangeSpec, func(t *testing.T) {
+			r, err := ParseVersionRange(tt.rangeSpec)
+			if err != nil {
+				t.Fatalf("ParseVersionRange(%q) error = %v", tt.rangeSpec, err)
+			}
+			got, found := r.MaxSatisfying(versions)
+			if found != tt.wantFound || got != tt.want {
+				t.Errorf("MaxSatisfying() = (%q, %v), want (%q, %v)", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestVersionRange_MinVersion(t *testing.T) {
+	tests := []struct {
+		rangeSpec string
+		want      string
+	}{
+		{">=18 <21", "18"},
+		{"^20.5 || ^22", "22"},
+		{">=16.0.0", "16.0.0"},
+		{"16.0.0 - 18.0.0", "16.0.0"},
+		{">20", "21"},
+		{"<21", ""},
+		{"*", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rangeSpec, func(t *testing.T) {
+			r, err := ParseVersionRange(tt.rangeSpec)
+			if err != nil {
+				t.Fatalf("ParseVersionRange(%q) error = %v", tt.rangeSpec, err)
+			}
+			if got := r.MinVersion(); got != tt.want {
+				t.Errorf("MinVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionRange_Intersects(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"18", ">=20", false},
+		{"20", ">=18 <21", true},
+		{"22", "^20.5 || ^22", true},
+		{"21", "^20.5 || ^22", false},
+		{"20.11.0", ">=20.11.0", true},
+		{"20.11.0", ">20.11.0", false},
+		{"<21", "21", false},
+		{"<=21", "21", true},
+		{"~20.5", "20.6", false},
+		{"*", "18", true},
+		{"16 - 18", "18.19.0", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" & "+tt.b, func(t *testing.T) {
+			a, err := ParseVersionRange(tt.a)
+			if err != nil {
+				t.Fatalf("ParseVersionRange(%q) error = %v", tt.a, err)
+			}
+			b, err := ParseVersionRange(tt.b)
+			if err != nil {
+				t.Fatalf("ParseVersionRange(%q) error = %v", tt.b, err)
+			}
+			if got := a.Intersects(b); got != tt.want {
+				t.Errorf("Intersects() = %v, want %v", got, tt.want)
+			}
+			if got := b.Intersects(a); got != tt.want {
+				t.Errorf("Intersects() reversed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVersionRange_Invalid(t *testing.T) {
+	for _, spec := range []string{"lts/iron", "node", ">=abc"} {
+		if _, err := ParseVersionRange(spec); err == nil {
+			t.Errorf("ParseVersionRange(%q) expected error", spec)
+		}
+	}
+}
+
+func TestIsExactVersion(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"18.17.0", true},
+		{"v20.1.0", true},
+		{"=18.17.0", true},
+		{"18", false},
+		{">=18.0.0", false},
+		{"^18.0.0", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsExactVersion(tt.input); got != tt.want {
+			t.Errorf("IsExactVersion(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
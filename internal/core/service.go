@@ -2,7 +2,12 @@ package core
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"sort"
+	"strconv"
+	"strings"
 )
 
 // AutoNodeService orchestrates version detection and switching, as well as npm profile switching
@@ -15,6 +20,17 @@ type AutoNodeService struct {
 	managers         []VersionManager
 	profileDetectors []ProfileDetector
 	profileSwitchers []ProfileSwitcher
+	shell            ShellExecutor // Optional: used to inspect the active Node.js version
+	// Optional: detect and activate the package manager (pnpm, yarn) the project pins
+	packageManagerDetectors []PackageManagerDetector
+	packageManagerSwitchers []PackageManagerSwitcher
+	detectionCache          *DetectionCache      // Optional: remembers shell mode detections between runs
+	offline                 bool                 // Never install: versions must already be installed
+	releaseIndex            ReleaseIndexProvider // Optional: resolves aliases the manager doesn't support
+	// Optional: what shell mode does about missing versions (per project, global and the user's answer)
+	settingsReader ProjectSettingsReader
+	autoInstall    AutoInstallPolicy
+	prompter       Prompter
 }
 
 // NewAutoNodeService creates a new AutoNodeService with injected dependencies
@@ -49,6 +65,64 @@ func NewAutoNodeService(
 	}
 }
 
+// SetShellExecutor sets the shell used to inspect the currently active Node.js version
+// (needed by shell mode to restore the previous version when leaving a project)
+func (s *AutoNodeService) SetShellExecutor(shell ShellExecutor) {
+	s.shell = shell
+}
+
+// SetPackageManagerDetectors sets the detectors for the package manager a project pins
+// Detectors are tried in priority order (lower number = higher priority)
+func (s *AutoNodeService) SetPackageManagerDetectors(detectors []PackageManagerDetector) {
+	sorted := make([]PackageManagerDetector, len(detectors))
+	copy(sorted, detectors)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].GetPriority() < sorted[j].GetPriority()
+	})
+	s.packageManagerDetectors = sorted
+}
+
+// SetPackageManagerSwitchers sets the tools that activate the pinned package manager
+// The first installed switcher is used
+func (s *AutoNodeService) SetPackageManagerSwitchers(switchers []PackageManagerSwitcher) {
+	s.packageManagerSwitchers = switchers
+}
+
+// SetDetectionCache sets the cache shell mode uses to skip detection in unchanged projects
+// It is cleared whenever a Node.js version is installed
+func (s *AutoNodeService) SetDetectionCache(cache *DetectionCache) {
+	s.detectionCache = cache
+}
+
+// SetOffline enables offline mode, in which missing Node.js versions are reported
+// instead of installed (installing downloads them)
+func (s *AutoNodeService) SetOffline(offline bool) {
+	s.offline = offline
+}
+
+// SetReleaseIndex sets the release index used to resolve version aliases (lts/iron, node, ...)
+// for managers that don't understand them natively
+func (s *AutoNodeService) SetReleaseIndex(provider ReleaseIndexProvider) {
+	s.releaseIndex = provider
+}
+
+// SetProjectSettingsReader sets the reader for per-project settings (.autonode.yml)
+func (s *AutoNodeService) SetProjectSettingsReader(reader ProjectSettingsReader) {
+	s.settingsReader = reader
+}
+
+// SetAutoInstall sets what shell mode does when a project's version is not installed,
+// unless the project configures it (DefaultAutoInstall when not set)
+func (s *AutoNodeService) SetAutoInstall(policy AutoInstallPolicy) {
+	s.autoInstall = policy
+}
+
+// SetPrompter sets how shell mode offers to install missing versions and reports them
+// (without one, missing versions are only installed with the always policy)
+func (s *AutoNodeService) SetPrompter(prompter Prompter) {
+	s.prompter = prompter
+}
+
 // Run executes the main workflow: detect version, find manager, and switch version
 // When ShellMode is enabled, outputs shell commands instead of executing them
 func (s *AutoNodeService) Run(config Config) error {
@@ -71,7 +145,11 @@ func (s *AutoNodeService) Run(config Config) error {
 		return fmt.Errorf("no version found")
 	}
 
-	s.logger.Success(fmt.Sprintf("Detected Node.js version %s from %s", result.Version, result.Source))
+	if result.Range != "" {
+		s.logger.Success(fmt.Sprintf("Detected Node.js version %s (range %s) from %s", result.Version, result.Range, result.Source))
+	} else {
+		s.logger.Success(fmt.Sprintf("Detected Node.js version %s from %s", result.Version, result.Source))
+	}
 
 	// Detect npm profile configuration (for dry-run display in check mode)
 	profileResult, _ := s.detectProfile(config.ProjectPath)
@@ -79,6 +157,14 @@ func (s *AutoNodeService) Run(config Config) error {
 		s.logger.Success(fmt.Sprintf("Detected npm profile '%s' from %s", profileResult.ProfileName, profileResult.Source))
 	}
 
+	// Detect the pinned package manager (pnpm, yarn)
+	packageManagerResult, err := s.detectPackageManager(config.ProjectPath)
+	if err != nil {
+		s.logger.Warning(fmt.Sprintf("Could not detect package manager: %v", err))
+	} else if packageManagerResult.Found {
+		s.logger.Success(fmt.Sprintf("Detected package manager %s from %s", packageManagerResult.Reference(), packageManagerResult.Source))
+	}
+
 	// If check-only mode, stop here (dry-run completed)
 	if config.CheckOnly {
 		return nil
@@ -92,6 +178,21 @@ func (s *AutoNodeService) Run(config Config) error {
 
 	s.logger.Info(fmt.Sprintf("Using version manager: %s", manager.GetName()))
 
+	// Resolve aliases the manager doesn't understand (e.g. lts/iron for volta)
+	if resolved, err := s.resolveVersionAlias(manager, result); err != nil {
+		s.logger.Error(err.Error())
+		return err
+	} else if resolved.Version != result.Version {
+		s.logger.Info(fmt.Sprintf("Resolved %s to Node.js %s", result.Version, resolved.Version))
+		result = resolved
+	}
+
+	// Prefer an already installed version that satisfies the range
+	if resolved := s.preferInstalledVersion(manager, result); resolved.Version != result.Version {
+		s.logger.Info(fmt.Sprintf("Using installed Node.js %s (satisfies %s)", resolved.Version, result.Range))
+		result = resolved
+	}
+
 	// Step 3: Check if version is already installed
 	installed, err := manager.IsVersionInstalled(result.Version)
 	if err != nil {
@@ -106,7 +207,7 @@ func (s *AutoNodeService) Run(config Config) error {
 			s.logger.Info(fmt.Sprintf("Installing Node.js %s...", result.Version))
 		}
 
-		err = manager.InstallVersion(result.Version)
+		err = s.installVersion(manager, result.Version)
 		if err != nil {
 			s.logger.Error(fmt.Sprintf("Failed to install version: %v", err))
 			return err
@@ -130,21 +231,43 @@ func (s *AutoNodeService) Run(config Config) error {
 	// Step 6: Switch npm profile if configured
 	s.switchProfileIfConfigured(config.ProjectPath)
 
+	// Step 7: Activate the pinned package manager for the new version
+	s.activatePackageManagerIfConfigured(config.ProjectPath, manager, result.Version)
+
+	return nil
+}
+
+// installVersion installs a version with the manager, unless offline mode forbids downloads
+func (s *AutoNodeService) installVersion(manager VersionManager, version string) error {
+	if s.offline {
+		return ErrOffline
+	}
+	if err := manager.InstallVersion(version); err != nil {
+		return err
+	}
+
+	// Ranges cached by shell mode may now resolve to the new version
+	if s.detectionCache != nil {
+		s.detectionCache.Clear()
+	}
 	return nil
 }
 
-// detectVersion tries all detectors in priority order
+// detectVersion tries all detectors in priority order, starting at projectPath and
+// walking up parent directories; the nearest directory with any version source wins
 // Chain of Responsibility Pattern: Try detectors until one succeeds
 func (s *AutoNodeService) detectVersion(projectPath string) (DetectionResult, error) {
-	for _, detector := range s.detectors {
-		result, err := detector.Detect(projectPath)
-		if err != nil {
-			s.logger.Warning(fmt.Sprintf("Detector %s failed: %v", detector.GetSourceName(), err))
-			continue
-		}
+	for _, dir := range searchDirectories(projectPath) {
+		for _, detector := range s.detectors {
+			result, err := detector.Detect(dir)
+			if err != nil {
+				s.logger.Warning(fmt.Sprintf("Detector %s failed: %v", detector.GetSourceName(), err))
+				continue
+			}
 
-		if result.Found {
-			return result, nil
+			if result.Found {
+				return result, nil
+			}
 		}
 	}
 
@@ -160,27 +283,153 @@ func (s *AutoNodeService) findVersionManager() (VersionManager, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("no version manager found (nvm, nvs, or volta)")
+	return nil, fmt.Errorf("no version manager found (nvm, nvs, volta, fnm, asdf, or mise)")
 }
 
-// detectProfile tries all profile detectors in priority order
+// resolveDetectedVersion returns the version the manager is asked for: aliases it doesn't
+// understand are resolved (or passed as written if that fails), and an installed version
+// satisfying a range is preferred
+func (s *AutoNodeService) resolveDetectedVersion(manager VersionManager, result DetectionResult) DetectionResult {
+	if resolved, err := s.resolveVersionAlias(manager, result); err == nil {
+		result = resolved
+	} else {
+		s.logger.Warning(err.Error())
+	}
+	return s.preferInstalledVersion(manager, result)
+}
+
+// resolveVersionAlias turns alias syntax (lts/iron, lts/*, node, stable, iron) into the newest
+// matching release, or the newest installed release of its line, unless the manager supports
+// the alias natively. Other results are returned unchanged.
+func (s *AutoNodeService) resolveVersionAlias(manager VersionManager, result DetectionResult) (DetectionResult, error) {
+	alias := result.Version
+	if !IsVersionAlias(alias) {
+		return result, nil
+	}
+	if supporter, ok := manager.(VersionAliasSupporter); ok && supporter.SupportsAlias(alias) {
+		return result, nil
+	}
+
+	if s.releaseIndex == nil {
+		return result, fmt.Errorf("cannot resolve %s for %s: no release index", alias, manager.GetName())
+	}
+	index, err := s.releaseIndex.GetReleaseIndex()
+	if err != nil {
+		return result, fmt.Errorf("cannot resolve %s for %s: %w", alias, manager.GetName(), err)
+	}
+	release, found := index.Resolve(alias)
+	if !found {
+		return result, fmt.Errorf("cannot resolve %s for %s: no Node.js release matches it", alias, manager.GetName())
+	}
+
+	result.Version = release.Number()
+	// Like nvm, use an installed release of the line rather than downloading the newest
+	line := s.preferInstalledVersion(manager, DetectionResult{Version: strconv.Itoa(release.Major())})
+	if IsExactVersion(line.Version) {
+		result.Version = line.Version
+	}
+
+	return result, nil
+}
+
+// installedVersion returns the installed version the manager has for version: the highest
+// installed match of a partial version or range. Managers that can't list their versions
+// are trusted with version as written.
+func installedVersion(manager VersionManager, version string) (string, bool) {
+	lister, ok := manager.(InstalledVersionLister)
+	if !ok {
+		return version, true
+	}
+
+	installed, err := lister.ListInstalledVersions()
+	if err != nil {
+		return version, true
+	}
+
+	versionRange, err := ParseVersionRange(version)
+	if err != nil {
+		return "", false
+	}
+	return versionRange.MaxSatisfying(installed)
+}
+
+// preferInstalledVersion re-resolves a version range against the versions the manager
+// already has installed, so an installed match wins over a newer release that would
+// need to be downloaded. Partial versions such as "20" are treated as ranges too, since
+// some managers (asdf) only accept exact versions. Other results are returned unchanged.
+func (s *AutoNodeService) preferInstalledVersion(manager VersionManager, result DetectionResult) DetectionResult {
+	spec := result.Range
+	if spec == "" {
+		spec = result.Version
+	}
+	if spec == "" || IsExactVersion(spec) {
+		return result
+	}
+
+	lister, ok := manager.(InstalledVersionLister)
+	if !ok {
+		return result
+	}
+
+	installed, err := lister.ListInstalledVersions()
+	if err != nil {
+		return result
+	}
+
+	versionRange, err := ParseVersionRange(spec)
+	if err != nil {
+		return result
+	}
+
+	if version, found := versionRange.MaxSatisfying(installed); found {
+		result.Version = version
+	}
+
+	return result
+}
+
+// detectProfile tries all profile detectors in priority order, starting at projectPath
+// and walking up parent directories; the nearest directory with a profile wins
 // Chain of Responsibility Pattern: Try detectors until one succeeds
 func (s *AutoNodeService) detectProfile(projectPath string) (ProfileDetectionResult, error) {
-	for _, detector := range s.profileDetectors {
-		result, err := detector.Detect(projectPath)
-		if err != nil {
-			// Silent failure - just try next detector
-			continue
-		}
+	for _, dir := range searchDirectories(projectPath) {
+		for _, detector := range s.profileDetectors {
+			result, err := detector.Detect(dir)
+			if err != nil {
+				// Silent failure - just try next detector
+				continue
+			}
 
-		if result.Found {
-			return result, nil
+			if result.Found {
+				return result, nil
+			}
 		}
 	}
 
 	return ProfileDetectionResult{Found: false}, nil
 }
 
+// detectPackageManager tries all package manager detectors in priority order, starting at
+// projectPath and walking up parent directories; the nearest directory with one wins.
+// Unlike profiles, an invalid pin is reported, since it would break installs.
+// Chain of Responsibility Pattern: Try detectors until one succeeds
+func (s *AutoNodeService) detectPackageManager(projectPath string) (PackageManagerDetectionResult, error) {
+	for _, dir := range searchDirectories(projectPath) {
+		for _, detector := range s.packageManagerDetectors {
+			result, err := detector.Detect(dir)
+			if err != nil {
+				return PackageManagerDetectionResult{Found: false}, fmt.Errorf("%s: %w", detector.GetSourceName(), err)
+			}
+
+			if result.Found {
+				return result, nil
+			}
+		}
+	}
+
+	return PackageManagerDetectionResult{Found: false}, nil
+}
+
 // findProfileSwitcher returns the first installed profile switcher
 // Strategy Pattern: Select the first available strategy
 func (s *AutoNodeService) findProfileSwitcher() ProfileSwitcher {
@@ -240,64 +489,622 @@ func (s *AutoNodeService) switchProfileIfConfigured(projectPath string) {
 	s.logger.Success(fmt.Sprintf("Successfully switched to npm profile '%s'", profileResult.ProfileName))
 }
 
+// findPackageManagerSwitcher returns the first installed package manager switcher
+// Strategy Pattern: Select the first available strategy
+func (s *AutoNodeService) findPackageManagerSwitcher() PackageManagerSwitcher {
+	for _, switcher := range s.packageManagerSwitchers {
+		if switcher.IsInstalled() {
+			return switcher
+		}
+	}
+
+	return nil
+}
+
+// activatePackageManagerIfConfigured activates the package manager the project pins,
+// running the switcher's commands under the Node.js version just switched to.
+// Does nothing when no package manager is pinned; problems are logged as warnings
+// since the Node.js switch itself succeeded.
+func (s *AutoNodeService) activatePackageManagerIfConfigured(projectPath string, manager VersionManager, version string) {
+	result, err := s.detectPackageManager(projectPath)
+	if err != nil {
+		s.logger.Warning(fmt.Sprintf("Could not detect package manager: %v", err))
+		return
+	}
+	if !result.Found {
+		return
+	}
+	// Activating may download the package manager
+	if s.offline {
+		s.logger.Warning(fmt.Sprintf("Offline mode: not activating %s", result.Reference()))
+		return
+	}
+
+	switcher := s.findPackageManagerSwitcher()
+	if switcher == nil {
+		s.logger.Warning(fmt.Sprintf("%s is pinned in %s but corepack is not available", result.Reference(), result.Source))
+		return
+	}
+
+	commands, err := switcher.ActivationCommands(result)
+	if err != nil {
+		s.logger.Warning(err.Error())
+		return
+	}
+
+	s.logger.Info(fmt.Sprintf("Activating %s@%s using %s...", result.Name, result.Version, switcher.GetName()))
+
+	for _, args := range commands {
+		cmd, err := versionCommand(manager, version, args)
+		if err != nil {
+			// The manager can't run commands under a version: use the tool on PATH
+			cmd = exec.Command(args[0], args[1:]...)
+		}
+		if output, err := cmd.CombinedOutput(); err != nil {
+			s.logger.Warning(fmt.Sprintf("Failed to activate %s@%s: %v %s", result.Name, result.Version, err, strings.TrimSpace(string(output))))
+			return
+		}
+	}
+
+	s.logger.Success(fmt.Sprintf("Successfully activated %s@%s", result.Name, result.Version))
+}
+
+// emitPackageManagerActivation outputs the commands that activate the pinned package
+// manager and record it in the shell state. They run after the Node.js switch, so they
+// apply to the new version. Silent when nothing is pinned, the pin is invalid or no
+// switcher is installed.
+func emitPackageManagerActivation(out ShellSyntax, result PackageManagerDetectionResult, switcher PackageManagerSwitcher) {
+	if !result.Found || switcher == nil {
+		return
+	}
+
+	commands, err := switcher.ActivationCommands(result)
+	if err != nil {
+		return
+	}
+
+	for _, args := range commands {
+		out.RunSilently(args)
+	}
+	out.SetEnv(ActivePackageManagerEnvVar, result.Reference())
+}
+
+// detectProject detects everything shell mode needs for projectPath. When a detection
+// cache is set, an unchanged project is served from it; otherwise the result is stored
+// for next time, provided every detector can list the files it reads.
+func (s *AutoNodeService) detectProject(projectPath string) ProjectDetection {
+	dir, err := filepath.Abs(projectPath)
+	if err != nil {
+		dir = projectPath
+	}
+
+	if s.detectionCache == nil {
+		return s.scanProject(dir)
+	}
+
+	if detection, ok := s.detectionCache.Lookup(dir); ok {
+		return detection
+	}
+
+	detection := s.scanProject(dir)
+	if files, ok := s.sourceFiles(dir); ok {
+		// Sources outside the search directories (volta extends, node-version-file)
+		files = append(files, detection.Version.Source, detection.Profile.Source, detection.PackageManager.Source)
+		// Best effort: a detection that can't be cached is simply detected again next time
+		_ = s.detectionCache.Store(dir, detection, files)
+	}
+
+	return detection
+}
+
+// scanProject runs every detector on projectPath and selects the installed tools
+func (s *AutoNodeService) scanProject(projectPath string) ProjectDetection {
+	var detection ProjectDetection
+
+	detection.Version, _ = s.detectVersion(projectPath)
+
+	// The manager is needed to restore the previous version even when nothing is detected
+	if manager, err := s.findVersionManager(); err == nil {
+		detection.Manager = manager.GetName()
+		if detection.Version.Found {
+			detection.Version = s.resolveDetectedVersion(manager, detection.Version)
+		}
+	}
+
+	if result, err := s.detectPackageManager(projectPath); err == nil && result.Found {
+		detection.PackageManager = result
+		if switcher := s.findPackageManagerSwitcher(); switcher != nil {
+			detection.PackageManagerSwitcher = switcher.GetName()
+		}
+	}
+
+	detection.Profile, _ = s.detectProfile(projectPath)
+	if switcher := s.findProfileSwitcher(); switcher != nil {
+		detection.ProfileSwitcher = switcher.GetName()
+	}
+
+	detection.Settings = s.readProjectSettings(projectPath)
+
+	return detection
+}
+
+// readProjectSettings returns the settings of the nearest directory that has any,
+// starting at projectPath and walking up like version detection
+func (s *AutoNodeService) readProjectSettings(projectPath string) ProjectSettings {
+	if s.settingsReader == nil {
+		return ProjectSettings{}
+	}
+
+	for _, dir := range searchDirectories(projectPath) {
+		settings, found, err := s.settingsReader.ReadSettings(dir)
+		if err != nil {
+			s.logger.Warning(fmt.Sprintf("Failed to read project settings: %v", err))
+			return ProjectSettings{}
+		}
+		if found {
+			return settings
+		}
+	}
+	return ProjectSettings{}
+}
+
+// autoInstallPolicy returns what shell mode does about a missing version: the project's
+// policy, then the global one, then DefaultAutoInstall
+func (s *AutoNodeService) autoInstallPolicy(settings ProjectSettings) AutoInstallPolicy {
+	if settings.AutoInstall != "" {
+		return settings.AutoInstall
+	}
+	if s.autoInstall != "" {
+		return s.autoInstall
+	}
+	return DefaultAutoInstall
+}
+
+// sourceFiles returns every file the detectors consult from projectPath up to the
+// repository root, plus each directory's .git (which bounds the search).
+// Returns false if a detector can't list its files, so its result can't be cached.
+func (s *AutoNodeService) sourceFiles(projectPath string) ([]string, bool) {
+	var listers []SourceFileLister
+	for _, detector := range s.detectors {
+		lister, ok := detector.(SourceFileLister)
+		if !ok {
+			return nil, false
+		}
+		listers = append(listers, lister)
+	}
+	for _, detector := range s.profileDetectors {
+		lister, ok := detector.(SourceFileLister)
+		if !ok {
+			return nil, false
+		}
+		listers = append(listers, lister)
+	}
+	for _, detector := range s.packageManagerDetectors {
+		lister, ok := detector.(SourceFileLister)
+		if !ok {
+			return nil, false
+		}
+		listers = append(listers, lister)
+	}
+	if s.settingsReader != nil {
+		lister, ok := s.settingsReader.(SourceFileLister)
+		if !ok {
+			return nil, false
+		}
+		listers = append(listers, lister)
+	}
+
+	var files []string
+	for _, dir := range searchDirectories(projectPath) {
+		files = append(files, filepath.Join(dir, ".git"))
+		for _, lister := range listers {
+			files = append(files, lister.SourceFiles(dir)...)
+		}
+	}
+
+	return files, true
+}
+
+// findByName returns the tool called name, or nil (the zero value) if there is none
+func findByName[T interface{ GetName() string }](tools []T, name string) T {
+	var none T
+	if name == "" {
+		return none
+	}
+	for _, tool := range tools {
+		if tool.GetName() == name {
+			return tool
+		}
+	}
+	return none
+}
+
 // runShellMode outputs shell commands for eval integration (used by shell hooks)
-// This runs silently - no logs, just command output
+// This runs silently - no logs, just command output in the syntax of config.Shell
 func (s *AutoNodeService) runShellMode(config Config) error {
-	// Detect Node.js version silently
-	versionResult, err := s.detectVersion(config.ProjectPath)
-	if err != nil || !versionResult.Found {
-		// Silent failure - no version detected, exit without output
-		return nil
+	out, err := NewShellSyntax(config.Shell)
+	if err != nil {
+		return err
 	}
 
-	// Find installed version manager
-	manager, err := s.findVersionManager()
-	if err != nil {
+	s.emitShellChanges(out, config)
+	fmt.Print(out.String())
+	return nil
+}
+
+// emitShellChanges collects in out the changes that bring the calling shell in line with
+// the project at config.ProjectPath
+func (s *AutoNodeService) emitShellChanges(out ShellSyntax, config Config) {
+	// direnv activates this project's version, and reverts it when leaving
+	if direnvManaged(config.ProjectPath) {
+		return
+	}
+
+	state := LoadShellState()
+
+	// Detect silently, from the detection cache when the project is unchanged
+	detection := s.detectProject(config.ProjectPath)
+	manager := findByName(s.managers, detection.Manager)
+	switcher := findByName(s.profileSwitchers, detection.ProfileSwitcher)
+
+	versionResult := detection.Version
+	if !versionResult.Found {
+		// Left a project: restore whatever was active before autonode switched
+		s.emitNodeRestore(out, manager, state)
+		emitProfileRestore(out, switcher, state)
+		return
+	}
+
+	if manager == nil {
 		// Silent failure - no manager found, exit without output
-		return nil
+		return
+	}
+
+	// Nothing to switch when the shell already runs the requested version
+	switched := !nodeAlreadyActive(manager, versionResult.Version)
+	if switched {
+		// A missing version is installed first, or reported instead of switching to it
+		versionResult, switched = s.ensureVersionInstalled(out, manager, versionResult, s.autoInstallPolicy(detection.Settings), state)
+	}
+	if switched {
+		// Remember the version active before the first switch so it can be restored later
+		if !state.NodeSaved {
+			out.SetEnv(PreviousNodeEnvVar, DetectActiveNodeVersion(s.shell))
+		}
+
+		s.emitNodeSwitch(out, manager, versionResult.Version, state)
+	}
+
+	// Activate the pinned package manager (pnpm, yarn) for the version just switched to;
+	// activations belong to a Node.js version, so a switch invalidates the previous one.
+	// Offline, activating is skipped: it may download the package manager.
+	packageManager := detection.PackageManager
+	if packageManager.Found && !s.offline && (switched || state.ActivePackageManager != packageManager.Reference()) {
+		emitPackageManagerActivation(out, packageManager, findByName(s.packageManagerSwitchers, detection.PackageManagerSwitcher))
+	} else if !packageManager.Found && switched && state.ActivePackageManager != "" {
+		out.UnsetEnv(ActivePackageManagerEnvVar)
+	}
+
+	profileResult := detection.Profile
+	if !profileResult.Found {
+		// No profile configured here - restore the one active before autonode switched
+		emitProfileRestore(out, switcher, state)
+		return
+	}
+
+	if switcher == nil {
+		// No profile switcher installed - exit without error
+		return
+	}
+
+	// Nothing to switch when this shell already switched to the profile
+	if state.ActiveProfile == profileResult.ProfileName {
+		return
+	}
+
+	// Remember the profile active before the first switch so it can be restored later
+	if !state.ProfileSaved {
+		if reader, ok := switcher.(ActiveProfileReader); ok {
+			if current, err := reader.GetActiveProfile(); err == nil && current != "" {
+				if current == profileResult.ProfileName {
+					// Already active: nothing changes, so there is nothing to restore either
+					out.SetEnv(ActiveProfileEnvVar, current)
+					return
+				}
+				out.SetEnv(PreviousProfileEnvVar, current)
+			}
+		}
+	}
+
+	// Output shell command to switch profile
+	emitProfileSwitch(out, switcher, profileResult.ProfileName)
+	out.SetEnv(ActiveProfileEnvVar, profileResult.ProfileName)
+}
+
+// ensureVersionInstalled applies policy when the version shell mode is about to switch to
+// is not installed: it is installed (always, or prompt when the user agrees), otherwise a
+// hint is shown once per shell and version. Returns the version to switch to, and false
+// when it is still missing.
+func (s *AutoNodeService) ensureVersionInstalled(out ShellSyntax, manager VersionManager, result DetectionResult, policy AutoInstallPolicy, state ShellState) (DetectionResult, bool) {
+	if policy == AutoInstallNever {
+		return result, true
+	}
+	// Finding the version on disk is cheap; asking the manager may mean sourcing its scripts
+	if resolver, ok := manager.(InstallDirResolver); ok {
+		if _, err := resolver.ResolveInstallDir(result.Version); err == nil {
+			return result, true
+		}
+	}
+
+	installed, err := manager.IsVersionInstalled(result.Version)
+	if err != nil || installed {
+		// When in doubt, let the manager try
+		return result, true
+	}
+	if state.DeclinedInstall == result.Version {
+		return result, false
+	}
+
+	if s.offline {
+		s.notify(fmt.Sprintf("autonode: Node.js %s is not installed (offline mode)", result.Version))
+		out.SetEnv(DeclinedInstallEnvVar, result.Version)
+		return result, false
+	}
+
+	install := policy == AutoInstallAlways
+	if !install && s.prompter != nil {
+		install = s.prompter.Confirm(fmt.Sprintf("autonode: Node.js %s is not installed. Install it with %s?", result.Version, manager.GetName()))
+	}
+	if !install {
+		s.notify(fmt.Sprintf("autonode: Node.js %s is not installed, run autonode to install it", result.Version))
+		out.SetEnv(DeclinedInstallEnvVar, result.Version)
+		return result, false
 	}
 
-	// Output shell commands based on manager type
+	s.notify(fmt.Sprintf("autonode: installing Node.js %s with %s...", result.Version, manager.GetName()))
+	if err := s.installVersion(manager, result.Version); err != nil {
+		s.notify(fmt.Sprintf("autonode: failed to install Node.js %s: %v", result.Version, err))
+		out.SetEnv(DeclinedInstallEnvVar, result.Version)
+		return result, false
+	}
+
+	// A partial version or range now resolves to the version just installed
+	return s.preferInstalledVersion(manager, result), true
+}
+
+// notify shows message to the user through the prompter, if there is one
+func (s *AutoNodeService) notify(message string) {
+	if s.prompter != nil {
+		s.prompter.Notify(message)
+	}
+}
+
+// emitNodeSwitch outputs the commands that switch the shell to version with manager
+func (s *AutoNodeService) emitNodeSwitch(out ShellSyntax, manager VersionManager, version string, state ShellState) {
 	switch manager.GetName() {
-	case "nvm":
-		// For nvm, output commands to source nvm.sh and use version
-		fmt.Println(`export NVM_DIR="${NVM_DIR:-$HOME/.nvm}"`)
-		fmt.Println(`[ -s "$NVM_DIR/nvm.sh" ] && \. "$NVM_DIR/nvm.sh"`)
-		fmt.Printf("nvm use %s 2>/dev/null\n", versionResult.Version)
-	case "nvs":
-		// For nvs, output commands to source nvs.sh and use version
-		fmt.Println(`export NVS_HOME="${NVS_HOME:-$HOME/.nvs}"`)
-		fmt.Println(`[ -s "$NVS_HOME/nvs.sh" ] && \. "$NVS_HOME/nvs.sh"`)
-		fmt.Printf("nvs use %s 2>/dev/null\n", versionResult.Version)
 	case "volta":
 		// Volta is a standalone binary, doesn't need sourcing
 		// It automatically manages versions per-directory
-		fmt.Printf("volta pin node@%s 2>/dev/null\n", versionResult.Version)
+		out.Run([]string{"volta", "pin", "node@" + version})
+	case "asdf":
+		// asdf shims honour a per-shell override without touching .tool-versions;
+		// it must name an installed version exactly
+		if installed, ok := installedVersion(manager, version); ok {
+			out.SetEnv("ASDF_NODEJS_VERSION", installed)
+		}
+	case "mise":
+		// mise reads the per-shell override; re-evaluate its env so PATH follows
+		// (where mise has no integration for the shell, its own prompt hook applies it)
+		out.SetEnv("MISE_NODE_VERSION", version)
+		out.UseManager("mise", version)
+	default:
+		// Fast path: a version the manager locates on disk only needs its bin directory on
+		// PATH, without sourcing the manager's shell integration (nvm.sh is slow to load)
+		if emitInstallDir(out, manager, version, state) {
+			return
+		}
+
+		// A bin directory put on PATH before would shadow the manager's switch
+		if state.NodeBin != "" {
+			out.SetEnv("PATH", pathWithout(os.Getenv("PATH"), state.NodeBin))
+			out.UnsetEnv(NodeBinEnvVar)
+		}
+
+		// nvm, nvs and fnm switch through their shell integration (aliases, versions they
+		// can't locate on disk); managers without an integration for this shell get the
+		// version's bin directory on PATH
+		if !out.UseManager(manager.GetName(), version) {
+			s.emitNodeBin(out, manager, version, state)
+		}
 	}
+}
 
-	// Detect npm profile configuration silently
-	profileResult, err := s.detectProfile(config.ProjectPath)
-	if err != nil || !profileResult.Found {
-		// No profile configured - exit without error
-		return nil
+// emitInstallDir outputs commands that put the bin directory of version, located on disk
+// by the manager, first on PATH along with the variables the manager's own switch exports.
+// Returns false if the manager can't locate the version that way.
+func emitInstallDir(out ShellSyntax, manager VersionManager, version string, state ShellState) bool {
+	resolver, ok := manager.(InstallDirResolver)
+	if !ok {
+		return false
+	}
+	dir, err := resolver.ResolveInstallDir(version)
+	if err != nil {
+		return false
 	}
 
-	// Find installed profile switcher
-	switcher := s.findProfileSwitcher()
-	if switcher == nil {
-		// No profile switcher installed - exit without error
-		return nil
+	emitBinOnPath(out, filepath.Join(dir, "bin"), state)
+	emitManagerEnv(out, manager, dir)
+	return true
+}
+
+// emitManagerEnv outputs the variables the manager's own switch exports for the version
+// installed in dir (nvm's NVM_BIN and NVM_INC, which nvm and other tools read), or clears
+// them when dir is empty
+func emitManagerEnv(out ShellSyntax, manager VersionManager, dir string) {
+	if manager.GetName() != "nvm" {
+		return
+	}
+	if dir == "" {
+		out.UnsetEnv("NVM_BIN", "NVM_INC")
+		return
+	}
+	out.SetEnv("NVM_BIN", filepath.Join(dir, "bin"))
+	out.SetEnv("NVM_INC", filepath.Join(dir, "include", "node"))
+}
+
+// nodeAlreadyActive reports whether the manager can tell, from the environment, that the
+// calling shell already runs version, so switching again (hundreds of milliseconds with
+// nvm) can be skipped
+func nodeAlreadyActive(manager VersionManager, version string) bool {
+	reader, ok := manager.(ActiveVersionReader)
+	if !ok {
+		return false
+	}
+
+	active := reader.GetActiveVersion()
+	return active != "" && active == strings.TrimPrefix(version, "v")
+}
+
+// emitNodeRestore outputs commands that switch back to the Node.js version recorded
+// before autonode's first switch, then forgets it. Does nothing if nothing was recorded.
+func (s *AutoNodeService) emitNodeRestore(out ShellSyntax, manager VersionManager, state ShellState) {
+	if !state.NodeSaved {
+		return
+	}
+
+	switch {
+	case state.NodeBin != "":
+		// Taking autonode's bin directory off PATH uncovers whatever node was there before
+		path := pathWithout(os.Getenv("PATH"), state.NodeBin)
+		out.SetEnv("PATH", path)
+		out.UnsetEnv(NodeBinEnvVar)
+		// Managers that locate versions on disk had their variables switched too
+		if resolver, ok := manager.(InstallDirResolver); ok {
+			emitManagerEnv(out, manager, uncoveredInstallDir(resolver, state.PreviousNode, path))
+		}
+	case manager == nil:
+		// No manager left to switch back with
+	case manager.GetName() == "asdf":
+		// Dropping the override lets asdf fall back to its own version files
+		out.UnsetEnv("ASDF_NODEJS_VERSION")
+	case manager.GetName() == "mise":
+		out.UnsetEnv("MISE_NODE_VERSION")
+		out.RestoreManager("mise", state.PreviousNode)
+	default:
+		// nvm, nvs and fnm switch back through their shell integration;
+		// Volta resolves the version per directory on its own, nothing to restore
+		out.RestoreManager(manager.GetName(), state.PreviousNode)
+	}
+
+	out.UnsetEnv(PreviousNodeEnvVar)
+
+	// The package manager was activated for the version just switched away from
+	if state.ActivePackageManager != "" {
+		out.UnsetEnv(ActivePackageManagerEnvVar)
+	}
+}
+
+// emitNodeBin outputs commands that put the bin directory of an installed version first
+// on PATH, replacing the one autonode added before. Does nothing if the manager can't
+// locate the version (e.g. it is not installed yet).
+func (s *AutoNodeService) emitNodeBin(out ShellSyntax, manager VersionManager, version string, state ShellState) {
+	bin, err := nodeBinDir(manager, version)
+	if err != nil {
+		return
+	}
+
+	emitBinOnPath(out, bin, state)
+}
+
+// uncoveredInstallDir returns the install directory of the version restored by taking
+// autonode's bin directory off PATH, or empty string if the resolver can't locate it or
+// its bin directory isn't on path
+func uncoveredInstallDir(resolver InstallDirResolver, version, path string) string {
+	if version == "" {
+		return ""
+	}
+	dir, err := resolver.ResolveInstallDir(version)
+	if err != nil {
+		return ""
 	}
 
-	// Output shell command to switch profile based on switcher type
+	bin := filepath.Join(dir, "bin")
+	for _, entry := range filepath.SplitList(path) {
+		if filepath.Clean(entry) == bin {
+			return dir
+		}
+	}
+	return ""
+}
+
+// emitBinOnPath outputs commands that put bin first on PATH, replacing the bin directory
+// autonode added before
+func emitBinOnPath(out ShellSyntax, bin string, state ShellState) {
+	path := pathWithout(os.Getenv("PATH"), state.NodeBin)
+	if path != "" {
+		path = bin + string(os.PathListSeparator) + path
+	} else {
+		path = bin
+	}
+
+	out.SetEnv("PATH", path)
+	out.SetEnv(NodeBinEnvVar, bin)
+}
+
+// nodeBinDir returns the directory holding the node binary of an installed version:
+// the install directory's bin when the manager can locate it, otherwise wherever node
+// says it runs from when started through the manager
+func nodeBinDir(manager VersionManager, version string) (string, error) {
+	if resolver, ok := manager.(InstallDirResolver); ok {
+		dir, err := resolver.ResolveInstallDir(version)
+		if err == nil {
+			return filepath.Join(dir, "bin"), nil
+		}
+		if _, ok := manager.(VersionExecutor); !ok {
+			return "", err
+		}
+	}
+
+	cmd, err := versionCommand(manager, version, []string{"node", "-p", "process.execPath"})
+	if err != nil {
+		return "", err
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+
+	// Managers may print a banner before node's output
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	execPath := strings.TrimSpace(lines[len(lines)-1])
+	if !filepath.IsAbs(execPath) {
+		return "", fmt.Errorf("%s did not report where node %s is installed", manager.GetName(), version)
+	}
+	return filepath.Dir(execPath), nil
+}
+
+// emitProfileRestore outputs commands that switch back to the npm profile recorded
+// before autonode's first profile switch, then forgets it
+func emitProfileRestore(out ShellSyntax, switcher ProfileSwitcher, state ShellState) {
+	if !state.ProfileSaved {
+		return
+	}
+
+	if switcher != nil && state.PreviousProfile != "" {
+		emitProfileSwitch(out, switcher, state.PreviousProfile)
+	}
+
+	out.UnsetEnv(PreviousProfileEnvVar, ActiveProfileEnvVar)
+}
+
+// emitProfileSwitch outputs the shell command to switch profile based on switcher type
+func emitProfileSwitch(out ShellSyntax, switcher ProfileSwitcher, profileName string) {
 	switch switcher.GetName() {
 	case "npmrc":
-		fmt.Printf("npmrc %s 2>/dev/null\n", profileResult.ProfileName)
+		out.Run([]string{"npmrc", profileName})
 	case "ts-npmrc":
-		fmt.Printf("ts-npmrc link -p %s 2>/dev/null\n", profileResult.ProfileName)
+		out.Run([]string{"ts-npmrc", "link", "-p", profileName})
 	case "rc-manager":
-		fmt.Printf("rc-manager load %s 2>/dev/null\n", profileResult.ProfileName)
+		out.Run([]string{"rc-manager", "load", profileName})
 	}
-
-	return nil
 }
@@ -0,0 +1,901 @@
+package core
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fileDetector is a VersionDetector test double that reads a version from a named file
+type fileDetector struct {
+	fileName string
+	priority int
+}
+
+func (d *fileDetector) Detect(projectPath string) (DetectionResult, error) {
+	path := filepath.Join(projectPath, d.fileName)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return DetectionResult{Found: false}, nil
+	}
+	return DetectionResult{Found: true, Version: string(content), Source: path}, nil
+}
+
+func (d *fileDetector) GetPriority() int      { return d.priority }
+func (d *fileDetector) GetSourceName() string { return d.fileName }
+func (d *fileDetector) SourceFiles(projectPath string) []string {
+	return []string{filepath.Join(projectPath, d.fileName)}
+}
+
+func TestAutoNodeService_DetectVersion_WalksUp(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	repo := filepath.Join(tempHome, "repo")
+	nested := filepath.Join(repo, "packages", "web", "src")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("failed to create dirs: %v", err)
+	}
+
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	service := NewAutoNodeService(
+		NewNullLogger(),
+		[]VersionDetector{
+			&fileDetector{fileName: ".nvmrc", priority: 1},
+			&fileDetector{fileName: ".node-version", priority: 2},
+		},
+		nil, nil, nil,
+	)
+
+	t.Run("finds file in repository root", func(t *testing.T) {
+		writeFile(filepath.Join(repo, ".nvmrc"), "18")
+
+		result, err := service.detectVersion(nested)
+		if err != nil {
+			t.Fatalf("detectVersion() error = %v", err)
+		}
+		if !result.Found || result.Version != "18" {
+			t.Errorf("detectVersion() = %+v, want version 18", result)
+		}
+		if want := filepath.Join(repo, ".nvmrc"); result.Source != want {
+			t.Errorf("Source = %q, want %q", result.Source, want)
+		}
+	})
+
+	t.Run("nearest directory wins over priority", func(t *testing.T) {
+		writeFile(filepath.Join(repo, "packages", "web", ".node-version"), "20")
+
+		result, _ := service.detectVersion(nested)
+		if result.Version != "20" {
+			t.Errorf("Version = %q, want 20 from the nearest directory", result.Version)
+		}
+	})
+
+	t.Run("nothing above home directory", func(t *testing.T) {
+		other := t.TempDir()
+		result, _ := service.detectVersion(other)
+		if result.Found {
+			t.Errorf("Found = true, want false in empty directory")
+		}
+	})
+}
+
+// stubManager is a VersionManager test double
+type stubManager struct {
+	name string
+}
+
+func (m *stubManager) GetName() string                         { return m.name }
+func (m *stubManager) IsInstalled() bool                       { return true }
+func (m *stubManager) IsVersionInstalled(string) (bool, error) { return true, nil }
+func (m *stubManager) InstallVersion(string) error             { return nil }
+func (m *stubManager) UseVersion(string) error                 { return nil }
+
+// captureStdout returns everything fn writes to os.Stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+
+	original := os.Stdout
+	os.Stdout = w
+	fn()
+	w.Close()
+	os.Stdout = original
+
+	output, _ := io.ReadAll(r)
+	return string(output)
+}
+
+// unsetEnv removes an environment variable for the duration of the test
+func unsetEnv(t *testing.T, key string) {
+	t.Helper()
+	t.Setenv(key, "")
+	os.Unsetenv(key)
+}
+
+func TestAutoNodeService_RunShellMode_RestoresPreviousVersion(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	elsewhere := filepath.Join(tempHome, "elsewhere")
+	for _, dir := range []string{project, elsewhere} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(project, ".nvmrc"), []byte("20.11.0"), 0644); err != nil {
+		t.Fatalf("failed to write .nvmrc: %v", err)
+	}
+
+	service := NewAutoNodeService(
+		NewNullLogger(),
+		[]VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}},
+		[]VersionManager{&stubManager{name: "nvm"}},
+		nil, nil,
+	)
+
+	t.Run("entering a project records the active version", func(t *testing.T) {
+		unsetEnv(t, PreviousNodeEnvVar)
+		t.Setenv("NVM_BIN", "/home/user/.nvm/versions/node/v18.17.0/bin")
+
+		output := captureStdout(t, func() {
+			service.runShellMode(Config{ProjectPath: project, ShellMode: true})
+		})
+
+		if !strings.Contains(output, "export AUTONODE_PREVIOUS_NODE='18.17.0'") {
+			t.Errorf("output does not record previous version:\n%s", output)
+		}
+		if !strings.Contains(output, "nvm use 20.11.0") {
+			t.Errorf("output does not switch version:\n%s", output)
+		}
+	})
+
+	t.Run("switching between projects keeps the first recorded version", func(t *testing.T) {
+		t.Setenv(PreviousNodeEnvVar, "18.17.0")
+
+		output := captureStdout(t, func() {
+			service.runShellMode(Config{ProjectPath: project, ShellMode: true})
+		})
+
+		if strings.Contains(output, PreviousNodeEnvVar) {
+			t.Errorf("output should not overwrite recorded version:\n%s", output)
+		}
+	})
+
+	t.Run("leaving the project restores the recorded version", func(t *testing.T) {
+		t.Setenv(PreviousNodeEnvVar, "18.17.0")
+
+		output := captureStdout(t, func() {
+			service.runShellMode(Config{ProjectPath: elsewhere, ShellMode: true})
+		})
+
+		if !strings.Contains(output, "nvm use 18.17.0") {
+			t.Errorf("output does not restore previous version:\n%s", output)
+		}
+		if !strings.Contains(output, "unset AUTONODE_PREVIOUS_NODE") {
+			t.Errorf("output does not clear state:\n%s", output)
+		}
+	})
+
+	t.Run("leaving without recorded state prints nothing", func(t *testing.T) {
+		unsetEnv(t, PreviousNodeEnvVar)
+		unsetEnv(t, PreviousProfileEnvVar)
+
+		output := captureStdout(t, func() {
+			service.runShellMode(Config{ProjectPath: elsewhere, ShellMode: true})
+		})
+
+		if output != "" {
+			t.Errorf("output = %q, want empty", output)
+		}
+	})
+}
+
+// listingManager is a VersionManager test double that also reports installed versions
+type listingManager struct {
+	stubManager
+	installed []string
+}
+
+func (m *listingManager) ListInstalledVersions() ([]string, error) { return m.installed, nil }
+
+func TestAutoNodeService_PreferInstalledVersion(t *testing.T) {
+	service := &AutoNodeService{}
+	manager := &listingManager{
+		stubManager: stubManager{name: "asdf"},
+		installed:   []string{"18.17.0", "20.5.0", "20.11.1"},
+	}
+
+	tests := []struct {
+		name   string
+		result DetectionResult
+		want   string
+	}{
+		{"range prefers installed", DetectionResult{Found: true, Version: "22.12.0", Range: ">=20"}, "20.11.1"},
+		{"partial version resolved", DetectionResult{Found: true, Version: "20"}, "20.11.1"},
+		{"exact version unchanged", DetectionResult{Found: true, Version: "20.5.0"}, "20.5.0"},
+		{"alias unchanged", DetectionResult{Found: true, Version: "lts/iron"}, "lts/iron"},
+		{"no installed match", DetectionResult{Found: true, Version: "22"}, "22"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := service.preferInstalledVersion(manager, tt.result)
+			if got.Version != tt.want {
+				t.Errorf("preferInstalledVersion() version = %q, want %q", got.Version, tt.want)
+			}
+		})
+	}
+}
+
+// aliasManager is a VersionManager test double that resolves lts/ aliases itself, like nvm
+type aliasManager struct {
+	stubManager
+}
+
+func (m *aliasManager) SupportsAlias(alias string) bool { return strings.HasPrefix(alias, "lts/") }
+
+// fixedReleaseIndex is a ReleaseIndexProvider test double
+type fixedReleaseIndex struct {
+	index ReleaseIndex
+	err   error
+}
+
+func (p *fixedReleaseIndex) GetReleaseIndex() (ReleaseIndex, error) { return p.index, p.err }
+
+func TestAutoNodeService_ResolveVersionAlias(t *testing.T) {
+	index := &fixedReleaseIndex{index: testIndex}
+
+	tests := []struct {
+		name    string
+		manager VersionManager
+		index   ReleaseIndexProvider
+		version string
+		want    string
+		wantErr bool
+	}{
+		{"codename resolved", &stubManager{name: "fnm"}, index, "lts/iron", "20.18.1", false},
+		{"latest LTS resolved", &stubManager{name: "fnm"}, index, "lts/*", "22.12.0", false},
+		{"bare codename resolved", &stubManager{name: "volta"}, index, "hydrogen", "18.20.5", false},
+		{"installed release preferred", &listingManager{stubManager: stubManager{name: "asdf"}, installed: []string{"20.5.0", "20.11.1"}}, index, "lts/iron", "20.11.1", false},
+		{"supported alias unchanged", &aliasManager{stubManager{name: "nvm"}}, index, "lts/iron", "lts/iron", false},
+		{"unsupported alias resolved", &aliasManager{stubManager{name: "nvm"}}, index, "iron", "20.18.1", false},
+		{"version unchanged", &stubManager{name: "fnm"}, nil, "20.11.0", "20.11.0", false},
+		{"manager-specific word unchanged", &stubManager{name: "fnm"}, index, "system", "system", false},
+		{"iojs unchanged", &stubManager{name: "fnm"}, index, "iojs", "iojs", false},
+		{"unknown codename", &stubManager{name: "fnm"}, index, "lts/argon", "", true},
+		{"no release index", &stubManager{name: "fnm"}, nil, "lts/iron", "", true},
+		{"release index unavailable", &stubManager{name: "fnm"}, &fixedReleaseIndex{err: ErrOffline}, "lts/iron", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AutoNodeService{}
+			if tt.index != nil {
+				service.SetReleaseIndex(tt.index)
+			}
+
+			got, err := service.resolveVersionAlias(tt.manager, DetectionResult{Found: true, Version: tt.version})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveVersionAlias() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Version != tt.want {
+				t.Errorf("resolveVersionAlias() version = %q, want %q", got.Version, tt.want)
+			}
+		})
+	}
+}
+
+// dirManager is a VersionManager test double that resolves install directories
+type dirManager struct {
+	stubManager
+	root string
+}
+
+func (m *dirManager) ResolveInstallDir(version string) (string, error) {
+	return filepath.Join(m.root, version), nil
+}
+
+func TestAutoNodeService_RunShellMode_AsdfOverride(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+	unsetEnv(t, "ASDF_NODEJS_VERSION")
+
+	tests := []struct {
+		name  string
+		nvmrc string
+		want  string // Empty when no override may be exported
+	}{
+		{"exact installed version", "20.5.0", "export ASDF_NODEJS_VERSION='20.5.0'"},
+		{"partial version", "20", "export ASDF_NODEJS_VERSION='20.11.1'"},
+		{"version not installed", "20.11.0", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := t.TempDir()
+			if err := os.WriteFile(filepath.Join(project, ".nvmrc"), []byte(tt.nvmrc), 0644); err != nil {
+				t.Fatalf("failed to write .nvmrc: %v", err)
+			}
+			t.Setenv(PreviousNodeEnvVar, "")
+
+			service := NewAutoNodeService(
+				NewNullLogger(),
+				[]VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}},
+				[]VersionManager{&listingManager{stubManager: stubManager{name: "asdf"}, installed: []string{"18.17.0", "20.5.0", "20.11.1"}}},
+				nil, nil,
+			)
+
+			output := captureStdout(t, func() {
+				service.runShellMode(Config{ProjectPath: project, ShellMode: true})
+			})
+
+			if tt.want == "" {
+				if strings.Contains(output, "ASDF_NODEJS_VERSION") {
+					t.Errorf("output exports an override for a missing version:\n%s", output)
+				}
+			} else if !strings.Contains(output, tt.want) {
+				t.Errorf("output does not contain %q:\n%s", tt.want, output)
+			}
+		})
+	}
+}
+
+func TestAutoNodeService_RunShellMode_StandalonePath(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	elsewhere := filepath.Join(tempHome, "elsewhere")
+	for _, dir := range []string{project, elsewhere} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(project, ".nvmrc"), []byte("20.11.0"), 0644); err != nil {
+		t.Fatalf("failed to write .nvmrc: %v", err)
+	}
+
+	service := NewAutoNodeService(
+		NewNullLogger(),
+		[]VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}},
+		[]VersionManager{&dirManager{stubManager: stubManager{name: "autonode"}, root: "/home/user/.autonode/versions"}},
+		nil, nil,
+	)
+
+	t.Run("switching replaces the previous bin directory", func(t *testing.T) {
+		t.Setenv(PreviousNodeEnvVar, "")
+		t.Setenv(NodeBinEnvVar, "/home/user/.autonode/versions/18.17.0/bin")
+		t.Setenv("PATH", "/home/user/.autonode/versions/18.17.0/bin:/usr/bin")
+
+		output := captureStdout(t, func() {
+			service.runShellMode(Config{ProjectPath: project, ShellMode: true})
+		})
+
+		if !strings.Contains(output, "export PATH='/home/user/.autonode/versions/20.11.0/bin:/usr/bin'") {
+			t.Errorf("output does not update PATH:\n%s", output)
+		}
+		if !strings.Contains(output, "export AUTONODE_NODE_BIN='/home/user/.autonode/versions/20.11.0/bin'") {
+			t.Errorf("output does not record bin directory:\n%s", output)
+		}
+	})
+
+	t.Run("leaving the project takes the bin directory off PATH", func(t *testing.T) {
+		t.Setenv(PreviousNodeEnvVar, "")
+		t.Setenv(NodeBinEnvVar, "/home/user/.autonode/versions/20.11.0/bin")
+		t.Setenv("PATH", "/home/user/.autonode/versions/20.11.0/bin:/usr/bin")
+
+		output := captureStdout(t, func() {
+			service.runShellMode(Config{ProjectPath: elsewhere, ShellMode: true})
+		})
+
+		if !strings.Contains(output, "export PATH='/usr/bin'") {
+			t.Errorf("output does not restore PATH:\n%s", output)
+		}
+		if !strings.Contains(output, "unset AUTONODE_NODE_BIN") {
+			t.Errorf("output does not clear bin directory:\n%s", output)
+		}
+	})
+}
+
+func TestAutoNodeService_RunShellMode_InstallDirFastPath(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	elsewhere := filepath.Join(tempHome, "elsewhere")
+	for _, dir := range []string{project, elsewhere} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(project, ".nvmrc"), []byte("20.11.0"), 0644); err != nil {
+		t.Fatalf("failed to write .nvmrc: %v", err)
+	}
+
+	// nvm with its versions located on disk
+	service := NewAutoNodeService(
+		NewNullLogger(),
+		[]VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}},
+		[]VersionManager{&dirManager{stubManager: stubManager{name: "nvm"}, root: "/home/user/.nvm/versions/node"}},
+		nil, nil,
+	)
+
+	t.Run("switching sets PATH and nvm's variables without sourcing nvm.sh", func(t *testing.T) {
+		t.Setenv(PreviousNodeEnvVar, "18.17.0")
+		t.Setenv(NodeBinEnvVar, "")
+		t.Setenv("NVM_BIN", "/home/user/.nvm/versions/node/18.17.0/bin")
+		t.Setenv("PATH", "/home/user/.nvm/versions/node/18.17.0/bin:/usr/bin")
+
+		output := captureStdout(t, func() {
+			service.runShellMode(Config{ProjectPath: project, ShellMode: true})
+		})
+
+		for _, want := range []string{
+			"export PATH='/home/user/.nvm/versions/node/20.11.0/bin:/home/user/.nvm/versions/node/18.17.0/bin:/usr/bin'",
+			"export NVM_BIN='/home/user/.nvm/versions/node/20.11.0/bin'",
+			"export NVM_INC='/home/user/.nvm/versions/node/20.11.0/include/node'",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("output does not contain %q:\n%s", want, output)
+			}
+		}
+		if strings.Contains(output, "nvm.sh") {
+			t.Errorf("output sources nvm.sh:\n%s", output)
+		}
+	})
+
+	t.Run("leaving points nvm's variables back at the uncovered version", func(t *testing.T) {
+		t.Setenv(PreviousNodeEnvVar, "18.17.0")
+		t.Setenv(NodeBinEnvVar, "/home/user/.nvm/versions/node/20.11.0/bin")
+		t.Setenv("NVM_BIN", "/home/user/.nvm/versions/node/20.11.0/bin")
+		t.Setenv("PATH", "/home/user/.nvm/versions/node/20.11.0/bin:/home/user/.nvm/versions/node/18.17.0/bin:/usr/bin")
+
+		output := captureStdout(t, func() {
+			service.runShellMode(Config{ProjectPath: elsewhere, ShellMode: true})
+		})
+
+		for _, want := range []string{
+			"export PATH='/home/user/.nvm/versions/node/18.17.0/bin:/usr/bin'",
+			"export NVM_BIN='/home/user/.nvm/versions/node/18.17.0/bin'",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("output does not contain %q:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("versions not found on disk switch with the manager", func(t *testing.T) {
+		fallback := NewAutoNodeService(
+			NewNullLogger(),
+			[]VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}},
+			[]VersionManager{&shellManager{stubManager{name: "nvm"}}},
+			nil, nil,
+		)
+		t.Setenv(PreviousNodeEnvVar, "18.17.0")
+		t.Setenv(NodeBinEnvVar, "/home/user/.nvm/versions/node/22.12.0/bin")
+		t.Setenv("NVM_BIN", "/home/user/.nvm/versions/node/22.12.0/bin")
+		t.Setenv("PATH", "/home/user/.nvm/versions/node/22.12.0/bin:/usr/bin")
+
+		output := captureStdout(t, func() {
+			fallback.runShellMode(Config{ProjectPath: project, ShellMode: true})
+		})
+
+		// The bin directory of the fast path comes off PATH before nvm switches
+		pathIndex := strings.Index(output, "export PATH='/usr/bin'")
+		useIndex := strings.Index(output, "nvm use 20.11.0")
+		if pathIndex < 0 || useIndex < pathIndex {
+			t.Errorf("output does not clear PATH before 'nvm use':\n%s", output)
+		}
+	})
+}
+
+// filePackageManagerDetector is a PackageManagerDetector test double that reads "<name>@<version>" from a named file
+type filePackageManagerDetector struct {
+	fileName string
+	priority int
+}
+
+func (d *filePackageManagerDetector) Detect(projectPath string) (PackageManagerDetectionResult, error) {
+	path := filepath.Join(projectPath, d.fileName)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return PackageManagerDetectionResult{Found: false}, nil
+	}
+	name, version, ok := strings.Cut(string(content), "@")
+	if !ok {
+		return PackageManagerDetectionResult{Found: false}, io.ErrUnexpectedEOF
+	}
+	return PackageManagerDetectionResult{Found: true, Name: name, Version: version, Source: path}, nil
+}
+
+func (d *filePackageManagerDetector) GetPriority() int      { return d.priority }
+func (d *filePackageManagerDetector) GetSourceName() string { return d.fileName }
+
+func TestAutoNodeService_DetectPackageManager(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	nested := filepath.Join(project, "src")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("failed to create dirs: %v", err)
+	}
+
+	service := NewAutoNodeService(NewNullLogger(), nil, nil, nil, nil)
+	service.SetPackageManagerDetectors([]PackageManagerDetector{
+		&filePackageManagerDetector{fileName: "pm-low", priority: 2},
+		&filePackageManagerDetector{fileName: "pm-high", priority: 1},
+	})
+
+	if result, err := service.detectPackageManager(nested); err != nil || result.Found {
+		t.Errorf("detectPackageManager() = %+v, %v; want not found", result, err)
+	}
+
+	for name, content := range map[string]string{"pm-low": "yarn@4.1.1", "pm-high": "pnpm@9.1.0"} {
+		if err := os.WriteFile(filepath.Join(project, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	result, err := service.detectPackageManager(nested)
+	if err != nil {
+		t.Fatalf("detectPackageManager() error = %v", err)
+	}
+	if result.Reference() != "pnpm@9.1.0" || result.Source != filepath.Join(project, "pm-high") {
+		t.Errorf("detectPackageManager() = %+v, want pnpm@9.1.0 from pm-high", result)
+	}
+
+	// An invalid pin is an error, not silently ignored
+	if err := os.WriteFile(filepath.Join(project, "pm-high"), []byte("pnpm"), 0644); err != nil {
+		t.Fatalf("failed to write pm-high: %v", err)
+	}
+	if _, err := service.detectPackageManager(nested); err == nil {
+		t.Error("expected error for an invalid package manager")
+	}
+}
+
+func TestPackageManagerDetectionResult_Reference(t *testing.T) {
+	result := PackageManagerDetectionResult{Name: "yarn", Version: "4.1.1", Hash: "sha512.abc"}
+	if got := result.Reference(); got != "yarn@4.1.1+sha512.abc" {
+		t.Errorf("Reference() = %q, want yarn@4.1.1+sha512.abc", got)
+	}
+	result.Hash = ""
+	if got := result.Reference(); got != "yarn@4.1.1" {
+		t.Errorf("Reference() = %q, want yarn@4.1.1", got)
+	}
+}
+
+// stubPackageManagerSwitcher is a PackageManagerSwitcher test double returning fixed commands
+type stubPackageManagerSwitcher struct {
+	commands [][]string
+}
+
+func (s *stubPackageManagerSwitcher) GetName() string   { return "stub" }
+func (s *stubPackageManagerSwitcher) IsInstalled() bool { return true }
+func (s *stubPackageManagerSwitcher) ActivationCommands(PackageManagerDetectionResult) ([][]string, error) {
+	return s.commands, nil
+}
+
+func TestAutoNodeService_PackageManagerActivation(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+	unsetEnv(t, PreviousNodeEnvVar)
+
+	project := filepath.Join(tempHome, "project")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", project, err)
+	}
+	for name, content := range map[string]string{".nvmrc": "20.11.0", "pm": "pnpm@9.1.0"} {
+		if err := os.WriteFile(filepath.Join(project, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	marker := filepath.Join(tempHome, "activated")
+	service := NewAutoNodeService(
+		NewNullLogger(),
+		[]VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}},
+		[]VersionManager{&stubManager{name: "nvm"}},
+		nil, nil,
+	)
+	service.SetPackageManagerDetectors([]PackageManagerDetector{&filePackageManagerDetector{fileName: "pm", priority: 1}})
+	service.SetPackageManagerSwitchers([]PackageManagerSwitcher{&stubPackageManagerSwitcher{
+		commands: [][]string{{"sh", "-c", "echo activated > " + shellQuote(marker)}},
+	}})
+
+	t.Run("shell mode emits the commands after the version switch", func(t *testing.T) {
+		output := captureStdout(t, func() {
+			service.runShellMode(Config{ProjectPath: project, ShellMode: true})
+		})
+
+		switchAt := strings.Index(output, "nvm use 20.11.0")
+		activateAt := strings.Index(output, "sh -c 'echo activated > ")
+		if switchAt < 0 || activateAt < switchAt {
+			t.Errorf("output does not activate the package manager after switching:\n%s", output)
+		}
+	})
+
+	t.Run("run mode runs the commands", func(t *testing.T) {
+		if err := service.Run(Config{ProjectPath: project}); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if _, err := os.Stat(marker); err != nil {
+			t.Errorf("activation command did not run: %v", err)
+		}
+	})
+
+	t.Run("offline mode skips activation", func(t *testing.T) {
+		os.Remove(marker)
+		service.SetOffline(true)
+		defer service.SetOffline(false)
+
+		output := captureStdout(t, func() {
+			service.runShellMode(Config{ProjectPath: project, ShellMode: true})
+		})
+		if strings.Contains(output, "echo activated") || strings.Contains(output, ActivePackageManagerEnvVar) {
+			t.Errorf("offline shell mode activates the package manager:\n%s", output)
+		}
+
+		if err := service.Run(Config{ProjectPath: project}); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if _, err := os.Stat(marker); err == nil {
+			t.Error("offline run mode ran the activation command")
+		}
+	})
+}
+
+func TestAutoNodeService_Run_Offline(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", project, err)
+	}
+	if err := os.WriteFile(filepath.Join(project, ".nvmrc"), []byte("20.11.0"), 0644); err != nil {
+		t.Fatalf("failed to write .nvmrc: %v", err)
+	}
+
+	detectors := []VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}}
+
+	t.Run("missing version is not installed", func(t *testing.T) {
+		manager := &execManager{stubManager: stubManager{name: "nvm"}}
+		service := NewAutoNodeService(NewNullLogger(), detectors, []VersionManager{manager}, nil, nil)
+		service.SetOffline(true)
+
+		if err := service.Run(Config{ProjectPath: project}); !errors.Is(err, ErrOffline) {
+			t.Errorf("Run() error = %v, want %v", err, ErrOffline)
+		}
+		if manager.installed {
+			t.Error("offline mode installed a version")
+		}
+	})
+
+	t.Run("installed version is used", func(t *testing.T) {
+		manager := &execManager{stubManager: stubManager{name: "nvm"}, installed: true}
+		service := NewAutoNodeService(NewNullLogger(), detectors, []VersionManager{manager}, nil, nil)
+		service.SetOffline(true)
+
+		if err := service.Run(Config{ProjectPath: project}); err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	})
+}
+
+// countingDetector is a fileDetector that counts how often it runs
+type countingDetector struct {
+	fileDetector
+	calls int
+}
+
+func (d *countingDetector) Detect(projectPath string) (DetectionResult, error) {
+	d.calls++
+	return d.fileDetector.Detect(projectPath)
+}
+
+// unlistedDetector hides the SourceFiles method of the detector it wraps
+type unlistedDetector struct {
+	VersionDetector
+}
+
+func TestAutoNodeService_RunShellMode_DetectionCache(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+	t.Setenv(PreviousNodeEnvVar, "")
+
+	project := filepath.Join(tempHome, "project")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", project, err)
+	}
+	// Backdated, so the cache doesn't consider the file racy
+	past := time.Now().Add(-time.Hour)
+	writeTestFile(t, filepath.Join(project, ".nvmrc"), "20.11.0", past)
+
+	newCachedService := func(detector VersionDetector) *AutoNodeService {
+		service := NewAutoNodeService(NewNullLogger(), []VersionDetector{detector}, []VersionManager{&stubManager{name: "nvm"}}, nil, nil)
+		service.SetDetectionCache(NewDetectionCache(&CacheManager{cacheDir: t.TempDir()}))
+		return service
+	}
+	runShell := func(service *AutoNodeService) string {
+		return captureStdout(t, func() {
+			service.runShellMode(Config{ProjectPath: project, ShellMode: true})
+		})
+	}
+
+	t.Run("unchanged project is served from the cache", func(t *testing.T) {
+		detector := &countingDetector{fileDetector: fileDetector{fileName: ".nvmrc", priority: 1}}
+		service := newCachedService(detector)
+
+		first, second := runShell(service), runShell(service)
+		if first != second || !strings.Contains(second, "nvm use 20.11.0") {
+			t.Errorf("cached output differs:\n%s\nvs\n%s", first, second)
+		}
+		if detector.calls != 1 {
+			t.Errorf("detector ran %d times, want 1", detector.calls)
+		}
+
+		writeTestFile(t, filepath.Join(project, ".nvmrc"), "22.12.0", past.Add(time.Minute))
+		if output := runShell(service); !strings.Contains(output, "nvm use 22.12.0") {
+			t.Errorf("output after editing .nvmrc:\n%s", output)
+		}
+		if detector.calls != 2 {
+			t.Errorf("detector ran %d times after editing .nvmrc, want 2", detector.calls)
+		}
+	})
+
+	t.Run("detectors without source files are never cached", func(t *testing.T) {
+		detector := &countingDetector{fileDetector: fileDetector{fileName: ".nvmrc", priority: 1}}
+		service := newCachedService(&unlistedDetector{detector})
+
+		runShell(service)
+		runShell(service)
+		if detector.calls != 2 {
+			t.Errorf("detector ran %d times, want 2", detector.calls)
+		}
+	})
+}
+
+// activeManager is a VersionManager test double that reports the active version
+type activeManager struct {
+	stubManager
+	active string
+}
+
+func (m *activeManager) GetActiveVersion() string { return m.active }
+
+// fixedProfileDetector is a ProfileDetector test double that always finds the same profile
+type fixedProfileDetector struct {
+	profile string
+}
+
+func (d *fixedProfileDetector) Detect(string) (ProfileDetectionResult, error) {
+	return ProfileDetectionResult{Found: true, ProfileName: d.profile, Source: ".autonode.yml"}, nil
+}
+func (d *fixedProfileDetector) GetPriority() int      { return 0 }
+func (d *fixedProfileDetector) GetSourceName() string { return ".autonode.yml" }
+
+// stubProfileSwitcher is a ProfileSwitcher test double that reports the active profile
+type stubProfileSwitcher struct {
+	active string
+}
+
+func (s *stubProfileSwitcher) GetName() string                    { return "npmrc" }
+func (s *stubProfileSwitcher) IsInstalled() bool                  { return true }
+func (s *stubProfileSwitcher) ProfileExists(string) (bool, error) { return true, nil }
+func (s *stubProfileSwitcher) SwitchProfile(string) error         { return nil }
+func (s *stubProfileSwitcher) GetActiveProfile() (string, error)  { return s.active, nil }
+
+func TestAutoNodeService_RunShellMode_SkipsActiveVersion(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", project, err)
+	}
+	for name, content := range map[string]string{".nvmrc": "20.11.0", "pm": "pnpm@9.1.0"} {
+		if err := os.WriteFile(filepath.Join(project, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		activeNode    string
+		activeProfile string // Profile reported by the switcher
+		env           map[string]string
+		want          []string
+		notWant       []string
+	}{
+		{
+			name:       "version and profile already active",
+			activeNode: "20.11.0",
+			env:        map[string]string{PreviousNodeEnvVar: "18.17.0", ActiveProfileEnvVar: "work", ActivePackageManagerEnvVar: "pnpm@9.1.0"},
+			notWant:    []string{"nvm use", "npmrc", "corepack", "export"},
+		},
+		{
+			name:       "other version active",
+			activeNode: "18.17.0",
+			env:        map[string]string{PreviousNodeEnvVar: "18.17.0", ActiveProfileEnvVar: "work", ActivePackageManagerEnvVar: "pnpm@9.1.0"},
+			want:       []string{"nvm use 20.11.0", "corepack prepare pnpm@9.1.0", "export AUTONODE_ACTIVE_PACKAGE_MANAGER='pnpm@9.1.0'"},
+			notWant:    []string{"npmrc work"},
+		},
+		{
+			name:       "version active on first visit is not recorded",
+			activeNode: "20.11.0",
+			env:        map[string]string{ActiveProfileEnvVar: "work"},
+			want:       []string{"corepack prepare pnpm@9.1.0"},
+			notWant:    []string{"nvm use", PreviousNodeEnvVar},
+		},
+		{
+			name:       "other profile active",
+			activeNode: "20.11.0",
+			env:        map[string]string{PreviousProfileEnvVar: "personal", ActiveProfileEnvVar: "personal", ActivePackageManagerEnvVar: "pnpm@9.1.0"},
+			want:       []string{"npmrc work", "export AUTONODE_ACTIVE_PROFILE='work'"},
+			notWant:    []string{"nvm use", "corepack"},
+		},
+		{
+			name:          "profile already active on first visit",
+			activeNode:    "20.11.0",
+			activeProfile: "work",
+			env:           map[string]string{ActivePackageManagerEnvVar: "pnpm@9.1.0"},
+			want:          []string{"export AUTONODE_ACTIVE_PROFILE='work'"},
+			notWant:       []string{"npmrc work", PreviousProfileEnvVar},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{PreviousNodeEnvVar, PreviousProfileEnvVar, ActiveProfileEnvVar, ActivePackageManagerEnvVar} {
+				unsetEnv(t, key)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			service := NewAutoNodeService(
+				NewNullLogger(),
+				[]VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}},
+				[]VersionManager{&activeManager{stubManager: stubManager{name: "nvm"}, active: tt.activeNode}},
+				[]ProfileDetector{&fixedProfileDetector{profile: "work"}},
+				[]ProfileSwitcher{&stubProfileSwitcher{active: tt.activeProfile}},
+			)
+			service.SetPackageManagerDetectors([]PackageManagerDetector{&filePackageManagerDetector{fileName: "pm", priority: 1}})
+			service.SetPackageManagerSwitchers([]PackageManagerSwitcher{&stubPackageManagerSwitcher{
+				commands: [][]string{{"corepack", "prepare", "pnpm@9.1.0", "--activate"}},
+			}})
+
+			output := captureStdout(t, func() {
+				service.runShellMode(Config{ProjectPath: project, ShellMode: true})
+			})
+
+			for _, want := range tt.want {
+				if !strings.Contains(output, want) {
+					t.Errorf("output does not contain %q:\n%s", want, output)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(output, notWant) {
+					t.Errorf("output contains %q:\n%s", notWant, output)
+				}
+			}
+		})
+	}
+}
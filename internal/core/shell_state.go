@@ -0,0 +1,120 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// PreviousNodeEnvVar holds the Node.js version that was active before autonode
+	// switched versions in the current shell (empty when no node was active)
+	PreviousNodeEnvVar = "AUTONODE_PREVIOUS_NODE"
+	// PreviousProfileEnvVar holds the npm profile that was active before autonode
+	// switched profiles in the current shell
+	PreviousProfileEnvVar = "AUTONODE_PREVIOUS_PROFILE"
+	// NodeBinEnvVar holds the bin directory autonode prepended to PATH, so the next
+	// switch (or leaving the project) can take it out again
+	NodeBinEnvVar = "AUTONODE_NODE_BIN"
+	// ActiveProfileEnvVar holds the npm profile autonode last switched to in the current
+	// shell, so entering another directory with the same profile doesn't switch again
+	ActiveProfileEnvVar = "AUTONODE_ACTIVE_PROFILE"
+	// ActivePackageManagerEnvVar holds the package manager autonode last activated in the
+	// current shell ("pnpm@9.1.0"), so it isn't activated again for the same Node.js version
+	ActivePackageManagerEnvVar = "AUTONODE_ACTIVE_PACKAGE_MANAGER"
+	// DeclinedInstallEnvVar holds the missing Node.js version autonode last reported (or
+	// offered to install) in the current shell, so it isn't reported again on every cd
+	DeclinedInstallEnvVar = "AUTONODE_DECLINED_INSTALL"
+)
+
+// ShellState describes what autonode changed in the current shell session.
+// It lives in environment variables exported by `autonode shell`, so every shell
+// keeps its own state and it disappears when the shell exits.
+// Single Responsibility Principle: Only responsible for holding per-shell state
+type ShellState struct {
+	// NodeSaved is true once autonode has recorded the version active before its first switch
+	NodeSaved bool
+	// PreviousNode is the version to restore when leaving a project (may be empty)
+	PreviousNode string
+	// ProfileSaved is true once autonode has recorded the profile active before its first switch
+	ProfileSaved bool
+	// PreviousProfile is the npm profile to restore when leaving a project
+	PreviousProfile string
+	// NodeBin is the bin directory autonode put on PATH (empty if it didn't)
+	NodeBin string
+	// ActiveProfile is the npm profile autonode last switched to (empty if it didn't)
+	ActiveProfile string
+	// ActivePackageManager is the package manager autonode last activated (empty if it didn't)
+	ActivePackageManager string
+	// DeclinedInstall is the missing version autonode last reported (empty if none)
+	DeclinedInstall string
+}
+
+// LoadShellState reads the shell state from the environment inherited from the shell
+func LoadShellState() ShellState {
+	var state ShellState
+	state.PreviousNode, state.NodeSaved = os.LookupEnv(PreviousNodeEnvVar)
+	state.PreviousProfile, state.ProfileSaved = os.LookupEnv(PreviousProfileEnvVar)
+	state.NodeBin = os.Getenv(NodeBinEnvVar)
+	state.ActiveProfile = os.Getenv(ActiveProfileEnvVar)
+	state.ActivePackageManager = os.Getenv(ActivePackageManagerEnvVar)
+	state.DeclinedInstall = os.Getenv(DeclinedInstallEnvVar)
+	return state
+}
+
+// DetectActiveNodeVersion returns the Node.js version active in the calling shell
+// (without "v" prefix), or empty string if no node is available.
+// $NVM_BIN is checked first because it avoids spawning a node process.
+func DetectActiveNodeVersion(shell ShellExecutor) string {
+	if version := NvmBinVersion(); version != "" {
+		return version
+	}
+
+	if shell == nil || !shell.CommandExists("node") {
+		return ""
+	}
+
+	output, err := shell.Execute("node", "--version")
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.TrimSpace(output), "v")
+}
+
+// NvmBinVersion returns the version nvm activated in the calling shell (without "v"
+// prefix), or empty string if nvm isn't active.
+// nvm exports NVM_BIN=~/.nvm/versions/node/v20.11.0/bin
+func NvmBinVersion() string {
+	nvmBin := os.Getenv("NVM_BIN")
+	if nvmBin == "" {
+		return ""
+	}
+	return strings.TrimPrefix(filepath.Base(filepath.Dir(nvmBin)), "v")
+}
+
+// shellQuote quotes a value for safe use in POSIX shell output
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// shellJoin returns a command line for the shell, quoting only the arguments that need it
+func shellJoin(args []string) string {
+	return joinArgs(args, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789@%+=:,./_-", shellQuote)
+}
+
+// pathWithout returns the PATH value without any occurrence of dir
+func pathWithout(path, dir string) string {
+	if dir == "" {
+		return path
+	}
+
+	var kept []string
+	for _, entry := range filepath.SplitList(path) {
+		if entry != dir {
+			kept = append(kept, entry)
+		}
+	}
+
+	return strings.Join(kept, string(os.PathListSeparator))
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+)
+
+// stubShell is a ShellExecutor test double returning canned output
+type stubShell struct {
+	commands map[string]string // command -> output of Execute
+}
+
+func (s *stubShell) Execute(command string, args ...string) (string, error) {
+	if output, ok := s.commands[command]; ok {
+		return output, nil
+	}
+	return "", fmt.Errorf("unexpected command: %s", command)
+}
+
+func (s *stubShell) ExecuteInShell(command string) (string, error) {
+	return s.Execute(command)
+}
+
+func (s *stubShell) CommandExists(command string) bool {
+	_, ok := s.commands[command]
+	return ok
+}
+
+func TestLoadShellState(t *testing.T) {
+	t.Run("nothing saved", func(t *testing.T) {
+		unsetEnv(t, PreviousNodeEnvVar)
+		unsetEnv(t, PreviousProfileEnvVar)
+
+		state := LoadShellState()
+		if state.NodeSaved || state.ProfileSaved {
+			t.Errorf("LoadShellState() = %+v, want nothing saved", state)
+		}
+	})
+
+	t.Run("empty previous version still counts as saved", func(t *testing.T) {
+		t.Setenv(PreviousNodeEnvVar, "")
+		t.Setenv(PreviousProfileEnvVar, "work")
+
+		state := LoadShellState()
+		if !state.NodeSaved || state.PreviousNode != "" {
+			t.Errorf("NodeSaved = %v, PreviousNode = %q, want saved and empty", state.NodeSaved, state.PreviousNode)
+		}
+		if !state.ProfileSaved || state.PreviousProfile != "work" {
+			t.Errorf("ProfileSaved = %v, PreviousProfile = %q, want saved 'work'", state.ProfileSaved, state.PreviousProfile)
+		}
+	})
+
+	t.Run("active profile and package manager", func(t *testing.T) {
+		t.Setenv(ActiveProfileEnvVar, "work")
+		t.Setenv(ActivePackageManagerEnvVar, "pnpm@9.1.0")
+
+		state := LoadShellState()
+		if state.ActiveProfile != "work" || state.ActivePackageManager != "pnpm@9.1.0" {
+			t.Errorf("LoadShellState() = %+v, want active work and pnpm@9.1.0", state)
+		}
+	})
+}
+
+func TestDetectActiveNodeVersion(t *testing.T) {
+	t.Run("from NVM_BIN", func(t *testing.T) {
+		t.Setenv("NVM_BIN", "/home/user/.nvm/versions/node/v20.11.0/bin")
+		if got := DetectActiveNodeVersion(nil); got != "20.11.0" {
+			t.Errorf("DetectActiveNodeVersion() = %q, want 20.11.0", got)
+		}
+	})
+
+	t.Run("from node --version", func(t *testing.T) {
+		t.Setenv("NVM_BIN", "")
+		shell := &stubShell{commands: map[string]string{"node": "v18.17.0\n"}}
+		if got := DetectActiveNodeVersion(shell); got != "18.17.0" {
+			t.Errorf("DetectActiveNodeVersion() = %q, want 18.17.0", got)
+		}
+	})
+
+	t.Run("no node available", func(t *testing.T) {
+		t.Setenv("NVM_BIN", "")
+		if got := DetectActiveNodeVersion(&stubShell{}); got != "" {
+			t.Errorf("DetectActiveNodeVersion() = %q, want empty", got)
+		}
+	})
+}
+
+func TestShellQuote(t *testing.T) {
+	if got := shellQuote("it's"); got != `'it'\''s'` {
+		t.Errorf("shellQuote() = %s", got)
+	}
+}
+
+func TestShellJoin(t *testing.T) {
+	got := shellJoin([]string{"corepack", "prepare", "pnpm@9.1.0", "--activate", "two words", ""})
+	if want := `corepack prepare pnpm@9.1.0 --activate 'two words' ''`; got != want {
+		t.Errorf("shellJoin() = %s, want %s", got, want)
+	}
+}
+
+func TestPathWithout(t *testing.T) {
+	tests := []struct {
+		path string
+		dir  string
+		want string
+	}{
+		{"/a/bin:/usr/bin:/a/bin", "/a/bin", "/usr/bin"},
+		{"/usr/bin:/bin", "/a/bin", "/usr/bin:/bin"},
+		{"/usr/bin", "", "/usr/bin"},
+	}
+
+	for _, tt := range tests {
+		if got := pathWithout(tt.path, tt.dir); got != tt.want {
+			t.Errorf("pathWithout(%q, %q) = %q, want %q", tt.path, tt.dir, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ShellNames lists the shells `autonode shell --shell` can write output for
+var ShellNames = []string{"bash", "zsh", "fish", "pwsh", "nu", "elvish"}
+
+// ShellSyntax collects the changes shell mode makes to the calling shell and renders
+// them in that shell's language
+// Open/Closed Principle: Supporting another shell means adding a ShellSyntax;
+// runShellMode only says what changes, never how a shell spells it
+type ShellSyntax interface {
+	// GetName returns the shell name as given to --shell
+	GetName() string
+	// SetEnv sets an environment variable in the calling shell
+	SetEnv(name, value string)
+	// UnsetEnv removes environment variables from the calling shell
+	UnsetEnv(names ...string)
+	// Run runs a command, hiding its errors and ignoring its failure
+	Run(args []string)
+	// RunSilently runs a command, hiding all of its output and ignoring its failure
+	RunSilently(args []string)
+	// UseManager switches to version through the manager's own shell integration
+	// (sourcing nvm.sh, evaluating 'fnm env', ...).
+	// Returns false if the manager has no integration for this shell.
+	UseManager(manager, version string) bool
+	// RestoreManager switches back to previous (empty if no node was active) through the
+	// manager's own shell integration. Returns false if it has none for this shell.
+	RestoreManager(manager, previous string) bool
+	// String returns the output for the shell to evaluate
+	String() string
+}
+
+// NewShellSyntax returns the ShellSyntax for the named shell (bash when empty)
+func NewShellSyntax(shell string) (ShellSyntax, error) {
+	switch shell {
+	case "", "bash", "zsh":
+		if shell == "" {
+			shell = "bash"
+		}
+		return &posixSyntax{name: shell}, nil
+	case "fish":
+		return &fishSyntax{}, nil
+	case "pwsh":
+		return &pwshSyntax{}, nil
+	case "nu":
+		return newNuSyntax(), nil
+	case "elvish":
+		return &elvishSyntax{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported shell %q (supported: %s)", shell, strings.Join(ShellNames, ", "))
+	}
+}
+
+// scriptLines accumulates output one line at a time for shells that evaluate code
+type scriptLines struct {
+	lines []string
+}
+
+// add appends a formatted line
+func (s *scriptLines) add(format string, args ...interface{}) {
+	s.lines = append(s.lines, fmt.Sprintf(format, args...))
+}
+
+// String returns the accumulated lines, each terminated by a newline
+func (s *scriptLines) String() string {
+	if len(s.lines) == 0 {
+		return ""
+	}
+	return strings.Join(s.lines, "\n") + "\n"
+}
+
+// joinArgs returns a command line, quoting with quote only the arguments that contain
+// characters outside safe
+func joinArgs(args []string, safe string, quote func(string) string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && strings.Trim(arg, safe) == "" {
+			quoted[i] = arg
+		} else {
+			quoted[i] = quote(arg)
+		}
+	}
+	return strings.Join(quoted, " ")
+}
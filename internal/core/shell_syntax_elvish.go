@@ -0,0 +1,50 @@
+package core
+
+import "strings"
+
+// elvishSafeChars are the characters elvish reads literally in a bareword
+const elvishSafeChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789@%+=:,./_-"
+
+// elvishSyntax writes output for elvish, evaluated with `eval (autonode shell --shell elvish | slurp)`
+// Failing commands raise exceptions in elvish, so every command is wrapped in try.
+// Only mise has an elvish integration; other managers' versions are put on PATH directly.
+type elvishSyntax struct {
+	scriptLines
+}
+
+func (e *elvishSyntax) GetName() string { return "elvish" }
+
+func (e *elvishSyntax) SetEnv(name, value string) {
+	e.add("set-env %s %s", name, elvishQuote(value))
+}
+
+func (e *elvishSyntax) UnsetEnv(names ...string) {
+	for _, name := range names {
+		e.add("unset-env %s", name)
+	}
+}
+
+func (e *elvishSyntax) Run(args []string) {
+	e.add("try { %s 2>/dev/null } catch { }", joinArgs(args, elvishSafeChars, elvishQuote))
+}
+
+func (e *elvishSyntax) RunSilently(args []string) {
+	e.add("try { %s >/dev/null 2>/dev/null } catch { }", joinArgs(args, elvishSafeChars, elvishQuote))
+}
+
+func (e *elvishSyntax) UseManager(manager, version string) bool {
+	if manager != "mise" {
+		return false
+	}
+	e.add("try { eval (mise env -s elvish 2>/dev/null | slurp) } catch { }")
+	return true
+}
+
+func (e *elvishSyntax) RestoreManager(manager, previous string) bool {
+	return e.UseManager(manager, previous)
+}
+
+// elvishQuote quotes a value for elvish, where quotes are doubled inside single quotes
+func elvishQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// fishSafeChars are the characters fish reads literally outside quotes
+const fishSafeChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789@+=:,./_-"
+
+// fishSyntax writes output for fish, evaluated with `autonode shell --shell fish | source`
+// nvm and nvs are bash functions fish can't run, so their versions are put on PATH directly
+type fishSyntax struct {
+	scriptLines
+}
+
+func (f *fishSyntax) GetName() string { return "fish" }
+
+func (f *fishSyntax) SetEnv(name, value string) {
+	if name == "PATH" {
+		// fish keeps PATH as a list: one element per directory
+		var dirs []string
+		for _, dir := range filepath.SplitList(value) {
+			dirs = append(dirs, fishQuote(dir))
+		}
+		f.add("set -gx PATH %s", strings.Join(dirs, " "))
+		return
+	}
+	f.add("set -gx %s %s", name, fishQuote(value))
+}
+
+func (f *fishSyntax) UnsetEnv(names ...string) {
+	f.add("set -e %s", strings.Join(names, " "))
+}
+
+func (f *fishSyntax) Run(args []string) {
+	f.add("%s 2>/dev/null", joinArgs(args, fishSafeChars, fishQuote))
+}
+
+func (f *fishSyntax) RunSilently(args []string) {
+	f.add("%s >/dev/null 2>&1", joinArgs(args, fishSafeChars, fishQuote))
+}
+
+func (f *fishSyntax) UseManager(manager, version string) bool {
+	switch manager {
+	case "fnm":
+		f.add("set -q FNM_MULTISHELL_PATH; or fnm env --shell fish | source")
+		f.add("fnm use %s --silent-if-unchanged 2>/dev/null", joinArgs([]string{version}, fishSafeChars, fishQuote))
+	case "mise":
+		f.add("mise env -s fish 2>/dev/null | source")
+	default:
+		return false
+	}
+	return true
+}
+
+func (f *fishSyntax) RestoreManager(manager, previous string) bool {
+	switch manager {
+	case "fnm":
+		if previous != "" {
+			f.UseManager(manager, previous)
+		}
+	case "mise":
+		f.UseManager(manager, previous)
+	default:
+		return false
+	}
+	return true
+}
+
+// fishQuote quotes a value for fish, where backslashes and quotes are escaped inside single quotes
+func fishQuote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return "'" + strings.ReplaceAll(value, "'", `\'`) + "'"
+}
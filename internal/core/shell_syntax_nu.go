@@ -0,0 +1,81 @@
+package core
+
+import (
+	"encoding/json"
+	"path/filepath"
+)
+
+// nuSyntax writes output for Nushell, which can't evaluate generated code. The output is
+// a JSON record the hook applies: {"set": {...}, "unset": [...], "run": [[...], ...]}.
+// Variables are unset and set before commands run, so commands see the new PATH.
+// No manager has a Nushell integration autonode can drive, so versions are put on PATH directly.
+type nuSyntax struct {
+	changes nuChanges
+}
+
+// nuChanges is the record `autonode shell --shell nu` prints
+type nuChanges struct {
+	Set   map[string]interface{} `json:"set"`
+	Unset []string               `json:"unset"`
+	Run   [][]string             `json:"run"`
+}
+
+// newNuSyntax creates a nuSyntax with no changes
+func newNuSyntax() *nuSyntax {
+	return &nuSyntax{changes: nuChanges{
+		Set:   map[string]interface{}{},
+		Unset: []string{},
+		Run:   [][]string{},
+	}}
+}
+
+func (n *nuSyntax) GetName() string { return "nu" }
+
+func (n *nuSyntax) SetEnv(name, value string) {
+	if name == "PATH" {
+		// Nushell keeps PATH as a list: one element per directory
+		n.changes.Set[name] = filepath.SplitList(value)
+	} else {
+		n.changes.Set[name] = value
+	}
+	n.changes.Unset = withoutString(n.changes.Unset, name)
+}
+
+func (n *nuSyntax) UnsetEnv(names ...string) {
+	for _, name := range names {
+		delete(n.changes.Set, name)
+		n.changes.Unset = append(withoutString(n.changes.Unset, name), name)
+	}
+}
+
+// Run records a command for the hook, which ignores its output and failure
+func (n *nuSyntax) Run(args []string) {
+	n.changes.Run = append(n.changes.Run, args)
+}
+
+func (n *nuSyntax) RunSilently(args []string) {
+	n.Run(args)
+}
+
+func (n *nuSyntax) UseManager(string, string) bool { return false }
+
+func (n *nuSyntax) RestoreManager(string, string) bool { return false }
+
+func (n *nuSyntax) String() string {
+	data, err := json.MarshalIndent(n.changes, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(data) + "\n"
+}
+
+// withoutString returns values without any occurrence of value
+func withoutString(values []string, value string) []string {
+	kept := values[:0]
+	for _, v := range values {
+		if v != value {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,90 @@
+package core
+
+import "strings"
+
+// posixSyntax writes output for bash and zsh
+type posixSyntax struct {
+	scriptLines
+	name string
+}
+
+func (p *posixSyntax) GetName() string { return p.name }
+
+func (p *posixSyntax) SetEnv(name, value string) {
+	p.add("export %s=%s", name, shellQuote(value))
+}
+
+func (p *posixSyntax) UnsetEnv(names ...string) {
+	p.add("unset %s", strings.Join(names, " "))
+}
+
+func (p *posixSyntax) Run(args []string) {
+	p.add("%s 2>/dev/null", shellJoin(args))
+}
+
+func (p *posixSyntax) RunSilently(args []string) {
+	p.add("%s >/dev/null 2>&1", shellJoin(args))
+}
+
+func (p *posixSyntax) UseManager(manager, version string) bool {
+	switch manager {
+	case "nvm":
+		// nvm is a shell function: source nvm.sh, then use the version
+		p.sourceNvm()
+		p.add("nvm use %s 2>/dev/null", shellJoin([]string{version}))
+	case "nvs":
+		// nvs is a shell function too
+		p.sourceNvs()
+		p.add("nvs use %s 2>/dev/null", shellJoin([]string{version}))
+	case "fnm":
+		// fnm needs its environment set up once per shell before 'fnm use' works
+		p.add(`[ -n "$FNM_MULTISHELL_PATH" ] || eval "$(fnm env)"`)
+		p.add("fnm use %s --silent-if-unchanged 2>/dev/null", shellJoin([]string{version}))
+	case "mise":
+		// Re-evaluate mise's env so PATH follows MISE_NODE_VERSION
+		p.add(`eval "$(mise env -s %s 2>/dev/null)"`, p.name)
+	default:
+		return false
+	}
+	return true
+}
+
+func (p *posixSyntax) RestoreManager(manager, previous string) bool {
+	switch manager {
+	case "nvm":
+		p.sourceNvm()
+		if previous == "" {
+			// No node was active before: drop nvm's node from PATH
+			p.add("nvm deactivate >/dev/null 2>&1")
+		} else {
+			// The previous node may be a system install nvm doesn't know about
+			p.add("nvm use %s >/dev/null 2>&1 || nvm deactivate >/dev/null 2>&1", shellJoin([]string{previous}))
+		}
+	case "nvs":
+		if previous != "" {
+			p.sourceNvs()
+			p.add("nvs use %s 2>/dev/null", shellJoin([]string{previous}))
+		}
+	case "fnm":
+		if previous != "" {
+			p.UseManager(manager, previous)
+		}
+	case "mise":
+		p.UseManager(manager, previous)
+	default:
+		return false
+	}
+	return true
+}
+
+// sourceNvm loads nvm into the shell
+func (p *posixSyntax) sourceNvm() {
+	p.add(`export NVM_DIR="${NVM_DIR:-$HOME/.nvm}"`)
+	p.add(`[ -s "$NVM_DIR/nvm.sh" ] && \. "$NVM_DIR/nvm.sh"`)
+}
+
+// sourceNvs loads nvs into the shell
+func (p *posixSyntax) sourceNvs() {
+	p.add(`export NVS_HOME="${NVS_HOME:-$HOME/.nvs}"`)
+	p.add(`[ -s "$NVS_HOME/nvs.sh" ] && \. "$NVS_HOME/nvs.sh"`)
+}
@@ -0,0 +1,77 @@
+package core
+
+import "strings"
+
+// pwshSafeChars are the characters PowerShell reads literally in a command argument
+// ("," builds arrays and a leading "@" splats, so neither is included)
+const pwshSafeChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789%+=:./_-"
+
+// pwshSyntax writes output for PowerShell, evaluated with
+// `autonode shell --shell pwsh | Out-String | Invoke-Expression`
+// nvm and nvs are bash functions PowerShell can't run, so their versions are put on PATH directly
+type pwshSyntax struct {
+	scriptLines
+}
+
+func (p *pwshSyntax) GetName() string { return "pwsh" }
+
+func (p *pwshSyntax) SetEnv(name, value string) {
+	p.add("$env:%s = %s", name, pwshQuote(value))
+}
+
+func (p *pwshSyntax) UnsetEnv(names ...string) {
+	items := make([]string, len(names))
+	for i, name := range names {
+		items[i] = "Env:" + name
+	}
+	p.add("Remove-Item %s -ErrorAction SilentlyContinue", strings.Join(items, ", "))
+}
+
+func (p *pwshSyntax) Run(args []string) {
+	p.add("%s 2>$null", pwshJoin(args))
+}
+
+func (p *pwshSyntax) RunSilently(args []string) {
+	p.add("%s *>$null", pwshJoin(args))
+}
+
+func (p *pwshSyntax) UseManager(manager, version string) bool {
+	switch manager {
+	case "fnm":
+		p.add("if (-not $env:FNM_MULTISHELL_PATH) { fnm env --shell powershell | Out-String | Invoke-Expression }")
+		p.add("fnm use %s --silent-if-unchanged 2>$null", joinArgs([]string{version}, pwshSafeChars, pwshQuote))
+	case "mise":
+		p.add("mise env -s pwsh 2>$null | Out-String | Invoke-Expression")
+	default:
+		return false
+	}
+	return true
+}
+
+func (p *pwshSyntax) RestoreManager(manager, previous string) bool {
+	switch manager {
+	case "fnm":
+		if previous != "" {
+			p.UseManager(manager, previous)
+		}
+	case "mise":
+		p.UseManager(manager, previous)
+	default:
+		return false
+	}
+	return true
+}
+
+// pwshJoin returns a PowerShell command line; the program is called with & when it needs quoting
+func pwshJoin(args []string) string {
+	line := joinArgs(args, pwshSafeChars, pwshQuote)
+	if strings.HasPrefix(line, "'") {
+		return "& " + line
+	}
+	return line
+}
+
+// pwshQuote quotes a value for PowerShell, where quotes are doubled inside single quotes
+func pwshQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
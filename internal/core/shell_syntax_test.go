@@ -0,0 +1,186 @@
+package core
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// updateGolden rewrites testdata/shell/<shell>/<manager>.golden from the current output
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// shellManager is a VersionManager test double that runs commands under a version the way
+// nvm, fnm, ... do; node's reported location is faked with echo
+type shellManager struct {
+	stubManager
+}
+
+func (m *shellManager) ExecArgs(version string, args []string) ([]string, error) {
+	return []string{"echo", "/opt/node/" + version + "/bin/node"}, nil
+}
+
+func TestAutoNodeService_RunShellMode_Golden(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+
+	project := filepath.Join(tempHome, "project")
+	elsewhere := filepath.Join(tempHome, "elsewhere")
+	for _, dir := range []string{project, elsewhere} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+	}
+	for name, content := range map[string]string{".nvmrc": "20.11.0", "pm": "pnpm@9.1.0"} {
+		if err := os.WriteFile(filepath.Join(project, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	managers := map[string]VersionManager{
+		"autonode": &dirManager{stubManager: stubManager{name: "autonode"}, root: "/home/user/.autonode/versions"},
+	}
+	for _, name := range []string{"nvm", "nvs", "volta", "fnm", "asdf", "mise"} {
+		managers[name] = &shellManager{stubManager{name: name}}
+	}
+
+	for _, shell := range ShellNames {
+		for name, manager := range managers {
+			t.Run(shell+"/"+name, func(t *testing.T) {
+				service := NewAutoNodeService(
+					NewNullLogger(),
+					[]VersionDetector{&fileDetector{fileName: ".nvmrc", priority: 1}},
+					[]VersionManager{manager},
+					[]ProfileDetector{&fixedProfileDetector{profile: "work"}},
+					[]ProfileSwitcher{&stubProfileSwitcher{active: "personal"}},
+				)
+				service.SetPackageManagerDetectors([]PackageManagerDetector{&filePackageManagerDetector{fileName: "pm", priority: 1}})
+				service.SetPackageManagerSwitchers([]PackageManagerSwitcher{&stubPackageManagerSwitcher{
+					commands: [][]string{{"corepack", "prepare", "pnpm@9.1.0", "--activate"}},
+				}})
+
+				// Entering the project for the first time, with nvm's 18.17.0 active
+				for _, key := range []string{PreviousNodeEnvVar, PreviousProfileEnvVar, NodeBinEnvVar, ActiveProfileEnvVar, ActivePackageManagerEnvVar} {
+					unsetEnv(t, key)
+				}
+				t.Setenv("NVM_BIN", "/home/user/.nvm/versions/node/v18.17.0/bin")
+				t.Setenv("PATH", "/usr/local/bin:/usr/bin")
+				enter := captureStdout(t, func() {
+					if err := service.Run(Config{ProjectPath: project, ShellMode: true, Shell: shell}); err != nil {
+						t.Fatalf("Run() error = %v", err)
+					}
+				})
+
+				// Leaving it again, with the state entering left behind
+				t.Setenv(PreviousNodeEnvVar, "18.17.0")
+				t.Setenv(PreviousProfileEnvVar, "personal")
+				t.Setenv(ActiveProfileEnvVar, "work")
+				t.Setenv(ActivePackageManagerEnvVar, "pnpm@9.1.0")
+				if strings.Contains(enter, NodeBinEnvVar) {
+					bin := "/opt/node/20.11.0/bin"
+					if name == "autonode" {
+						bin = "/home/user/.autonode/versions/20.11.0/bin"
+					}
+					t.Setenv(NodeBinEnvVar, bin)
+					t.Setenv("PATH", bin+":/usr/local/bin:/usr/bin")
+				}
+				leave := captureStdout(t, func() {
+					if err := service.Run(Config{ProjectPath: elsewhere, ShellMode: true, Shell: shell}); err != nil {
+						t.Fatalf("Run() error = %v", err)
+					}
+				})
+
+				got := "# cd project\n" + enter + "# cd ..\n" + leave
+				golden := filepath.Join("testdata", "shell", shell, name+".golden")
+				if *updateGolden {
+					if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+						t.Fatalf("failed to create %s: %v", filepath.Dir(golden), err)
+					}
+					if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+						t.Fatalf("failed to write %s: %v", golden, err)
+					}
+				}
+
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("failed to read %s (run with -update to create it): %v", golden, err)
+				}
+				if got != string(want) {
+					t.Errorf("output differs from %s:\n--- got\n%s--- want\n%s", golden, got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestNewShellSyntax(t *testing.T) {
+	for _, shell := range ShellNames {
+		syntax, err := NewShellSyntax(shell)
+		if err != nil || syntax.GetName() != shell {
+			t.Errorf("NewShellSyntax(%q) = %v, %v", shell, syntax, err)
+		}
+	}
+
+	if syntax, err := NewShellSyntax(""); err != nil || syntax.GetName() != "bash" {
+		t.Errorf("NewShellSyntax(\"\") = %v, %v, want bash", syntax, err)
+	}
+	if _, err := NewShellSyntax("tcsh"); err == nil {
+		t.Error("expected error for an unsupported shell")
+	}
+}
+
+func TestShellSyntax_Quoting(t *testing.T) {
+	value := `it's a \ test`
+	tests := []struct {
+		shell string
+		want  string
+	}{
+		{"bash", `export NAME='it'\''s a \ test'`},
+		{"fish", `set -gx NAME 'it\'s a \\ test'`},
+		{"pwsh", `$env:NAME = 'it''s a \ test'`},
+		{"elvish", `set-env NAME 'it''s a \ test'`},
+		{"nu", `"NAME": "it's a \\ test"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			syntax, _ := NewShellSyntax(tt.shell)
+			syntax.SetEnv("NAME", value)
+			if got := syntax.String(); !strings.Contains(got, tt.want) {
+				t.Errorf("SetEnv() output = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+
+	// Versions come from project files, so they must never be evaluated as code
+	version := "$(touch /tmp/pwned)"
+	managerTests := []struct {
+		shell   string
+		manager string
+		want    []string
+	}{
+		{"bash", "nvm", []string{`nvm use '$(touch /tmp/pwned)' 2>/dev/null`, `nvm use '$(touch /tmp/pwned)' >/dev/null 2>&1`}},
+		{"bash", "nvs", []string{`nvs use '$(touch /tmp/pwned)' 2>/dev/null`}},
+		{"zsh", "fnm", []string{`fnm use '$(touch /tmp/pwned)' --silent-if-unchanged`}},
+		{"fish", "fnm", []string{`fnm use '$(touch /tmp/pwned)' --silent-if-unchanged`}},
+		{"pwsh", "fnm", []string{`fnm use '$(touch /tmp/pwned)' --silent-if-unchanged`}},
+	}
+
+	for _, tt := range managerTests {
+		t.Run(tt.shell+"/"+tt.manager, func(t *testing.T) {
+			syntax, _ := NewShellSyntax(tt.shell)
+			syntax.UseManager(tt.manager, version)
+			syntax.RestoreManager(tt.manager, version)
+			got := syntax.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("UseManager()/RestoreManager() output = %q, want it to contain %q", got, want)
+				}
+			}
+			if strings.Contains(got, " "+version) {
+				t.Errorf("UseManager()/RestoreManager() output = %q, version is not quoted", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package core
+
+// SourceFileLister is an optional interface for detectors that can tell which files
+// their Detect reads in a directory, whether they exist or not.
+// The detection cache fingerprints these files to know when a cached result is stale,
+// so it is only used when every detector implements this interface.
+//
+// Interface Segregation Principle: Kept separate from the detector interfaces so
+// detectors that don't support caching don't have to implement it
+type SourceFileLister interface {
+	// SourceFiles returns the paths of the files (and directories that are listed,
+	// such as .github/workflows) Detect consults in projectPath
+	SourceFiles(projectPath string) []string
+}
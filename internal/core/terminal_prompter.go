@@ -0,0 +1,49 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TerminalPrompter is a Prompter for the terminal the shell runs in. It opens the terminal
+// itself: shell hooks capture autonode's stdout and usually discard its stderr.
+// Without a terminal (scripts, CI), messages go to stderr and questions are answered no.
+// Single Responsibility Principle: Only responsible for terminal interaction
+type TerminalPrompter struct{}
+
+// NewTerminalPrompter creates a new TerminalPrompter
+func NewTerminalPrompter() *TerminalPrompter {
+	return &TerminalPrompter{}
+}
+
+// Notify writes message to the terminal, or to stderr without one
+func (p *TerminalPrompter) Notify(message string) {
+	_, out, release, err := openTerminal()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, message)
+		return
+	}
+	defer release()
+
+	fmt.Fprintln(out, message)
+}
+
+// Confirm asks question on the terminal and reads the answer; only y or yes accepts
+func (p *TerminalPrompter) Confirm(question string) bool {
+	in, out, release, err := openTerminal()
+	if err != nil {
+		return false
+	}
+	defer release()
+
+	fmt.Fprintf(out, "%s [y/N] ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
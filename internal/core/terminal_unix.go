@@ -0,0 +1,17 @@
+//go:build !windows
+
+package core
+
+import (
+	"io"
+	"os"
+)
+
+// openTerminal opens the controlling terminal; release closes it
+func openTerminal() (in io.Reader, out io.Writer, release func(), err error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return tty, tty, func() { tty.Close() }, nil
+}
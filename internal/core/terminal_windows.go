@@ -0,0 +1,22 @@
+//go:build windows
+
+package core
+
+import (
+	"io"
+	"os"
+)
+
+// openTerminal opens the console's input and output buffers; release closes them
+func openTerminal() (in io.Reader, out io.Writer, release func(), err error) {
+	conin, err := os.OpenFile("CONIN$", os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	conout, err := os.OpenFile("CONOUT$", os.O_RDWR, 0)
+	if err != nil {
+		conin.Close()
+		return nil, nil, nil, err
+	}
+	return conin, conout, func() { conin.Close(); conout.Close() }, nil
+}
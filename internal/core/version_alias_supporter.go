@@ -0,0 +1,13 @@
+package core
+
+// VersionAliasSupporter is an optional interface for version managers that resolve
+// alias syntax (lts/*, lts/iron, node, ...) themselves.
+// Aliases a manager supports are passed to it untouched; any other alias is resolved to a
+// concrete version with the release index first.
+//
+// Interface Segregation Principle: Kept separate from VersionManager so managers
+// that only take version numbers don't have to implement it
+type VersionAliasSupporter interface {
+	// SupportsAlias reports whether the manager understands the alias (see IsVersionAlias)
+	SupportsAlias(alias string) bool
+}
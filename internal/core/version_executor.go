@@ -0,0 +1,12 @@
+package core
+
+// VersionExecutor is an optional interface for version managers that can run a
+// command under a specific Node.js version without switching the calling shell
+// (e.g. 'nvm exec', 'volta run'). Used by `autonode exec`.
+//
+// Interface Segregation Principle: Kept separate from VersionManager so managers
+// without such a command don't have to implement it
+type VersionExecutor interface {
+	// ExecArgs returns the full command line (program first) that runs args under version
+	ExecArgs(version string, args []string) ([]string, error)
+}
@@ -0,0 +1,12 @@
+package core
+
+// VersionSourceFixer is an optional interface for version detectors that can rewrite
+// the version in the file they read it from. `autonode lint --fix` uses it to make
+// lower-priority sources agree with the authoritative one.
+//
+// Interface Segregation Principle: Kept separate from VersionDetector so detectors
+// that can't safely edit their source don't have to implement it
+type VersionSourceFixer interface {
+	// FixVersion replaces the version found in result (as returned by Detect) with version
+	FixVersion(result DetectionResult, version string) error
+}
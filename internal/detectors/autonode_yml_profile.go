@@ -68,3 +68,8 @@ func (d *AutonodeYmlProfileDetector) GetPriority() int {
 func (d *AutonodeYmlProfileDetector) GetSourceName() string {
 	return ".autonode.yml"
 }
+
+// SourceFiles returns the files Detect reads in projectPath
+func (d *AutonodeYmlProfileDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, ".autonode.yml")
+}
@@ -0,0 +1,59 @@
+package detectors
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"gopkg.in/yaml.v3"
+)
+
+// AutonodeYmlSettingsReader reads the project settings kept in .autonode.yml (autoInstall).
+//
+// This reader adheres to:
+// - Single Responsibility Principle (SRP): Only handles .autonode.yml project settings
+// - Liskov Substitution Principle (LSP): Implements ProjectSettingsReader interface
+type AutonodeYmlSettingsReader struct{}
+
+// autonodeYmlSettingsConfig represents the structure of .autonode.yml file for project settings
+type autonodeYmlSettingsConfig struct {
+	AutoInstall string `yaml:"autoInstall"`
+}
+
+// NewAutonodeYmlSettingsReader creates a new AutonodeYmlSettingsReader instance.
+func NewAutonodeYmlSettingsReader() *AutonodeYmlSettingsReader {
+	return &AutonodeYmlSettingsReader{}
+}
+
+// ReadSettings reads the settings from .autonode.yml in projectPath.
+// A file without any of them is not found, so settings further up still apply.
+func (r *AutonodeYmlSettingsReader) ReadSettings(projectPath string) (core.ProjectSettings, bool, error) {
+	data, err := os.ReadFile(filepath.Join(projectPath, projectSettingsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return core.ProjectSettings{}, false, nil
+		}
+		return core.ProjectSettings{}, false, err
+	}
+
+	var config autonodeYmlSettingsConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return core.ProjectSettings{}, false, err
+	}
+	if strings.TrimSpace(config.AutoInstall) == "" {
+		return core.ProjectSettings{}, false, nil
+	}
+
+	policy, err := core.ParseAutoInstallPolicy(config.AutoInstall)
+	if err != nil {
+		return core.ProjectSettings{}, false, fmt.Errorf("%s: %w", projectSettingsFile, err)
+	}
+	return core.ProjectSettings{AutoInstall: policy}, true, nil
+}
+
+// SourceFiles returns the files ReadSettings reads in projectPath
+func (r *AutonodeYmlSettingsReader) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, projectSettingsFile)
+}
@@ -0,0 +1,74 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// Ensure AutonodeYmlSettingsReader can be cached by shell mode
+var (
+	_ core.ProjectSettingsReader = (*AutonodeYmlSettingsReader)(nil)
+	_ core.SourceFileLister      = (*AutonodeYmlSettingsReader)(nil)
+)
+
+func TestAutonodeYmlSettingsReader_ReadSettings(t *testing.T) {
+	reader := NewAutonodeYmlSettingsReader()
+
+	tests := []struct {
+		name        string
+		fileContent string // empty means no file
+		wantFound   bool
+		wantPolicy  core.AutoInstallPolicy
+		wantErr     bool
+	}{
+		{
+			name: "no file",
+		},
+		{
+			name:        "no settings",
+			fileContent: "nodeVersion: 20",
+		},
+		{
+			name:        "autoInstall",
+			fileContent: "nodeVersion: 20\nautoInstall: always",
+			wantFound:   true,
+			wantPolicy:  core.AutoInstallAlways,
+		},
+		{
+			name:        "autoInstall is case-insensitive",
+			fileContent: "autoInstall: Never",
+			wantFound:   true,
+			wantPolicy:  core.AutoInstallNever,
+		},
+		{
+			name:        "invalid autoInstall",
+			fileContent: "autoInstall: sometimes",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.fileContent != "" {
+				if err := os.WriteFile(filepath.Join(dir, ".autonode.yml"), []byte(tt.fileContent), 0644); err != nil {
+					t.Fatalf("failed to write .autonode.yml: %v", err)
+				}
+			}
+
+			settings, found, err := reader.ReadSettings(dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if found != tt.wantFound {
+				t.Errorf("ReadSettings() found = %v, want %v", found, tt.wantFound)
+			}
+			if settings.AutoInstall != tt.wantPolicy {
+				t.Errorf("ReadSettings() autoInstall = %q, want %q", settings.AutoInstall, tt.wantPolicy)
+			}
+		})
+	}
+}
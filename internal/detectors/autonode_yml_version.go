@@ -3,6 +3,7 @@ package detectors
 import (
 	"os"
 	"path/filepath"
+	"regexp"
 
 	"github.com/matutetandil/autonode/internal/core"
 	"gopkg.in/yaml.v3"
@@ -21,6 +22,9 @@ type autonodeYmlVersionConfig struct {
 	NodeVersion string `yaml:"nodeVersion"`
 }
 
+// autonodeYmlVersionPattern matches the nodeVersion value on its line, with or without quotes
+var autonodeYmlVersionPattern = regexp.MustCompile(`^(\s*nodeVersion\s*:\s*["']?)([^"'\s#]+)`)
+
 // NewAutonodeYmlVersionDetector creates a new AutonodeYmlVersionDetector instance.
 func NewAutonodeYmlVersionDetector() *AutonodeYmlVersionDetector {
 	return &AutonodeYmlVersionDetector{}
@@ -54,10 +58,42 @@ func (d *AutonodeYmlVersionDetector) Detect(projectPath string) (core.DetectionR
 	return core.DetectionResult{
 		Found:   true,
 		Version: config.NodeVersion,
-		Source:  ".autonode.yml",
+		Source:  filePath,
+		Line:    yamlKeyLine(data, "nodeVersion"),
 	}, nil
 }
 
+// FixVersion replaces the nodeVersion value with version, keeping the rest of the file as written.
+func (d *AutonodeYmlVersionDetector) FixVersion(result core.DetectionResult, version string) error {
+	return rewriteVersionOnLine(result.Source, result.Line, autonodeYmlVersionPattern, version)
+}
+
+// yamlKeyLine returns the 1-based line of a top-level key's value (0 if not found)
+func yamlKeyLine(data []byte, key string) int {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return 0
+	}
+
+	if value := yamlMappingValue(doc.Content[0], key); value != nil {
+		return value.Line
+	}
+	return 0
+}
+
+// yamlMappingValue returns the value node of key in a mapping node (nil if absent)
+func yamlMappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
 // GetPriority returns the priority of this detector.
 // Priority 0 means highest priority (checked first, before .nvmrc).
 func (d *AutonodeYmlVersionDetector) GetPriority() int {
@@ -68,3 +104,8 @@ func (d *AutonodeYmlVersionDetector) GetPriority() int {
 func (d *AutonodeYmlVersionDetector) GetSourceName() string {
 	return ".autonode.yml"
 }
+
+// SourceFiles returns the files Detect reads in projectPath
+func (d *AutonodeYmlVersionDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, ".autonode.yml")
+}
@@ -118,8 +118,8 @@ func TestAutonodeYmlVersionDetector_Detect(t *testing.T) {
 				if result.Version != tt.expectVer {
 					t.Errorf("Version = %q, want %q", result.Version, tt.expectVer)
 				}
-				if result.Source != tt.expectSource {
-					t.Errorf("Source = %q, want %q", result.Source, tt.expectSource)
+				if wantSource := filepath.Join(tmpDir, tt.expectSource); result.Source != wantSource {
+					t.Errorf("Source = %q, want %q", result.Source, wantSource)
 				}
 			}
 		})
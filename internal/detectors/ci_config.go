@@ -0,0 +1,168 @@
+package detectors
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"gopkg.in/yaml.v3"
+)
+
+// Matrix selection policies for the ciMatrix setting in .autonode.yml
+const (
+	matrixHighest = "highest"
+	matrixLowest  = "lowest"
+	matrixFirst   = "first"
+	matrixLast    = "last"
+)
+
+var (
+	// ciPartialVersion matches versions written as a major or major.minor, with or
+	// without trailing x-ranges: "20", "20.x", "20.11.x"
+	ciPartialVersion = regexp.MustCompile(`^v?(\d+(?:\.\d+)?)(?:\.[xX*])*$`)
+	// ciVersionNumbers extracts the leading numbers of a version to order matrix entries
+	ciVersionNumbers = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+	// ciVersionPattern matches the version written on a CI config line: the value of
+	// "key: value" or "- value", or the tag of a node image. Lines holding a flow
+	// sequence ("[18, 20]") don't match, since the entry to rewrite can't be told apart.
+	ciVersionPattern = regexp.MustCompile(`^([^\[]*?(?:node:|:\s*["']?|-\s*["']?))(\d+(?:\.[0-9xX*]+)*)`)
+)
+
+// resolveCIVersion converts a CI version spec (as accepted by actions/setup-node or the
+// CircleCI node orb) to a version and, when it was resolved from one, the original range.
+// "20.x" becomes "20"; ranges resolve to the highest release; aliases ("lts/*", "node") are kept.
+// Returns an empty version for anything else (expressions, shell syntax, ...), which
+// must never reach a shell as a version.
+func resolveCIVersion(client releasesClient, spec string) (string, string) {
+	spec = strings.TrimSpace(spec)
+
+	if matches := ciPartialVersion.FindStringSubmatch(spec); matches != nil {
+		return matches[1], ""
+	}
+	if core.IsExactVersion(spec) {
+		return strings.TrimPrefix(strings.TrimPrefix(spec, "="), "v"), ""
+	}
+	if versionRange, err := core.ParseVersionRange(spec); err == nil {
+		if version := resolveVersionRange(client, versionRange); version != "" {
+			return version, spec
+		}
+	}
+
+	if core.IsVersionAlias(spec) {
+		return spec, ""
+	}
+
+	return "", ""
+}
+
+// pickMatrixEntry returns the matrix entry chosen by policy: the highest version by
+// default, the lowest, or the first or last entry as written.
+// Entries that don't start with a version number (e.g. "lts/*") are only picked
+// by position, or when no entry is numeric.
+func pickMatrixEntry(entries []*yaml.Node, policy string) *yaml.Node {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	switch policy {
+	case matrixFirst:
+		return entries[0]
+	case matrixLast:
+		return entries[len(entries)-1]
+	}
+
+	var best *yaml.Node
+	var bestKey []int
+	for _, entry := range entries {
+		key := versionNumbers(entry.Value)
+		if key == nil {
+			continue
+		}
+		cmp := 0
+		if best != nil {
+			cmp = compareVersionNumbers(key, bestKey)
+		}
+		if best == nil || (policy == matrixLowest && cmp < 0) || (policy != matrixLowest && cmp > 0) {
+			best, bestKey = entry, key
+		}
+	}
+
+	if best == nil {
+		return entries[0]
+	}
+	return best
+}
+
+// versionNumbers returns the major, minor and patch numbers a version starts with (nil if none)
+func versionNumbers(version string) []int {
+	matches := ciVersionNumbers.FindStringSubmatch(strings.TrimSpace(version))
+	if matches == nil {
+		return nil
+	}
+
+	numbers := make([]int, 3)
+	for i := range numbers {
+		numbers[i], _ = strconv.Atoi(matches[i+1])
+	}
+	return numbers
+}
+
+// compareVersionNumbers compares two results of versionNumbers
+func compareVersionNumbers(a, b []int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// yamlScalars returns the scalar values of a node: the node itself for a scalar,
+// its items for a sequence
+func yamlScalars(node *yaml.Node) []*yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return []*yaml.Node{node}
+	case yaml.SequenceNode:
+		var scalars []*yaml.Node
+		for _, item := range node.Content {
+			if item.Kind == yaml.ScalarNode {
+				scalars = append(scalars, item)
+			}
+		}
+		return scalars
+	}
+	return nil
+}
+
+// yamlPath follows mapping keys from node, returning nil when one is missing
+func yamlPath(node *yaml.Node, keys ...string) *yaml.Node {
+	for _, key := range keys {
+		if node == nil {
+			return nil
+		}
+		node = yamlMappingValue(node, key)
+	}
+	return node
+}
+
+// parseYamlDocument parses data and returns the root node of its first document
+// (nil for an empty document)
+func parseYamlDocument(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	return doc.Content[0], nil
+}
@@ -0,0 +1,73 @@
+package detectors
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestPickMatrixEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		policy  string
+		want    string
+	}{
+		{name: "highest by default", entries: []string{"18", "22.x", "20"}, want: "22.x"},
+		{name: "highest compares numerically", entries: []string{"9", "10.1", "10.0.5"}, policy: matrixHighest, want: "10.1"},
+		{name: "lowest", entries: []string{"20", "18.20", "18.3"}, policy: matrixLowest, want: "18.3"},
+		{name: "first", entries: []string{"lts/*", "20"}, policy: matrixFirst, want: "lts/*"},
+		{name: "last", entries: []string{"20", "18"}, policy: matrixLast, want: "18"},
+		{name: "aliases skipped when ordering", entries: []string{"lts/*", "18", "node"}, want: "18"},
+		{name: "only aliases", entries: []string{"lts/*", "node"}, policy: matrixLowest, want: "lts/*"},
+		{name: "unknown policy is highest", entries: []string{"18", "20"}, policy: "newest", want: "20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entries []*yaml.Node
+			for _, value := range tt.entries {
+				entries = append(entries, &yaml.Node{Kind: yaml.ScalarNode, Value: value})
+			}
+
+			got := pickMatrixEntry(entries, tt.policy)
+			if got == nil || got.Value != tt.want {
+				t.Errorf("pickMatrixEntry(%v, %q) = %v, want %q", tt.entries, tt.policy, got, tt.want)
+			}
+		})
+	}
+
+	if got := pickMatrixEntry(nil, matrixHighest); got != nil {
+		t.Errorf("pickMatrixEntry(nil) = %v, want nil", got)
+	}
+}
+
+func TestResolveCIVersion(t *testing.T) {
+	client := newMockReleasesClient()
+
+	tests := []struct {
+		spec      string
+		wantVer   string
+		wantRange string
+	}{
+		{spec: "20", wantVer: "20"},
+		{spec: "20.x", wantVer: "20"},
+		{spec: "18.17.x", wantVer: "18.17"},
+		{spec: "v18.17.0", wantVer: "18.17.0"},
+		{spec: "^18.0.0", wantVer: "18.20.5", wantRange: "^18.0.0"},
+		{spec: ">=20 <22", wantVer: "21.7.3", wantRange: ">=20 <22"},
+		{spec: "lts/*", wantVer: "lts/*"},
+		{spec: " node ", wantVer: "node"},
+		{spec: "${{ inputs.node }}", wantVer: ""},
+		{spec: "$(touch /tmp/pwned)", wantVer: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			gotVer, gotRange := resolveCIVersion(client, tt.spec)
+			if gotVer != tt.wantVer || gotRange != tt.wantRange {
+				t.Errorf("resolveCIVersion(%q) = (%q, %q), want (%q, %q)", tt.spec, gotVer, gotRange, tt.wantVer, tt.wantRange)
+			}
+		})
+	}
+}
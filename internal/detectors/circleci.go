@@ -0,0 +1,230 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"gopkg.in/yaml.v3"
+)
+
+// CircleCIDetector detects Node.js version from .circleci/config.yml: the primary docker
+// image of a job (node, cimg/node) or the node-version of the node orb's install step
+// Single Responsibility Principle: Only responsible for detecting version from CircleCI config
+// Open/Closed Principle: Implements VersionDetector interface
+// Liskov Substitution Principle: Can be used anywhere a VersionDetector is expected
+// Dependency Inversion Principle: Depends on releasesClient interface abstraction
+type CircleCIDetector struct {
+	releasesClient releasesClient
+}
+
+var (
+	// circleParameter matches a parameter reference: << parameters.node >> or << pipeline.parameters.node >>
+	circleParameter = regexp.MustCompile(`<<\s*(pipeline\.)?parameters\.([A-Za-z0-9_-]+)\s*>>`)
+	// circleNodeImages are the CircleCI convenience images for Node.js
+	circleNodeImages = map[string]bool{"cimg/node": true, "circleci/node": true}
+)
+
+// circleParameterValue is a parameter value with the line it is written on
+type circleParameterValue struct {
+	value string
+	line  int
+}
+
+// NewCircleCIDetector creates a new CircleCIDetector instance
+func NewCircleCIDetector(releasesClient *core.NodeReleasesClient) *CircleCIDetector {
+	return &CircleCIDetector{
+		releasesClient: releasesClient,
+	}
+}
+
+// Detect reads .circleci/config.yml and returns the version of the first job (in file
+// order) that runs on a node image or installs Node.js with the node orb.
+// Parameters are taken from the workflow's matrix (see ciMatrix) or their defaults.
+func (d *CircleCIDetector) Detect(projectPath string) (core.DetectionResult, error) {
+	configPath := filepath.Join(projectPath, ".circleci", "config.yml")
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return core.DetectionResult{Found: false}, nil
+		}
+		return core.DetectionResult{Found: false}, err
+	}
+
+	root, err := parseYamlDocument(data)
+	if err != nil || root == nil {
+		return core.DetectionResult{Found: false}, err
+	}
+
+	settings := readProjectSettings(projectPath)
+
+	jobs := yamlPath(root, "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return core.DetectionResult{Found: false}, nil
+	}
+
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		name, job := jobs.Content[i].Value, jobs.Content[i+1]
+		parameters := circleParameters(root, name, job, settings.CIMatrix)
+
+		if version, line := d.jobVersion(root, job, parameters); version != "" {
+			return core.DetectionResult{
+				Found:   true,
+				Version: version,
+				Source:  configPath,
+				Line:    line,
+			}, nil
+		}
+	}
+
+	return core.DetectionResult{Found: false}, nil
+}
+
+// jobVersion returns the version of a job and the line it is written on
+// The node orb's install step wins over the image, since it installs Node.js on top of it
+func (d *CircleCIDetector) jobVersion(root, job *yaml.Node, parameters map[string]circleParameterValue) (string, int) {
+	if steps := yamlPath(job, "steps"); steps != nil && steps.Kind == yaml.SequenceNode {
+		for _, step := range steps.Content {
+			spec := yamlPath(step, "node/install", "node-version")
+			if spec == nil || spec.Kind != yaml.ScalarNode {
+				continue
+			}
+			value, line := substituteCircleParameters(spec, parameters)
+			if version, _ := resolveCIVersion(d.releasesClient, value); version != "" {
+				return version, line
+			}
+		}
+	}
+
+	docker := yamlPath(job, "docker")
+	if docker == nil {
+		// Jobs can run on a named executor
+		executor := yamlPath(job, "executor")
+		if executor != nil && executor.Kind == yaml.MappingNode {
+			executor = yamlPath(executor, "name")
+		}
+		if executor != nil && executor.Kind == yaml.ScalarNode {
+			docker = yamlPath(root, "executors", executor.Value, "docker")
+		}
+	}
+	if docker == nil || docker.Kind != yaml.SequenceNode || len(docker.Content) == 0 {
+		return "", 0
+	}
+
+	// The first image is the primary container the steps run in
+	image := yamlPath(docker.Content[0], "image")
+	if image == nil || image.Kind != yaml.ScalarNode {
+		return "", 0
+	}
+
+	value, line := substituteCircleParameters(image, parameters)
+	tag, ok := circleNodeImageTag(value)
+	if !ok {
+		return "", 0
+	}
+	return resolveNodeImageTag(d.releasesClient, tag), line
+}
+
+// circleParameters returns the parameter values of a job: the entry of a workflow
+// matrix that runs the job, or the parameter's default. Pipeline parameters are
+// stored with a "pipeline." prefix.
+func circleParameters(root *yaml.Node, jobName string, job *yaml.Node, policy string) map[string]circleParameterValue {
+	parameters := make(map[string]circleParameterValue)
+
+	addDefaults := func(block *yaml.Node, prefix string) {
+		if block == nil || block.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(block.Content); i += 2 {
+			if value := yamlPath(block.Content[i+1], "default"); value != nil && value.Kind == yaml.ScalarNode {
+				parameters[prefix+block.Content[i].Value] = circleParameterValue{value: value.Value, line: value.Line}
+			}
+		}
+	}
+	addDefaults(yamlPath(root, "parameters"), "pipeline.")
+	addDefaults(yamlPath(job, "parameters"), "")
+
+	// workflows: <name>: jobs: - <job>: { matrix: { parameters: { node: [...] } } }
+	workflows := yamlPath(root, "workflows")
+	if workflows == nil || workflows.Kind != yaml.MappingNode {
+		return parameters
+	}
+	for i := 1; i < len(workflows.Content); i += 2 {
+		jobs := yamlPath(workflows.Content[i], "jobs")
+		if jobs == nil || jobs.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, entry := range jobs.Content {
+			matrix := yamlPath(entry, jobName, "matrix", "parameters")
+			if matrix == nil || matrix.Kind != yaml.MappingNode {
+				continue
+			}
+			for j := 0; j+1 < len(matrix.Content); j += 2 {
+				if value := pickMatrixEntry(yamlScalars(matrix.Content[j+1]), policy); value != nil {
+					parameters[matrix.Content[j].Value] = circleParameterValue{value: value.Value, line: value.Line}
+				}
+			}
+			return parameters
+		}
+	}
+
+	return parameters
+}
+
+// substituteCircleParameters expands << parameters.x >> references in a value and returns
+// the line of the first parameter used, or the value's own line when there is none
+func substituteCircleParameters(node *yaml.Node, parameters map[string]circleParameterValue) (string, int) {
+	line := node.Line
+	substituted := false
+
+	value := circleParameter.ReplaceAllStringFunc(node.Value, func(match string) string {
+		parts := circleParameter.FindStringSubmatch(match)
+		parameter, ok := parameters[parts[1]+parts[2]]
+		if ok && !substituted {
+			line = parameter.line
+			substituted = true
+		}
+		return parameter.value
+	})
+
+	return strings.TrimSpace(value), line
+}
+
+// circleNodeImageTag returns the tag of a node image: the official image or a CircleCI
+// convenience image such as "cimg/node:20.11-browsers"
+func circleNodeImageTag(image string) (string, bool) {
+	if tag, ok := nodeImageTag(image); ok {
+		return tag, true
+	}
+
+	reference, _, _ := strings.Cut(strings.ToLower(image), "@")
+	idx := strings.LastIndex(reference, ":")
+	if idx < 0 || !circleNodeImages[reference[:idx]] {
+		return "", false
+	}
+	return reference[idx+1:], true
+}
+
+// FixVersion replaces the version on the line it was read from: the image tag, the
+// node-version of the install step, or the parameter default or matrix entry used by them
+func (d *CircleCIDetector) FixVersion(result core.DetectionResult, version string) error {
+	return rewriteVersionOnLine(result.Source, result.Line, ciVersionPattern, version)
+}
+
+// GetPriority returns the priority of this detector (11 = lowest priority, after .gitlab-ci.yml)
+func (d *CircleCIDetector) GetPriority() int {
+	return 11
+}
+
+// GetSourceName returns the name of the version source
+func (d *CircleCIDetector) GetSourceName() string {
+	return ".circleci/config.yml"
+}
+
+// SourceFiles returns the files Detect reads in projectPath: the config and .autonode.yml, which tunes matrix handling
+func (d *CircleCIDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, ".circleci/config.yml", projectSettingsFile)
+}
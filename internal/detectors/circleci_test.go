@@ -0,0 +1,185 @@
+package detectors
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCircleCIDetector_Detect(t *testing.T) {
+	detector := &CircleCIDetector{releasesClient: newMockReleasesClient()}
+
+	const matrixConfig = `version: 2.1
+jobs:
+  test:
+    parameters:
+      node:
+        type: string
+        default: "16"
+    docker:
+      - image: cimg/node:<< parameters.node >>
+    steps:
+      - checkout
+workflows:
+  ci:
+    jobs:
+      - test:
+          matrix:
+            parameters:
+              node: ["18.20", "22.12", "20.18"]
+`
+
+	tests := []struct {
+		name        string
+		config      string
+		settings    string
+		wantFound   bool
+		wantVersion string
+		wantLine    int
+	}{
+		{
+			name: "convenience image",
+			config: `version: 2.1
+jobs:
+  build:
+    docker:
+      - image: cimg/node:20.11.0-browsers
+      - image: cimg/postgres:16.1
+    steps:
+      - checkout
+`,
+			wantFound:   true,
+			wantVersion: "20.11.0",
+			wantLine:    5,
+		},
+		{
+			name:        "official image",
+			config:      "jobs:\n  build:\n    docker:\n      - image: node:hydrogen-alpine\n",
+			wantFound:   true,
+			wantVersion: "18",
+			wantLine:    4,
+		},
+		{
+			name:      "secondary node image is ignored",
+			config:    "jobs:\n  build:\n    docker:\n      - image: cimg/base:stable\n      - image: node:20\n",
+			wantFound: false,
+		},
+		{
+			name: "executor",
+			config: `executors:
+  node:
+    docker:
+      - image: circleci/node:14.21
+jobs:
+  build:
+    executor: node
+`,
+			wantFound:   true,
+			wantVersion: "14.21",
+			wantLine:    4,
+		},
+		{
+			name: "node orb install step",
+			config: `orbs:
+  node: circleci/node@5
+jobs:
+  build:
+    machine:
+      image: ubuntu-2204:current
+    steps:
+      - node/install:
+          node-version: "18.x"
+`,
+			wantFound:   true,
+			wantVersion: "18",
+			wantLine:    9,
+		},
+		{
+			name: "job parameter default",
+			config: `jobs:
+  build:
+    parameters:
+      node:
+        type: string
+        default: "18.17.0"
+    docker:
+      - image: cimg/node:<< parameters.node >>
+`,
+			wantFound:   true,
+			wantVersion: "18.17.0",
+			wantLine:    6,
+		},
+		{
+			name: "pipeline parameter",
+			config: `parameters:
+  node-version:
+    type: string
+    default: "22.12.0"
+jobs:
+  build:
+    docker:
+      - image: cimg/node:<< pipeline.parameters.node-version >>
+`,
+			wantFound:   true,
+			wantVersion: "22.12.0",
+			wantLine:    4,
+		},
+		{
+			name:        "matrix picks highest",
+			config:      matrixConfig,
+			wantFound:   true,
+			wantVersion: "22.12",
+			wantLine:    18,
+		},
+		{
+			name:        "matrix policy lowest",
+			config:      matrixConfig,
+			settings:    "ciMatrix: lowest\n",
+			wantFound:   true,
+			wantVersion: "18.20",
+			wantLine:    18,
+		},
+		{
+			name:      "no node image",
+			config:    "jobs:\n  build:\n    docker:\n      - image: cimg/python:3.12\n",
+			wantFound: false,
+		},
+		{
+			name:      "empty file",
+			config:    "",
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			files := map[string]string{".circleci/config.yml": tt.config}
+			if tt.settings != "" {
+				files[".autonode.yml"] = tt.settings
+			}
+			writeProjectFiles(t, tmpDir, files)
+
+			result, err := detector.Detect(tmpDir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Found != tt.wantFound {
+				t.Fatalf("Found = %v, want %v (%+v)", result.Found, tt.wantFound, result)
+			}
+			if !tt.wantFound {
+				return
+			}
+			configPath := filepath.Join(tmpDir, ".circleci", "config.yml")
+			if result.Version != tt.wantVersion || result.Line != tt.wantLine || result.Source != configPath {
+				t.Errorf("Detect() = %+v, want %s at %s:%d", result, tt.wantVersion, configPath, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestCircleCIDetector_GetPriority(t *testing.T) {
+	detector := &CircleCIDetector{releasesClient: newMockReleasesClient()}
+	if priority := detector.GetPriority(); priority != 11 {
+		t.Errorf("GetPriority() = %d, want 11 (after .gitlab-ci.yml)", priority)
+	}
+}
@@ -0,0 +1,252 @@
+package detectors
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// DevcontainerDetector detects Node.js version from a dev container configuration:
+// the version of the node feature, or else the tag of the container image
+// Single Responsibility Principle: Only responsible for detecting version from devcontainer.json
+// Open/Closed Principle: Implements VersionDetector interface
+// Liskov Substitution Principle: Can be used anywhere a VersionDetector is expected
+// Dependency Inversion Principle: Depends on releasesClient interface abstraction
+type DevcontainerDetector struct {
+	releasesClient releasesClient
+}
+
+// devcontainerConfig represents the structure of devcontainer.json we care about
+type devcontainerConfig struct {
+	Image    string                     `json:"image"`
+	Features map[string]json.RawMessage `json:"features"`
+}
+
+// devcontainerFeatureOptions are the options of the node feature
+type devcontainerFeatureOptions struct {
+	Version string `json:"version"`
+}
+
+const (
+	// devcontainerNodeFeature is the node feature, without its ":<major>" version
+	devcontainerNodeFeature = "ghcr.io/devcontainers/features/node"
+	// devcontainerFeatureDefault is the version the node feature installs when none is set
+	devcontainerFeatureDefault = "lts"
+)
+
+var (
+	// devcontainerFiles lists the dev container configuration locations, in lookup order
+	devcontainerFiles = []string{filepath.Join(".devcontainer", "devcontainer.json"), ".devcontainer.json"}
+	// devcontainerNodeImages are the dev container images that ship Node.js, tagged
+	// "<node>", "<node>-<os>" or "<image version>-<node>-<os>" (e.g. "1-20-bookworm")
+	devcontainerNodeImages = regexp.MustCompile(`(?:^|/)(?:vscode/)?devcontainers/(?:javascript|typescript)-node$`)
+	// devcontainerVersionPattern matches the node feature version or an official node image tag on their line
+	devcontainerVersionPattern = regexp.MustCompile(`^(.*?(?:"version"\s*:\s*"|"image"\s*:\s*"(?:[^"]*/)?node:))([A-Za-z0-9][A-Za-z0-9.]*)`)
+	// devcontainerImageKey finds the image property, to report its line
+	devcontainerImageKey = regexp.MustCompile(`"image"\s*:`)
+	// numericTagPart matches a version-number part of a dash-separated tag
+	numericTagPart = regexp.MustCompile(`^\d+(?:\.\d+)*$`)
+)
+
+// NewDevcontainerDetector creates a new DevcontainerDetector instance
+func NewDevcontainerDetector(releasesClient *core.NodeReleasesClient) *DevcontainerDetector {
+	return &DevcontainerDetector{
+		releasesClient: releasesClient,
+	}
+}
+
+// Detect reads .devcontainer/devcontainer.json (or .devcontainer.json) and extracts the
+// Node.js version. The node feature wins over the image, since it installs Node.js on top of it.
+// Comments and trailing commas (JSONC) are allowed, as in VS Code.
+func (d *DevcontainerDetector) Detect(projectPath string) (core.DetectionResult, error) {
+	for _, fileName := range devcontainerFiles {
+		configPath := filepath.Join(projectPath, fileName)
+
+		content, err := os.ReadFile(configPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return core.DetectionResult{Found: false}, err
+		}
+
+		var config devcontainerConfig
+		if err := json.Unmarshal(stripJSONComments(content), &config); err != nil {
+			return core.DetectionResult{Found: false}, err
+		}
+
+		if key, version := d.featureVersion(config.Features); version != "" {
+			return core.DetectionResult{
+				Found:   true,
+				Version: version,
+				Source:  configPath,
+				Line:    featureVersionLine(content, key),
+			}, nil
+		}
+
+		if version := d.imageVersion(config.Image); version != "" {
+			line := 0
+			if loc := devcontainerImageKey.FindIndex(content); loc != nil {
+				line = lineAt(content, loc[1])
+			}
+			return core.DetectionResult{
+				Found:   true,
+				Version: version,
+				Source:  configPath,
+				Line:    line,
+			}, nil
+		}
+
+		return core.DetectionResult{Found: false}, nil
+	}
+
+	return core.DetectionResult{Found: false}, nil
+}
+
+// featureVersion returns the node feature's key and resolved version
+// The version is empty when the feature is not used, is disabled ("none")
+// or its version can't be resolved
+func (d *DevcontainerDetector) featureVersion(features map[string]json.RawMessage) (string, string) {
+	for key, raw := range features {
+		id, _, _ := strings.Cut(strings.ToLower(key), "@")
+		if idx := strings.LastIndex(id, ":"); idx > strings.LastIndex(id, "/") {
+			id = id[:idx]
+		}
+		if id != devcontainerNodeFeature {
+			continue
+		}
+
+		// Options are an object; a plain string is the legacy shorthand for the version
+		var options devcontainerFeatureOptions
+		if err := json.Unmarshal(raw, &options); err != nil {
+			json.Unmarshal(raw, &options.Version)
+		}
+
+		version := strings.TrimSpace(options.Version)
+		if version == "" {
+			version = devcontainerFeatureDefault
+		}
+		if version == "none" {
+			return key, ""
+		}
+		return key, resolveTag(d.releasesClient, version)
+	}
+
+	return "", ""
+}
+
+// imageVersion resolves the Node.js version of an official node image or of a
+// javascript-node / typescript-node dev container image
+func (d *DevcontainerDetector) imageVersion(image string) string {
+	if tag, ok := nodeImageTag(image); ok {
+		return resolveNodeImageTag(d.releasesClient, tag)
+	}
+
+	reference, _, _ := strings.Cut(strings.ToLower(image), "@")
+	repository, tag := reference, "latest"
+	if idx := strings.LastIndex(reference, ":"); idx > strings.LastIndex(reference, "/") {
+		repository, tag = reference[:idx], reference[idx+1:]
+	}
+	if !devcontainerNodeImages.MatchString(repository) {
+		return ""
+	}
+
+	// "1-20-bookworm": the first part is the image version, the second the Node.js version
+	parts := strings.Split(tag, "-")
+	if len(parts) >= 2 && numericTagPart.MatchString(parts[1]) {
+		return resolveTag(d.releasesClient, parts[1])
+	}
+	return resolveTag(d.releasesClient, parts[0])
+}
+
+// featureVersionLine returns the line of the node feature's version option,
+// or of the feature itself when it has no version option
+func featureVersionLine(content []byte, key string) int {
+	quoted := regexp.QuoteMeta(`"` + key + `"`)
+	if loc := regexp.MustCompile(quoted + `\s*:\s*\{[^}]*?"version"\s*:`).FindIndex(content); loc != nil {
+		return lineAt(content, loc[1])
+	}
+	if loc := regexp.MustCompile(quoted).FindIndex(content); loc != nil {
+		return lineAt(content, loc[0])
+	}
+	return 0
+}
+
+// stripJSONComments removes // and /* */ comments and trailing commas so JSONC
+// can be parsed as JSON. Newlines are kept so line numbers don't change.
+func stripJSONComments(content []byte) []byte {
+	out := make([]byte, 0, len(content))
+	inString := false
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+
+		switch {
+		case inString:
+			out = append(out, c)
+			if c == '\\' && i+1 < len(content) {
+				i++
+				out = append(out, content[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			if i < len(content) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			i += 2
+			for i < len(content) && !(content[i] == '*' && i+1 < len(content) && content[i+1] == '/') {
+				if content[i] == '\n' {
+					out = append(out, '\n')
+				}
+				i++
+			}
+			i++ // Skip the closing '/'
+		case c == '}' || c == ']':
+			// Drop a trailing comma before the closing bracket
+			j := len(out) - 1
+			for j >= 0 && (out[j] == ' ' || out[j] == '\t' || out[j] == '\n' || out[j] == '\r') {
+				j--
+			}
+			if j >= 0 && out[j] == ',' {
+				out = append(out[:j], out[j+1:]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+// FixVersion replaces the node feature version, or the tag of an official node image, with version
+func (d *DevcontainerDetector) FixVersion(result core.DetectionResult, version string) error {
+	return rewriteVersionOnLine(result.Source, result.Line, devcontainerVersionPattern, version)
+}
+
+// GetPriority returns the priority of this detector (8 = after docker-compose)
+func (d *DevcontainerDetector) GetPriority() int {
+	return 8
+}
+
+// GetSourceName returns the name of the version source
+func (d *DevcontainerDetector) GetSourceName() string {
+	return "devcontainer.json"
+}
+
+// SourceFiles returns the files Detect reads in projectPath
+func (d *DevcontainerDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, devcontainerFiles...)
+}
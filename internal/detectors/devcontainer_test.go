@@ -0,0 +1,152 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDevcontainerDetector_Detect(t *testing.T) {
+	mockClient := newMockReleasesClient()
+	detector := &DevcontainerDetector{releasesClient: mockClient}
+
+	tests := []struct {
+		name        string
+		fileContent string
+		wantFound   bool
+		wantVersion string
+		wantLine    int
+	}{
+		{
+			name:        "official node image",
+			fileContent: `{"name": "app", "image": "node:20-bookworm"}`,
+			wantFound:   true,
+			wantVersion: "20",
+			wantLine:    1,
+		},
+		{
+			name: "javascript-node image with image version",
+			fileContent: `{
+  // Dev container for the web app
+  "name": "web",
+  "image": "mcr.microsoft.com/devcontainers/javascript-node:1-18-bookworm",
+}`,
+			wantFound:   true,
+			wantVersion: "18",
+			wantLine:    4,
+		},
+		{
+			name:        "typescript-node image",
+			fileContent: `{"image": "mcr.microsoft.com/devcontainers/typescript-node:22"}`,
+			wantFound:   true,
+			wantVersion: "22",
+			wantLine:    1,
+		},
+		{
+			name: "node feature wins over image",
+			fileContent: `{
+  "image": "mcr.microsoft.com/devcontainers/base:ubuntu",
+  "features": {
+    /* Node.js for the frontend */
+    "ghcr.io/devcontainers/features/node:1": {
+      "version": "20.11.0"
+    }
+  }
+}`,
+			wantFound:   true,
+			wantVersion: "20.11.0",
+			wantLine:    6,
+		},
+		{
+			name: "node feature defaults to lts",
+			fileContent: `{
+  "features": {
+    "ghcr.io/devcontainers/features/node:1": {}
+  }
+}`,
+			wantFound:   true,
+			wantVersion: "24",
+			wantLine:    3,
+		},
+		{
+			name: "node feature disabled falls back to image",
+			fileContent: `{
+  "image": "node:18",
+  "features": {"ghcr.io/devcontainers/features/node:1": {"version": "none"}}
+}`,
+			wantFound:   true,
+			wantVersion: "18",
+			wantLine:    2,
+		},
+		{
+			name:        "other image",
+			fileContent: `{"image": "mcr.microsoft.com/devcontainers/go:1.23"}`,
+			wantFound:   false,
+		},
+		{
+			name:        "comment markers inside strings",
+			fileContent: `{"name": "http://example.com/*", "image": "node:iron"}`,
+			wantFound:   true,
+			wantVersion: "20",
+			wantLine:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			dir := filepath.Join(tmpDir, ".devcontainer")
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				t.Fatalf("failed to create %s: %v", dir, err)
+			}
+			filePath := filepath.Join(dir, "devcontainer.json")
+			if err := os.WriteFile(filePath, []byte(tt.fileContent), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			result, err := detector.Detect(tmpDir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Found != tt.wantFound {
+				t.Fatalf("Found = %v, want %v", result.Found, tt.wantFound)
+			}
+			if !tt.wantFound {
+				return
+			}
+			if result.Version != tt.wantVersion || result.Line != tt.wantLine || result.Source != filePath {
+				t.Errorf("Detect() = %+v, want %s at %s:%d", result, tt.wantVersion, filePath, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestDevcontainerDetector_RootFile(t *testing.T) {
+	detector := &DevcontainerDetector{releasesClient: newMockReleasesClient()}
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, ".devcontainer.json")
+	if err := os.WriteFile(filePath, []byte(`{"image": "node:20"}`), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := detector.Detect(tmpDir)
+	if err != nil || !result.Found || result.Source != filePath {
+		t.Errorf("Detect() = %+v, %v", result, err)
+	}
+}
+
+func TestStripJSONComments(t *testing.T) {
+	input := "{\n  // comment\n  \"a\": \"x // y\", /* block\n comment */ \"b\": [1, 2,],\n}"
+	want := "{\n  \n  \"a\": \"x // y\", \n \"b\": [1, 2]\n}"
+
+	if got := string(stripJSONComments([]byte(input))); got != want {
+		t.Errorf("stripJSONComments() = %q, want %q", got, want)
+	}
+}
+
+func TestDevcontainerDetector_GetPriority(t *testing.T) {
+	detector := &DevcontainerDetector{releasesClient: newMockReleasesClient()}
+	if priority := detector.GetPriority(); priority != 8 {
+		t.Errorf("GetPriority() = %d, want 8 (after docker-compose)", priority)
+	}
+}
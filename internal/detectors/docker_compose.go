@@ -0,0 +1,114 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"gopkg.in/yaml.v3"
+)
+
+// DockerComposeDetector detects Node.js version from the node image of a docker-compose service
+// Single Responsibility Principle: Only responsible for detecting version from compose files
+// Open/Closed Principle: Implements VersionDetector interface
+// Liskov Substitution Principle: Can be used anywhere a VersionDetector is expected
+// Dependency Inversion Principle: Depends on releasesClient interface abstraction
+type DockerComposeDetector struct {
+	releasesClient releasesClient
+}
+
+// composeFiles lists the compose file names in the order docker compose looks for them
+var composeFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// composeImagePattern matches the version part of a node image tag on an "image:" line
+var composeImagePattern = regexp.MustCompile(`(?i)^(\s*image\s*:\s*["']?\S*node:)([a-z0-9][a-z0-9.]*)`)
+
+// NewDockerComposeDetector creates a new DockerComposeDetector instance
+func NewDockerComposeDetector(releasesClient *core.NodeReleasesClient) *DockerComposeDetector {
+	return &DockerComposeDetector{
+		releasesClient: releasesClient,
+	}
+}
+
+// Detect reads the compose file and returns the version of the first service (in file
+// order) whose image is the official node image, e.g. "image: node:20-alpine".
+// "${VAR:-default}" interpolation uses the default, since the environment of
+// `docker compose up` is not known.
+func (d *DockerComposeDetector) Detect(projectPath string) (core.DetectionResult, error) {
+	for _, fileName := range composeFiles {
+		composePath := filepath.Join(projectPath, fileName)
+
+		data, err := os.ReadFile(composePath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return core.DetectionResult{Found: false}, err
+		}
+
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return core.DetectionResult{Found: false}, err
+		}
+		if len(doc.Content) == 0 {
+			return core.DetectionResult{Found: false}, nil
+		}
+
+		services := yamlMappingValue(doc.Content[0], "services")
+		if services == nil || services.Kind != yaml.MappingNode {
+			return core.DetectionResult{Found: false}, nil
+		}
+
+		for i := 1; i < len(services.Content); i += 2 {
+			image := yamlMappingValue(services.Content[i], "image")
+			if image == nil || image.Kind != yaml.ScalarNode {
+				continue
+			}
+
+			// Variables without a default come from the environment or .env: the tag can't be known
+			expanded, _, resolved := expandDockerArgs(image.Value, nil)
+			if !resolved {
+				continue
+			}
+			tag, ok := nodeImageTag(expanded)
+			if !ok {
+				continue
+			}
+
+			if version := resolveNodeImageTag(d.releasesClient, tag); version != "" {
+				return core.DetectionResult{
+					Found:   true,
+					Version: version,
+					Source:  composePath,
+					Line:    image.Line,
+				}, nil
+			}
+		}
+
+		// docker compose only reads the first compose file it finds
+		return core.DetectionResult{Found: false}, nil
+	}
+
+	return core.DetectionResult{Found: false}, nil
+}
+
+// FixVersion replaces the version in the service's node image tag with version
+func (d *DockerComposeDetector) FixVersion(result core.DetectionResult, version string) error {
+	return rewriteVersionOnLine(result.Source, result.Line, composeImagePattern, version)
+}
+
+// GetPriority returns the priority of this detector (7 = after Dockerfile)
+func (d *DockerComposeDetector) GetPriority() int {
+	return 7
+}
+
+// GetSourceName returns the name of the version source
+func (d *DockerComposeDetector) GetSourceName() string {
+	return "docker-compose"
+}
+
+// SourceFiles returns the files Detect reads in projectPath
+func (d *DockerComposeDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, composeFiles...)
+}
@@ -0,0 +1,168 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDockerComposeDetector_Detect(t *testing.T) {
+	mockClient := newMockReleasesClient()
+	detector := &DockerComposeDetector{releasesClient: mockClient}
+
+	tests := []struct {
+		name        string
+		fileName    string
+		fileContent string
+		wantFound   bool
+		wantVersion string
+		wantLine    int
+	}{
+		{
+			name:     "node service",
+			fileName: "docker-compose.yml",
+			fileContent: `services:
+  web:
+    image: node:20-alpine
+`,
+			wantFound:   true,
+			wantVersion: "20",
+			wantLine:    3,
+		},
+		{
+			name:     "first node service in file order",
+			fileName: "compose.yaml",
+			fileContent: `services:
+  db:
+    image: postgres:16
+  worker:
+    image: "docker.io/library/node:18.20.5"
+  web:
+    image: node:22
+`,
+			wantFound:   true,
+			wantVersion: "18.20.5",
+			wantLine:    5,
+		},
+		{
+			name:     "codename tag",
+			fileName: "compose.yml",
+			fileContent: `services:
+  app:
+    image: node:iron-slim
+`,
+			wantFound:   true,
+			wantVersion: "20",
+			wantLine:    3,
+		},
+		{
+			name:     "interpolation default",
+			fileName: "docker-compose.yaml",
+			fileContent: `services:
+  app:
+    image: node:${NODE_VERSION:-18}
+`,
+			wantFound:   true,
+			wantVersion: "18",
+			wantLine:    3,
+		},
+		{
+			name:     "variable without a default",
+			fileName: "docker-compose.yml",
+			fileContent: `services:
+  app:
+    image: node:${NODE_VERSION}
+  worker:
+    image: node:20-alpine
+`,
+			wantFound:   true,
+			wantVersion: "20",
+			wantLine:    5,
+		},
+		{
+			name:     "build only",
+			fileName: "docker-compose.yml",
+			fileContent: `services:
+  app:
+    build: .
+`,
+			wantFound: false,
+		},
+		{
+			name:        "no services",
+			fileName:    "docker-compose.yml",
+			fileContent: "version: '3'\n",
+			wantFound:   false,
+		},
+		{
+			name:        "empty file",
+			fileName:    "docker-compose.yml",
+			fileContent: "",
+			wantFound:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			filePath := filepath.Join(tmpDir, tt.fileName)
+			if err := os.WriteFile(filePath, []byte(tt.fileContent), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			result, err := detector.Detect(tmpDir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Found != tt.wantFound {
+				t.Fatalf("Found = %v, want %v", result.Found, tt.wantFound)
+			}
+			if !tt.wantFound {
+				return
+			}
+			if result.Version != tt.wantVersion || result.Line != tt.wantLine || result.Source != filePath {
+				t.Errorf("Detect() = %+v, want %s at %s:%d", result, tt.wantVersion, filePath, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestDockerComposeDetector_FirstFileOnly(t *testing.T) {
+	detector := &DockerComposeDetector{releasesClient: newMockReleasesClient()}
+	tmpDir := t.TempDir()
+
+	// compose.yaml takes precedence, even when it declares no node image
+	files := map[string]string{
+		"compose.yaml":       "services:\n  db:\n    image: postgres\n",
+		"docker-compose.yml": "services:\n  web:\n    image: node:20\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	result, err := detector.Detect(tmpDir)
+	if err != nil || result.Found {
+		t.Errorf("Detect() = %+v, %v; want not found", result, err)
+	}
+}
+
+func TestDockerComposeDetector_InvalidYaml(t *testing.T) {
+	detector := &DockerComposeDetector{releasesClient: newMockReleasesClient()}
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "docker-compose.yml"), []byte("services: [\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	if _, err := detector.Detect(tmpDir); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
+
+func TestDockerComposeDetector_GetPriority(t *testing.T) {
+	detector := &DockerComposeDetector{releasesClient: newMockReleasesClient()}
+	if priority := detector.GetPriority(); priority != 7 {
+		t.Errorf("GetPriority() = %d, want 7 (after Dockerfile)", priority)
+	}
+}
@@ -1,22 +1,17 @@
 package detectors
 
 import (
-	"bufio"
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/matutetandil/autonode/internal/core"
 )
 
-// releasesClient interface for dependency injection
-// This allows us to mock the client in tests
-type releasesClient interface {
-	GetVersionForCodename(codename string) (string, error)
-}
-
-// DockerfileDetector detects Node.js version from Dockerfile FROM node:X instruction
+// DockerfileDetector detects Node.js version from the node base image of a Dockerfile
 // Single Responsibility Principle: Only responsible for detecting version from Dockerfile
 // Open/Closed Principle: Implements VersionDetector interface
 // Liskov Substitution Principle: Can be used anywhere a VersionDetector is expected
@@ -25,6 +20,15 @@ type DockerfileDetector struct {
 	releasesClient releasesClient
 }
 
+var (
+	// dockerNodeTagVersion captures the version part of a node image tag, before any
+	// variant (e.g. "20.11.0" in "20.11.0-alpine", "iron" in "iron-bookworm-slim")
+	dockerNodeTagVersion = regexp.MustCompile(`^([a-z0-9][a-z0-9.]*)(?:-[a-z0-9.-]+)?$`)
+	// dockerfileNodeTagPattern matches the version part of a node image tag on a FROM line,
+	// or of an ARG default substituted into one
+	dockerfileNodeTagPattern = regexp.MustCompile(`(?i)^(\s*(?:FROM\s+(?:--\S+\s+)*\S*node:|ARG\s+[a-z_][a-z0-9_]*=["']?(?:\S*node:)?))([a-z0-9][a-z0-9.]*)`)
+)
+
 // NewDockerfileDetector creates a new DockerfileDetector instance
 func NewDockerfileDetector(releasesClient *core.NodeReleasesClient) *DockerfileDetector {
 	return &DockerfileDetector{
@@ -32,65 +36,91 @@ func NewDockerfileDetector(releasesClient *core.NodeReleasesClient) *DockerfileD
 	}
 }
 
-// Detect reads the Dockerfile and extracts Node.js version from FROM instruction
+// Detect parses the Dockerfile and extracts the Node.js version from the node base image
+// of the selected stage: the stage named by dockerStage in .autonode.yml, or by default
+// the last stage based on node (usually the runtime stage of a multi-stage build).
+// ARG defaults are substituted, so "ARG NODE_VERSION=20" + "FROM node:${NODE_VERSION}" works.
 func (d *DockerfileDetector) Detect(projectPath string) (core.DetectionResult, error) {
 	dockerfilePath := filepath.Join(projectPath, "Dockerfile")
 
-	// Check if file exists
-	if _, err := os.Stat(dockerfilePath); os.IsNotExist(err) {
-		return core.DetectionResult{Found: false}, nil
-	}
-
 	// Open file
 	file, err := os.Open(dockerfilePath)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return core.DetectionResult{Found: false}, nil
+		}
 		return core.DetectionResult{Found: false}, err
 	}
 	defer file.Close()
 
-	// Regular expression to match:
-	// - FROM node:18.17.0
-	// - FROM node:18
-	// - FROM node:iron
-	// - FROM node:iron-alpine
-	// - FROM node:18-alpine
-	// - FROM node:lts
-	// Captures tag after "node:" (before any variant like -alpine, -slim, etc.)
-	re := regexp.MustCompile(`(?i)FROM\s+node:([a-z0-9][a-z0-9.]*)(?:-[a-z0-9]+)?`)
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-
-		// Skip comments
-		if strings.HasPrefix(line, "#") {
-			continue
-		}
+	instructions, err := parseDockerInstructions(file)
+	if err != nil {
+		return core.DetectionResult{Found: false}, err
+	}
 
-		matches := re.FindStringSubmatch(line)
-		if len(matches) > 1 {
-			tag := matches[1]
-			version := d.resolveTag(tag)
-
-			if version != "" {
-				return core.DetectionResult{
-					Found:   true,
-					Version: version,
-					Source:  "Dockerfile",
-				}, nil
+	stage, err := selectDockerStage(parseDockerStages(instructions), readProjectSettings(projectPath).DockerStage)
+	if err != nil {
+		return core.DetectionResult{Found: false}, err
+	}
+	if stage == nil {
+		return core.DetectionResult{Found: false}, nil
+	}
+
+	version := resolveNodeImageTag(d.releasesClient, stage.nodeTag)
+	if version == "" {
+		return core.DetectionResult{Found: false}, nil
+	}
+
+	return core.DetectionResult{
+		Found:   true,
+		Version: version,
+		Source:  dockerfilePath,
+		Line:    stage.tagLine,
+	}, nil
+}
+
+// selectDockerStage returns the named stage, or the last stage based on node when name is empty
+// Returns nil when no suitable stage is based on node
+func selectDockerStage(stages []dockerStage, name string) (*dockerStage, error) {
+	if name != "" {
+		for i := range stages {
+			if strings.EqualFold(stages[i].name, name) {
+				if stages[i].nodeTag == "" {
+					return nil, nil
+				}
+				return &stages[i], nil
 			}
 		}
+		return nil, fmt.Errorf("Dockerfile has no stage named '%s'", name)
 	}
 
-	if err := scanner.Err(); err != nil {
-		return core.DetectionResult{Found: false}, err
+	for i := len(stages) - 1; i >= 0; i-- {
+		if stages[i].nodeTag != "" {
+			return &stages[i], nil
+		}
 	}
+	return nil, nil
+}
+
+// FixVersion replaces the version in the node image tag with version, keeping any variant
+// (e.g. -alpine). When the tag comes from an ARG default, the default is rewritten.
+func (d *DockerfileDetector) FixVersion(result core.DetectionResult, version string) error {
+	return rewriteVersionOnLine(result.Source, result.Line, dockerfileNodeTagPattern, version)
+}
 
-	return core.DetectionResult{Found: false}, nil
+// resolveNodeImageTag converts a node image tag (e.g. "20-alpine", "iron-slim", "lts")
+// to a Node.js version. Returns empty string for tags that name no known version.
+// Shared by the detectors that read node images (Dockerfile, docker-compose, devcontainer).
+func resolveNodeImageTag(client releasesClient, tag string) string {
+	matches := dockerNodeTagVersion.FindStringSubmatch(strings.ToLower(strings.TrimSpace(tag)))
+	if matches == nil {
+		return ""
+	}
+	return resolveTag(client, matches[1])
 }
 
 // resolveTag converts Docker image tags to Node.js versions
-func (d *DockerfileDetector) resolveTag(tag string) string {
+func resolveTag(client releasesClient, tag string) string {
 	tag = strings.ToLower(strings.TrimSpace(tag))
 
 	// If it's already a numeric version, return it
@@ -98,18 +128,16 @@ func (d *DockerfileDetector) resolveTag(tag string) string {
 		return tag
 	}
 
-	// Try to resolve as LTS codename from cache/API
-	if version, err := d.releasesClient.GetVersionForCodename(tag); err == nil {
-		return version
+	// Resolve LTS codenames and the "lts", "latest" and "current" tags from the release index
+	if index, err := client.GetReleaseIndex(); err == nil {
+		if release, found := index.Resolve(tag); found {
+			return strconv.Itoa(release.Major())
+		}
 	}
 
-	// Handle special tags
+	// Without a release index, fall back to hardcoded special tags
 	switch tag {
 	case "lts":
-		// Return latest LTS version (try to get from cache, fallback to hardcoded)
-		if version, err := d.releasesClient.GetVersionForCodename("jod"); err == nil {
-			return version
-		}
 		return "22" // Fallback to current LTS
 	case "latest", "current":
 		return "23" // Latest stable as of January 2025
@@ -119,12 +147,17 @@ func (d *DockerfileDetector) resolveTag(tag string) string {
 	return ""
 }
 
-// GetPriority returns the priority of this detector (4 = fifth/lowest priority)
+// GetPriority returns the priority of this detector (6 = after package.json)
 func (d *DockerfileDetector) GetPriority() int {
-	return 4
+	return 6
 }
 
 // GetSourceName returns the name of the version source
 func (d *DockerfileDetector) GetSourceName() string {
 	return "Dockerfile"
 }
+
+// SourceFiles returns the files Detect reads in projectPath: the Dockerfile and .autonode.yml, which selects the stage
+func (d *DockerfileDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, "Dockerfile", projectSettingsFile)
+}
@@ -0,0 +1,269 @@
+package detectors
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// dockerInstruction is one Dockerfile instruction with its continuation lines joined
+type dockerInstruction struct {
+	command string // Instruction keyword, upper-cased (FROM, ARG, RUN, ...)
+	args    string // Everything after the keyword
+	line    int    // 1-based line the instruction starts on
+}
+
+// dockerStage is one build stage, started by a FROM instruction
+type dockerStage struct {
+	name string // Stage name from "AS <name>" (empty if unnamed)
+	// image is the base image with ARG defaults substituted (e.g. "node:20-alpine")
+	image string
+	// nodeTag is the tag of the node base image ("" when the stage isn't based on node)
+	nodeTag string
+	// tagLine is the line the tag is written on: the FROM instruction, or the ARG
+	// instruction whose default was substituted into the tag
+	tagLine int
+}
+
+// dockerArg is an ARG declared before the first FROM, usable in FROM instructions
+type dockerArg struct {
+	value string
+	line  int
+}
+
+var (
+	// dockerDirective matches a parser directive such as "# escape=`"
+	dockerDirective = regexp.MustCompile(`^#\s*([a-zA-Z]+)\s*=\s*(\S+)\s*$`)
+	// dockerVariable matches $VAR, ${VAR}, ${VAR:-default} and ${VAR:+alternative}
+	dockerVariable = regexp.MustCompile(`\$(?:([A-Za-z_][A-Za-z0-9_]*)|\{([A-Za-z_][A-Za-z0-9_]*)(?::([-+])([^}]*))?\})`)
+	// nodeImageRepositories are the repository paths of the official node image,
+	// once a registry host (docker.io, public.ecr.aws, a mirror...) is removed
+	nodeImageRepositories = map[string]bool{
+		"node":                true,
+		"library/node":        true,
+		"docker/library/node": true,
+	}
+)
+
+// parseDockerInstructions splits a Dockerfile into instructions.
+// Comments and blank lines are skipped, lines ending with the escape character
+// (backslash, or the one set by an "# escape=" directive) are joined with the next one.
+func parseDockerInstructions(r io.Reader) ([]dockerInstruction, error) {
+	var instructions []dockerInstruction
+
+	escape := `\`
+	directives := true // Parser directives are only allowed before anything else
+	current := ""
+	startLine := 0
+	lineNumber := 0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if directives {
+			if matches := dockerDirective.FindStringSubmatch(line); matches != nil {
+				if strings.EqualFold(matches[1], "escape") && (matches[2] == "`" || matches[2] == `\`) {
+					escape = matches[2]
+				}
+				continue
+			}
+			directives = false
+		}
+
+		// Comment lines are removed, even in the middle of a continued instruction
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if current == "" {
+			startLine = lineNumber
+		}
+
+		if strings.HasSuffix(line, escape) {
+			current += strings.TrimSuffix(line, escape) + " "
+			continue
+		}
+
+		instructions = append(instructions, newDockerInstruction(current+line, startLine))
+		current = ""
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// A continuation on the last line still ends the instruction
+	if strings.TrimSpace(current) != "" {
+		instructions = append(instructions, newDockerInstruction(current, startLine))
+	}
+
+	return instructions, nil
+}
+
+// newDockerInstruction splits an instruction into its keyword and arguments
+func newDockerInstruction(text string, line int) dockerInstruction {
+	fields := strings.SplitN(strings.TrimSpace(text), " ", 2)
+	instruction := dockerInstruction{command: strings.ToUpper(fields[0]), line: line}
+	if len(fields) > 1 {
+		instruction.args = strings.TrimSpace(fields[1])
+	}
+	return instruction
+}
+
+// parseDockerStages returns the build stages in order.
+// ARG defaults declared before the first FROM are substituted into FROM instructions,
+// and a stage built FROM an earlier stage inherits that stage's base image.
+func parseDockerStages(instructions []dockerInstruction) []dockerStage {
+	var stages []dockerStage
+	args := make(map[string]dockerArg)
+
+	for _, instruction := range instructions {
+		switch instruction.command {
+		case "ARG":
+			// Only global ARGs (before the first FROM) can be used in FROM; an ARG
+			// inside a stage only brings a global one into that stage
+			if len(stages) == 0 {
+				declareDockerArgs(args, instruction)
+			}
+		case "FROM":
+			stages = append(stages, newDockerStage(instruction, args, stages))
+		}
+	}
+
+	return stages
+}
+
+// declareDockerArgs adds the ARGs of "ARG NAME=value OTHER" to args.
+// A name without a default keeps the default it was declared with before, as in docker.
+func declareDockerArgs(args map[string]dockerArg, instruction dockerInstruction) {
+	for _, field := range strings.Fields(instruction.args) {
+		name, value, hasDefault := strings.Cut(field, "=")
+		if _, declared := args[name]; declared && !hasDefault {
+			continue
+		}
+		args[name] = dockerArg{value: strings.Trim(value, `"'`), line: instruction.line}
+	}
+}
+
+// newDockerStage builds a stage from "FROM [--platform=...] <image> [AS <name>]"
+func newDockerStage(instruction dockerInstruction, args map[string]dockerArg, previous []dockerStage) dockerStage {
+	var fields []string
+	for _, field := range strings.Fields(instruction.args) {
+		if !strings.HasPrefix(field, "--") {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return dockerStage{}
+	}
+
+	stage := dockerStage{tagLine: instruction.line}
+	if len(fields) >= 3 && strings.EqualFold(fields[1], "AS") {
+		stage.name = fields[2]
+	}
+
+	raw := fields[0]
+	image, argLine, resolved := expandDockerArgs(raw, args)
+	stage.image = image
+
+	// FROM <earlier stage>: same base image as that stage
+	for i := len(previous) - 1; i >= 0; i-- {
+		if previous[i].name != "" && strings.EqualFold(previous[i].name, image) {
+			stage.nodeTag = previous[i].nodeTag
+			stage.tagLine = previous[i].tagLine
+			return stage
+		}
+	}
+
+	// An ARG without a value must be given with --build-arg: the tag can't be known
+	if !resolved {
+		return stage
+	}
+
+	if tag, ok := nodeImageTag(image); ok {
+		stage.nodeTag = tag
+		// The version comes from an ARG default when the image is written with a variable
+		if argLine > 0 {
+			stage.tagLine = argLine
+		}
+	}
+
+	return stage
+}
+
+// expandDockerArgs substitutes ARG defaults into text and returns the line of the
+// first ARG used (0 if none). Unknown variables expand to an empty string, like in docker;
+// resolved is false when a variable without a fallback had no value.
+func expandDockerArgs(text string, args map[string]dockerArg) (expanded string, argLine int, resolved bool) {
+	resolved = true
+	expanded = dockerVariable.ReplaceAllStringFunc(text, func(match string) string {
+		parts := dockerVariable.FindStringSubmatch(match)
+		name := parts[1] + parts[2]
+		arg, defined := args[name]
+		if defined && arg.value != "" && argLine == 0 {
+			argLine = arg.line
+		}
+
+		switch parts[3] {
+		case "-":
+			if !defined || arg.value == "" {
+				return parts[4]
+			}
+		case "+":
+			if defined && arg.value != "" {
+				return parts[4]
+			}
+			return ""
+		default:
+			if !defined || arg.value == "" {
+				resolved = false
+			}
+		}
+		return arg.value
+	})
+	return expanded, argLine, resolved
+}
+
+// nodeImageTag returns the tag of an official node image reference such as
+// "node:20-alpine", "docker.io/library/node:20" or "node:20@sha256:...".
+// A reference without a tag uses "latest"; a digest-only reference or an empty tag
+// ("node:") has no usable tag.
+func nodeImageTag(image string) (string, bool) {
+	image = strings.ToLower(image)
+
+	reference, _, pinned := strings.Cut(image, "@")
+
+	repository := reference
+	tag := ""
+	tagged := false
+	if idx := strings.LastIndex(reference, ":"); idx > strings.LastIndex(reference, "/") {
+		repository = reference[:idx]
+		tag = reference[idx+1:]
+		tagged = true
+	}
+
+	parts := strings.Split(repository, "/")
+	if len(parts) > 1 && isRegistryHost(parts[0]) {
+		parts = parts[1:]
+	}
+	if !nodeImageRepositories[strings.Join(parts, "/")] {
+		return "", false
+	}
+
+	if tag == "" {
+		if pinned || tagged {
+			return "", false
+		}
+		tag = "latest"
+	}
+	return tag, true
+}
+
+// isRegistryHost reports whether the first path component of an image reference
+// is a registry (docker.io, localhost:5000, ...) rather than a namespace
+func isRegistryHost(component string) bool {
+	return strings.ContainsAny(component, ".:") || component == "localhost"
+}
@@ -0,0 +1,102 @@
+package detectors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDockerInstructions(t *testing.T) {
+	dockerfile := "# syntax=docker/dockerfile:1\n" +
+		"# escape=`\n" +
+		"\n" +
+		"FROM node:20 `\n" +
+		"  # comment inside a continuation\n" +
+		"  AS build\n" +
+		"RUN echo \\\n" +
+		"ARG VERSION"
+
+	instructions, err := parseDockerInstructions(strings.NewReader(dockerfile))
+	if err != nil {
+		t.Fatalf("parseDockerInstructions() error = %v", err)
+	}
+
+	want := []dockerInstruction{
+		{command: "FROM", args: "node:20  AS build", line: 4},
+		{command: "RUN", args: `echo \`, line: 7},
+		{command: "ARG", args: "VERSION", line: 8},
+	}
+	if len(instructions) != len(want) {
+		t.Fatalf("parseDockerInstructions() = %+v", instructions)
+	}
+	for i := range want {
+		if instructions[i] != want[i] {
+			t.Errorf("instructions[%d] = %+v, want %+v", i, instructions[i], want[i])
+		}
+	}
+}
+
+func TestNodeImageTag(t *testing.T) {
+	tests := []struct {
+		image  string
+		want   string
+		wantOK bool
+	}{
+		{"node:20-alpine", "20-alpine", true},
+		{"NODE:Iron", "iron", true},
+		{"node", "latest", true},
+		{"library/node:18", "18", true},
+		{"docker.io/library/node:20", "20", true},
+		{"docker.io/node:20", "20", true},
+		{"public.ecr.aws/docker/library/node:22-slim", "22-slim", true},
+		{"localhost:5000/node:20", "20", true},
+		{"node:20@sha256:abc", "20", true},
+		{"node@sha256:abc", "", false},
+		{"node:", "", false},
+		{"node:@sha256:abc", "", false},
+		{"bitnami/node:20", "", false},
+		{"nodejs:20", "", false},
+		{"ubuntu:24.04", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			got, ok := nodeImageTag(tt.image)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("nodeImageTag(%q) = (%q, %v), want (%q, %v)", tt.image, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestExpandDockerArgs(t *testing.T) {
+	args := map[string]dockerArg{
+		"NODE_VERSION": {value: "20", line: 2},
+		"EMPTY":        {value: "", line: 3},
+	}
+
+	tests := []struct {
+		text         string
+		want         string
+		wantLine     int
+		wantResolved bool
+	}{
+		{"node:${NODE_VERSION}-alpine", "node:20-alpine", 2, true},
+		{"node:$NODE_VERSION", "node:20", 2, true},
+		{"node:${EMPTY:-18}", "node:18", 0, true},
+		{"node:${MISSING:-18}", "node:18", 0, true},
+		{"node:20${NODE_VERSION:+-slim}", "node:20-slim", 2, true},
+		{"node:20${MISSING:+-slim}", "node:20", 0, true},
+		{"node:${MISSING}", "node:", 0, false},
+		{"node:${EMPTY}-alpine", "node:-alpine", 0, false},
+		{"node:22", "node:22", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, line, resolved := expandDockerArgs(tt.text, args)
+			if got != tt.want || line != tt.wantLine || resolved != tt.wantResolved {
+				t.Errorf("expandDockerArgs(%q) = (%q, %d, %v), want (%q, %d, %v)", tt.text, got, line, resolved, tt.want, tt.wantLine, tt.wantResolved)
+			}
+		})
+	}
+}
@@ -99,19 +99,19 @@ func TestDockerfileDetector_Detect(t *testing.T) {
 			name:        "lts tag",
 			fileContent: "FROM node:lts",
 			wantFound:   true,
-			wantVersion: "22", // Current LTS
+			wantVersion: "24", // Latest LTS in the release index
 		},
 		{
 			name:        "latest tag",
 			fileContent: "FROM node:latest",
 			wantFound:   true,
-			wantVersion: "23", // Current latest
+			wantVersion: "24", // Latest release in the release index
 		},
 		{
 			name:        "current tag",
 			fileContent: "FROM node:current",
 			wantFound:   true,
-			wantVersion: "23", // Current stable
+			wantVersion: "24", // Latest release in the release index
 		},
 
 		// Special tags with variants
@@ -119,13 +119,13 @@ func TestDockerfileDetector_Detect(t *testing.T) {
 			name:        "lts with alpine",
 			fileContent: "FROM node:lts-alpine",
 			wantFound:   true,
-			wantVersion: "22",
+			wantVersion: "24",
 		},
 		{
 			name:        "latest with slim",
 			fileContent: "FROM node:latest-slim",
 			wantFound:   true,
-			wantVersion: "23",
+			wantVersion: "24",
 		},
 
 		// Numeric versions with variants
@@ -189,7 +189,118 @@ FROM node:18.17.0
 			wantVersion: "18.17.0",
 		},
 
+		// Image references
+		{
+			name:        "platform flag",
+			fileContent: "FROM --platform=linux/amd64 node:20-alpine",
+			wantFound:   true,
+			wantVersion: "20",
+		},
+		{
+			name:        "docker hub library path",
+			fileContent: "FROM docker.io/library/node:20.11.0",
+			wantFound:   true,
+			wantVersion: "20.11.0",
+		},
+		{
+			name:        "ECR public mirror",
+			fileContent: "FROM public.ecr.aws/docker/library/node:18-slim",
+			wantFound:   true,
+			wantVersion: "18",
+		},
+		{
+			name:        "digest-pinned tag",
+			fileContent: "FROM node:20.11.0-alpine@sha256:0123456789abcdef",
+			wantFound:   true,
+			wantVersion: "20.11.0",
+		},
+		{
+			name:        "no tag means latest",
+			fileContent: "FROM node",
+			wantFound:   true,
+			wantVersion: "24",
+		},
+
+		// ARG substitution and line continuations
+		{
+			name: "ARG default in tag",
+			fileContent: `ARG NODE_VERSION=20.11.0
+FROM node:${NODE_VERSION}-alpine`,
+			wantFound:   true,
+			wantVersion: "20.11.0",
+		},
+		{
+			name: "ARG with fallback",
+			fileContent: `ARG NODE_VERSION
+FROM node:${NODE_VERSION:-18}`,
+			wantFound:   true,
+			wantVersion: "18",
+		},
+		{
+			name: "ARG for the whole image",
+			fileContent: `ARG BASE=docker.io/library/node:iron
+FROM $BASE`,
+			wantFound:   true,
+			wantVersion: "20",
+		},
+		{
+			name: "ARG redeclared without a default",
+			fileContent: `ARG NODE_VERSION=20.11.0
+ARG NODE_VERSION
+FROM node:${NODE_VERSION}
+ARG NODE_VERSION
+FROM node:${NODE_VERSION}-slim AS runtime`,
+			wantFound:   true,
+			wantVersion: "20.11.0",
+		},
+		{
+			name: "line continuation",
+			fileContent: `FROM \
+    --platform=$BUILDPLATFORM \
+    node:22 AS build`,
+			wantFound:   true,
+			wantVersion: "22",
+		},
+
+		// Multi-stage builds: the last stage based on node wins
+		{
+			name: "final stage",
+			fileContent: `FROM node:18 AS build
+RUN npm ci
+FROM node:20-slim AS runtime
+COPY --from=build /app /app`,
+			wantFound:   true,
+			wantVersion: "20",
+		},
+		{
+			name: "final stage not based on node",
+			fileContent: `FROM node:20 AS build
+RUN npm run build
+FROM nginx:1.27
+COPY --from=build /app/dist /usr/share/nginx/html`,
+			wantFound:   true,
+			wantVersion: "20",
+		},
+		{
+			name: "stage built from an earlier stage",
+			fileContent: `FROM node:18 AS base
+FROM ubuntu:24.04 AS tools
+FROM base AS runtime`,
+			wantFound:   true,
+			wantVersion: "18",
+		},
+
 		// Negative cases
+		{
+			name:        "digest only",
+			fileContent: "FROM node@sha256:0123456789abcdef",
+			wantFound:   false,
+		},
+		{
+			name:        "other image named node",
+			fileContent: "FROM bitnami/node:20",
+			wantFound:   false,
+		},
 		{
 			name:        "different base image",
 			fileContent: "FROM ubuntu:20.04",
@@ -205,6 +316,17 @@ FROM node:18.17.0
 			fileContent: "FROM node:unknowncodename",
 			wantFound:   false,
 		},
+		{
+			name: "ARG without a default",
+			fileContent: `ARG NODE_VERSION
+FROM node:${NODE_VERSION}`,
+			wantFound: false,
+		},
+		{
+			name:        "unset variable before the variant",
+			fileContent: "FROM node:${UNSET}-alpine",
+			wantFound:   false,
+		},
 		{
 			name:        "empty file",
 			fileContent: "",
@@ -235,8 +357,38 @@ FROM node:18.17.0
 				t.Errorf("Version = %v, want %v", result.Version, tt.wantVersion)
 			}
 
-			if tt.wantFound && result.Source != "Dockerfile" {
-				t.Errorf("Source = %v, want 'Dockerfile'", result.Source)
+			if tt.wantFound && result.Source != filePath {
+				t.Errorf("Source = %v, want %v", result.Source, filePath)
+			}
+		})
+	}
+}
+
+func TestResolveTag(t *testing.T) {
+	offline := newMockReleasesClient()
+	offline.offline = true
+
+	tests := []struct {
+		name   string
+		client releasesClient
+		tag    string
+		want   string
+	}{
+		{"numeric", newMockReleasesClient(), "20.11", "20.11"},
+		{"codename", newMockReleasesClient(), "Hydrogen", "18"},
+		{"lts", newMockReleasesClient(), "lts", "24"},
+		{"latest", newMockReleasesClient(), "latest", "24"},
+		{"unknown", newMockReleasesClient(), "bookworm", ""},
+		{"numeric without index", offline, "20", "20"},
+		{"lts without index", offline, "lts", "22"},
+		{"latest without index", offline, "latest", "23"},
+		{"codename without index", offline, "iron", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveTag(tt.client, tt.tag); got != tt.want {
+				t.Errorf("resolveTag(%q) = %q, want %q", tt.tag, got, tt.want)
 			}
 		})
 	}
@@ -262,8 +414,8 @@ func TestDockerfileDetector_GetPriority(t *testing.T) {
 	detector := &DockerfileDetector{releasesClient: mockClient}
 
 	priority := detector.GetPriority()
-	if priority != 4 {
-		t.Errorf("GetPriority() = %d, want 4 (lowest priority)", priority)
+	if priority != 6 {
+		t.Errorf("GetPriority() = %d, want 6 (lowest priority)", priority)
 	}
 }
 
@@ -276,3 +428,55 @@ func TestDockerfileDetector_GetSourceName(t *testing.T) {
 		t.Errorf("GetSourceName() = %s, want 'Dockerfile'", sourceName)
 	}
 }
+
+func TestDockerfileDetector_DockerStageSetting(t *testing.T) {
+	mockClient := newMockReleasesClient()
+	detector := &DockerfileDetector{releasesClient: mockClient}
+
+	dockerfile := `ARG NODE_VERSION=18
+FROM node:${NODE_VERSION} AS build
+RUN npm ci
+
+FROM node:22-alpine AS runtime
+FROM nginx AS static
+`
+
+	tests := []struct {
+		name        string
+		setting     string
+		wantFound   bool
+		wantVersion string
+		wantLine    int
+		wantErr     bool
+	}{
+		{name: "default", wantFound: true, wantVersion: "22", wantLine: 5},
+		{name: "named build stage", setting: "dockerStage: build\n", wantFound: true, wantVersion: "18", wantLine: 1},
+		{name: "stage not based on node", setting: "dockerStage: static\n", wantFound: false},
+		{name: "unknown stage", setting: "dockerStage: test\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "Dockerfile"), []byte(dockerfile), 0644); err != nil {
+				t.Fatalf("failed to write Dockerfile: %v", err)
+			}
+			if tt.setting != "" {
+				if err := os.WriteFile(filepath.Join(tmpDir, ".autonode.yml"), []byte(tt.setting), 0644); err != nil {
+					t.Fatalf("failed to write .autonode.yml: %v", err)
+				}
+			}
+
+			result, err := detector.Detect(tmpDir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Detect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result.Found != tt.wantFound {
+				t.Errorf("Found = %v, want %v", result.Found, tt.wantFound)
+			}
+			if tt.wantFound && (result.Version != tt.wantVersion || result.Line != tt.wantLine) {
+				t.Errorf("Detect() = %s at line %d, want %s at line %d", result.Version, result.Line, tt.wantVersion, tt.wantLine)
+			}
+		})
+	}
+}
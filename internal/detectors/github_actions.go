@@ -0,0 +1,249 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"gopkg.in/yaml.v3"
+)
+
+// GitHubActionsDetector detects Node.js version from the actions/setup-node steps of
+// GitHub Actions workflows
+// Single Responsibility Principle: Only responsible for detecting version from workflow files
+// Open/Closed Principle: Implements VersionDetector interface
+// Liskov Substitution Principle: Can be used anywhere a VersionDetector is expected
+// Dependency Inversion Principle: Depends on releasesClient interface abstraction
+type GitHubActionsDetector struct {
+	releasesClient releasesClient
+	// versionFiles are the detectors that read a node-version-file, by file name
+	versionFiles map[string]core.VersionDetector
+}
+
+// githubMatrixExpression matches a node-version taken from the job matrix: ${{ matrix.node }}
+var githubMatrixExpression = regexp.MustCompile(`^\$\{\{\s*matrix\.([A-Za-z0-9_-]+)\s*\}\}$`)
+
+// NewGitHubActionsDetector creates a new GitHubActionsDetector instance
+func NewGitHubActionsDetector(releasesClient *core.NodeReleasesClient) *GitHubActionsDetector {
+	return newGitHubActionsDetector(releasesClient)
+}
+
+// newGitHubActionsDetector creates the detector with any releases client implementation
+func newGitHubActionsDetector(client releasesClient) *GitHubActionsDetector {
+	return &GitHubActionsDetector{
+		releasesClient: client,
+		versionFiles: map[string]core.VersionDetector{
+			".nvmrc":         NewNvmrcDetector(),
+			".node-version":  NewNodeVersionDetector(),
+			".tool-versions": NewToolVersionsDetector(),
+			"package.json":   &PackageJsonDetector{releasesClient: client},
+		},
+	}
+}
+
+// Detect scans .github/workflows/*.yml (in name order) for the first actions/setup-node step
+// and returns its version. "node-version: ${{ matrix.node }}" picks an entry of the job's
+// matrix (see ciMatrix); "node-version-file" is followed to the referenced file.
+func (d *GitHubActionsDetector) Detect(projectPath string) (core.DetectionResult, error) {
+	workflows, err := d.workflowFiles(projectPath)
+	if err != nil || len(workflows) == 0 {
+		return core.DetectionResult{Found: false}, err
+	}
+
+	settings := readProjectSettings(projectPath)
+
+	for _, workflowPath := range workflows {
+		data, err := os.ReadFile(workflowPath)
+		if err != nil {
+			return core.DetectionResult{Found: false}, err
+		}
+
+		root, err := parseYamlDocument(data)
+		if err != nil {
+			return core.DetectionResult{Found: false}, err
+		}
+
+		jobs := yamlPath(root, "jobs")
+		if jobs == nil || jobs.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for i := 1; i < len(jobs.Content); i += 2 {
+			result, err := d.detectJob(projectPath, workflowPath, jobs.Content[i], settings)
+			if err != nil || result.Found {
+				return result, err
+			}
+		}
+	}
+
+	return core.DetectionResult{Found: false}, nil
+}
+
+// workflowFiles returns the workflow files sorted by name
+func (d *GitHubActionsDetector) workflowFiles(projectPath string) ([]string, error) {
+	dir := filepath.Join(projectPath, ".github", "workflows")
+
+	var files []string
+	for _, pattern := range []string{"*.yml", "*.yaml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// detectJob returns the version of the first setup-node step of a job
+func (d *GitHubActionsDetector) detectJob(projectPath, workflowPath string, job *yaml.Node, settings projectSettings) (core.DetectionResult, error) {
+	steps := yamlPath(job, "steps")
+	if steps == nil || steps.Kind != yaml.SequenceNode {
+		return core.DetectionResult{Found: false}, nil
+	}
+
+	for _, step := range steps.Content {
+		uses := yamlPath(step, "uses")
+		if uses == nil || !isSetupNodeAction(uses.Value) {
+			continue
+		}
+
+		if spec := yamlPath(step, "with", "node-version"); spec != nil && spec.Kind == yaml.ScalarNode && spec.Value != "" {
+			if entry := d.resolveMatrix(job, spec, settings); entry != nil {
+				if version, versionRange := resolveCIVersion(d.releasesClient, entry.Value); version != "" {
+					return core.DetectionResult{
+						Found:   true,
+						Version: version,
+						Source:  workflowPath,
+						Range:   versionRange,
+						Line:    entry.Line,
+					}, nil
+				}
+			}
+		}
+
+		if file := yamlPath(step, "with", "node-version-file"); file != nil && file.Kind == yaml.ScalarNode && file.Value != "" {
+			if strings.Contains(file.Value, "${{") {
+				continue
+			}
+			// Only files in the repository are read (and rewritten by FixVersion)
+			path := filepath.Join(projectPath, filepath.FromSlash(file.Value))
+			if filepath.IsAbs(filepath.FromSlash(file.Value)) || !isWithinDir(projectPath, path) {
+				continue
+			}
+			result, err := d.readVersionFile(path)
+			if err != nil || result.Found {
+				return result, err
+			}
+		}
+	}
+
+	return core.DetectionResult{Found: false}, nil
+}
+
+// resolveMatrix returns the node holding the version: spec itself, or the matrix entry
+// it refers to. Returns nil for other expressions, which can't be evaluated here.
+func (d *GitHubActionsDetector) resolveMatrix(job, spec *yaml.Node, settings projectSettings) *yaml.Node {
+	matches := githubMatrixExpression.FindStringSubmatch(strings.TrimSpace(spec.Value))
+	if matches == nil {
+		if strings.Contains(spec.Value, "${{") {
+			return nil
+		}
+		return spec
+	}
+
+	return pickMatrixEntry(yamlScalars(yamlPath(job, "strategy", "matrix", matches[1])), settings.CIMatrix)
+}
+
+// readVersionFile reads the version from a node-version-file, the way setup-node does:
+// .nvmrc, .node-version, .tool-versions and package.json have their own format,
+// any other file holds a version spec, read like an inline node-version
+func (d *GitHubActionsDetector) readVersionFile(path string) (core.DetectionResult, error) {
+	if detector, ok := d.versionFiles[filepath.Base(path)]; ok {
+		return detector.Detect(filepath.Dir(path))
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return core.DetectionResult{Found: false}, nil
+		}
+		return core.DetectionResult{Found: false}, err
+	}
+
+	spec, _, _ := strings.Cut(strings.TrimSpace(string(content)), "\n")
+	version, versionRange := resolveCIVersion(d.releasesClient, spec)
+	if version == "" {
+		return core.DetectionResult{Found: false}, nil
+	}
+
+	return core.DetectionResult{
+		Found:   true,
+		Version: version,
+		Source:  path,
+		Range:   versionRange,
+		Line:    firstContentLine(content),
+	}, nil
+}
+
+// isWithinDir reports whether path is dir or lies below it
+func isWithinDir(dir, path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// FixVersion replaces the version on the workflow line it was read from.
+// A version read through node-version-file is fixed in the referenced file.
+func (d *GitHubActionsDetector) FixVersion(result core.DetectionResult, version string) error {
+	if d.isWorkflowFile(result.Source) {
+		return rewriteVersionOnLine(result.Source, result.Line, ciVersionPattern, version)
+	}
+
+	if fixer, ok := d.versionFiles[filepath.Base(result.Source)].(core.VersionSourceFixer); ok {
+		return fixer.FixVersion(result, version)
+	}
+	return rewriteVersionOnLine(result.Source, result.Line, plainVersionPattern, version)
+}
+
+// isWorkflowFile reports whether path is one of the workflows Detect reads, rather than
+// a file referenced by node-version-file
+func (d *GitHubActionsDetector) isWorkflowFile(path string) bool {
+	// Workflows are <project>/.github/workflows/<name>
+	projectPath := filepath.Dir(filepath.Dir(filepath.Dir(path)))
+	workflows, _ := d.workflowFiles(projectPath)
+	for _, workflow := range workflows {
+		if workflow == path {
+			return true
+		}
+	}
+	return false
+}
+
+// isSetupNodeAction reports whether a step's "uses" is actions/setup-node
+func isSetupNodeAction(uses string) bool {
+	action, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(uses)), "@")
+	return action == "actions/setup-node"
+}
+
+// GetPriority returns the priority of this detector (9 = after devcontainer.json)
+func (d *GitHubActionsDetector) GetPriority() int {
+	return 9
+}
+
+// GetSourceName returns the name of the version source
+func (d *GitHubActionsDetector) GetSourceName() string {
+	return "GitHub Actions"
+}
+
+// SourceFiles returns the files Detect reads in projectPath: the workflows directory (so
+// added workflows are noticed), every workflow in it and .autonode.yml. A node-version-file
+// a workflow points to is the result's Source.
+func (d *GitHubActionsDetector) SourceFiles(projectPath string) []string {
+	files := sourceFiles(projectPath, ".github/workflows", projectSettingsFile)
+	workflows, _ := d.workflowFiles(projectPath)
+	return append(files, workflows...)
+}
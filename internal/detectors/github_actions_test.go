@@ -0,0 +1,333 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeProjectFiles writes files (by slash-separated relative path) into dir
+func writeProjectFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestGitHubActionsDetector_Detect(t *testing.T) {
+	detector := newGitHubActionsDetector(newMockReleasesClient())
+
+	const matrixWorkflow = `jobs:
+  test:
+    strategy:
+      matrix:
+        node: [18, 22.x, 20]
+    steps:
+      - uses: actions/setup-node@v4
+        with:
+          node-version: ${{ matrix.node }}
+`
+
+	tests := []struct {
+		name        string
+		files       map[string]string
+		wantFound   bool
+		wantVersion string
+		wantRange   string
+		wantSource  string
+		wantLine    int
+	}{
+		{
+			name: "node-version",
+			files: map[string]string{
+				".github/workflows/ci.yml": `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-node@v4
+        with:
+          node-version: "20.11.0"
+`,
+			},
+			wantFound:   true,
+			wantVersion: "20.11.0",
+			wantSource:  ".github/workflows/ci.yml",
+			wantLine:    9,
+		},
+		{
+			name: "x-range",
+			files: map[string]string{
+				".github/workflows/ci.yaml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v3\n        with:\n          node-version: 18.x\n",
+			},
+			wantFound:   true,
+			wantVersion: "18",
+			wantSource:  ".github/workflows/ci.yaml",
+			wantLine:    6,
+		},
+		{
+			name: "range resolves to highest release",
+			files: map[string]string{
+				".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version: '>=20 <22'\n",
+			},
+			wantFound:   true,
+			wantVersion: "21.7.3",
+			wantRange:   ">=20 <22",
+			wantSource:  ".github/workflows/ci.yml",
+			wantLine:    6,
+		},
+		{
+			name: "alias is kept",
+			files: map[string]string{
+				".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version: lts/*\n",
+			},
+			wantFound:   true,
+			wantVersion: "lts/*",
+			wantSource:  ".github/workflows/ci.yml",
+			wantLine:    6,
+		},
+		{
+			name: "matrix picks highest by default",
+			files: map[string]string{
+				".github/workflows/test.yml": matrixWorkflow,
+			},
+			wantFound:   true,
+			wantVersion: "22",
+			wantSource:  ".github/workflows/test.yml",
+			wantLine:    5,
+		},
+		{
+			name: "matrix policy lowest",
+			files: map[string]string{
+				".github/workflows/test.yml": matrixWorkflow,
+				".autonode.yml":              "ciMatrix: lowest\n",
+			},
+			wantFound:   true,
+			wantVersion: "18",
+			wantSource:  ".github/workflows/test.yml",
+			wantLine:    5,
+		},
+		{
+			name: "matrix policy last",
+			files: map[string]string{
+				".github/workflows/test.yml": "jobs:\n  test:\n    strategy:\n      matrix:\n        node:\n          - 22\n          - 20\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version: ${{ matrix.node }}\n",
+				".autonode.yml":              "ciMatrix: Last\n",
+			},
+			wantFound:   true,
+			wantVersion: "20",
+			wantSource:  ".github/workflows/test.yml",
+			wantLine:    7,
+		},
+		{
+			name: "node-version-file .nvmrc",
+			files: map[string]string{
+				".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version-file: .nvmrc\n",
+				".nvmrc":                   "18.17.0\n",
+			},
+			wantFound:   true,
+			wantVersion: "18.17.0",
+			wantSource:  ".nvmrc",
+			wantLine:    1,
+		},
+		{
+			name: "node-version-file package.json",
+			files: map[string]string{
+				".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version-file: package.json\n",
+				"package.json":             "{\n  \"engines\": {\n    \"node\": \"^18.0.0\"\n  }\n}\n",
+			},
+			wantFound:   true,
+			wantVersion: "18.20.5",
+			wantRange:   "^18.0.0",
+			wantSource:  "package.json",
+			wantLine:    3,
+		},
+		{
+			name: "node-version-file plain file",
+			files: map[string]string{
+				".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version-file: config/node.txt\n",
+				"config/node.txt":          "\n20.5.0\n",
+			},
+			wantFound:   true,
+			wantVersion: "20.5.0",
+			wantSource:  "config/node.txt",
+			wantLine:    2,
+		},
+		{
+			name: "node-version-file x-range",
+			files: map[string]string{
+				".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version-file: config/node.txt\n",
+				"config/node.txt":          "v20.x\n",
+			},
+			wantFound:   true,
+			wantVersion: "20",
+			wantSource:  "config/node.txt",
+			wantLine:    1,
+		},
+		{
+			name: "node-version-file that is not a version",
+			files: map[string]string{
+				".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version-file: config/node.txt\n",
+				"config/node.txt":          "$(touch /tmp/pwned)\n",
+			},
+			wantFound: false,
+		},
+		{
+			name: "node-version-file outside the project",
+			files: map[string]string{
+				".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version-file: ../outside.txt\n",
+				"../outside.txt":           "20.5.0\n",
+			},
+			wantFound: false,
+		},
+		{
+			name: "missing node-version-file",
+			files: map[string]string{
+				".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version-file: .nvmrc\n",
+			},
+			wantFound: false,
+		},
+		{
+			name: "workflows in name order",
+			files: map[string]string{
+				".github/workflows/b.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version: 22\n",
+				".github/workflows/a.yml": "jobs:\n  lint:\n    steps:\n      - run: npm run lint\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version: 16\n",
+			},
+			wantFound:   true,
+			wantVersion: "16",
+			wantSource:  ".github/workflows/a.yml",
+			wantLine:    9,
+		},
+		{
+			name: "other expression is skipped",
+			files: map[string]string{
+				".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version: ${{ inputs.node }}\n",
+			},
+			wantFound: false,
+		},
+		{
+			name: "command substitution is not a version",
+			files: map[string]string{
+				".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version: \"$(touch /tmp/pwned)\"\n",
+			},
+			wantFound: false,
+		},
+		{
+			name: "matrix entry that is not a version",
+			files: map[string]string{
+				".github/workflows/ci.yml": "jobs:\n  test:\n    strategy:\n      matrix:\n        node: [\"$(id)\"]\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version: ${{ matrix.node }}\n",
+			},
+			wantFound: false,
+		},
+		{
+			name: "no setup-node step",
+			files: map[string]string{
+				".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/checkout@v4\n",
+			},
+			wantFound: false,
+		},
+		{
+			name:      "no workflows",
+			files:     map[string]string{"README.md": "# app\n"},
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			writeProjectFiles(t, tmpDir, tt.files)
+
+			result, err := detector.Detect(tmpDir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Found != tt.wantFound {
+				t.Fatalf("Found = %v, want %v (%+v)", result.Found, tt.wantFound, result)
+			}
+			if !tt.wantFound {
+				return
+			}
+
+			wantSource := filepath.Join(tmpDir, filepath.FromSlash(tt.wantSource))
+			if result.Version != tt.wantVersion || result.Range != tt.wantRange {
+				t.Errorf("Detect() = %q (range %q), want %q (range %q)", result.Version, result.Range, tt.wantVersion, tt.wantRange)
+			}
+			if result.Source != wantSource || result.Line != tt.wantLine {
+				t.Errorf("Detect() location = %s:%d, want %s:%d", result.Source, result.Line, wantSource, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestGitHubActionsDetector_FixVersionFile(t *testing.T) {
+	detector := newGitHubActionsDetector(newMockReleasesClient())
+	tmpDir := t.TempDir()
+	writeProjectFiles(t, tmpDir, map[string]string{
+		".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version-file: .tool-versions\n",
+		".tool-versions":           "python 3.12.0\nnodejs 18.17.0\n",
+	})
+
+	result, err := detector.Detect(tmpDir)
+	if err != nil || !result.Found {
+		t.Fatalf("Detect() = %+v, %v", result, err)
+	}
+
+	// The referenced file is fixed, the workflow is left alone
+	if err := detector.FixVersion(result, "20.11.0"); err != nil {
+		t.Fatalf("FixVersion() error = %v", err)
+	}
+	got, _ := os.ReadFile(filepath.Join(tmpDir, ".tool-versions"))
+	if want := "python 3.12.0\nnodejs 20.11.0\n"; string(got) != want {
+		t.Errorf("FixVersion() wrote %q, want %q", got, want)
+	}
+
+	// A referenced file is not a workflow just because its directory is named like one
+	writeProjectFiles(t, tmpDir, map[string]string{
+		".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version-file: config/workflows/.nvmrc\n",
+		"config/workflows/.nvmrc":  "18.17.0\n",
+	})
+	result, err = detector.Detect(tmpDir)
+	if err != nil || !result.Found {
+		t.Fatalf("Detect() = %+v, %v", result, err)
+	}
+	if err := detector.FixVersion(result, "20.11.0"); err != nil {
+		t.Fatalf("FixVersion() error = %v", err)
+	}
+	got, _ = os.ReadFile(filepath.Join(tmpDir, "config", "workflows", ".nvmrc"))
+	if want := "20.11.0\n"; string(got) != want {
+		t.Errorf("FixVersion() wrote %q, want %q", got, want)
+	}
+}
+
+func TestIsSetupNodeAction(t *testing.T) {
+	tests := []struct {
+		uses string
+		want bool
+	}{
+		{"actions/setup-node@v4", true},
+		{"actions/setup-node@1a4442cacd436585916779262731d5b162bc6ec7", true},
+		{"Actions/Setup-Node", true},
+		{"actions/setup-python@v5", false},
+		{"my-org/actions/setup-node@v1", false},
+	}
+
+	for _, tt := range tests {
+		if got := isSetupNodeAction(tt.uses); got != tt.want {
+			t.Errorf("isSetupNodeAction(%q) = %v, want %v", tt.uses, got, tt.want)
+		}
+	}
+}
+
+func TestGitHubActionsDetector_GetPriority(t *testing.T) {
+	detector := newGitHubActionsDetector(newMockReleasesClient())
+	if priority := detector.GetPriority(); priority != 9 {
+		t.Errorf("GetPriority() = %d, want 9 (after devcontainer.json)", priority)
+	}
+}
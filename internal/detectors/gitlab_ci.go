@@ -0,0 +1,196 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"gopkg.in/yaml.v3"
+)
+
+// GitLabCIDetector detects Node.js version from the node images of .gitlab-ci.yml
+// Single Responsibility Principle: Only responsible for detecting version from GitLab CI config
+// Open/Closed Principle: Implements VersionDetector interface
+// Liskov Substitution Principle: Can be used anywhere a VersionDetector is expected
+// Dependency Inversion Principle: Depends on releasesClient interface abstraction
+type GitLabCIDetector struct {
+	releasesClient releasesClient
+}
+
+// gitlabKeywords are the top-level keys of .gitlab-ci.yml that are not jobs
+var gitlabKeywords = map[string]bool{
+	"default": true, "include": true, "stages": true, "variables": true, "workflow": true,
+	"image": true, "services": true, "cache": true, "before_script": true, "after_script": true,
+	"types": true, "spec": true,
+}
+
+// NewGitLabCIDetector creates a new GitLabCIDetector instance
+func NewGitLabCIDetector(releasesClient *core.NodeReleasesClient) *GitLabCIDetector {
+	return &GitLabCIDetector{
+		releasesClient: releasesClient,
+	}
+}
+
+// Detect reads .gitlab-ci.yml and returns the version of the first node image: the
+// global image (top-level or under default) first, then the image of each job in file
+// order. Variables in the image ("node:$NODE_VERSION") are expanded from the variables
+// blocks, and from parallel:matrix (see ciMatrix) when the job runs a matrix.
+func (d *GitLabCIDetector) Detect(projectPath string) (core.DetectionResult, error) {
+	configPath := filepath.Join(projectPath, ".gitlab-ci.yml")
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return core.DetectionResult{Found: false}, nil
+		}
+		return core.DetectionResult{Found: false}, err
+	}
+
+	root, err := parseYamlDocument(data)
+	if err != nil || root == nil || root.Kind != yaml.MappingNode {
+		return core.DetectionResult{Found: false}, err
+	}
+
+	settings := readProjectSettings(projectPath)
+	globals := gitlabVariables(nil, yamlPath(root, "variables"))
+
+	// Global image, then each job's own image
+	candidates := []*yaml.Node{root, yamlPath(root, "default")}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		name := root.Content[i].Value
+		if gitlabKeywords[name] || strings.HasPrefix(name, ".") || root.Content[i+1].Kind != yaml.MappingNode {
+			continue
+		}
+		candidates = append(candidates, root.Content[i+1])
+	}
+
+	for i, candidate := range candidates {
+		image := gitlabImage(candidate)
+		if image == nil {
+			continue
+		}
+
+		variables := globals
+		if i >= 2 {
+			// Jobs can override variables and run a matrix over them
+			variables = gitlabVariables(globals, yamlPath(candidate, "variables"))
+			variables = gitlabMatrixVariables(variables, yamlPath(candidate, "parallel", "matrix"), settings.CIMatrix)
+		}
+
+		// Variables defined only in the CI/CD settings can't be known here
+		expanded, variableLine, resolved := expandDockerArgs(image.Value, variables)
+		if !resolved {
+			continue
+		}
+		tag, ok := nodeImageTag(expanded)
+		if !ok {
+			continue
+		}
+
+		if version := resolveNodeImageTag(d.releasesClient, tag); version != "" {
+			line := image.Line
+			if variableLine > 0 {
+				line = variableLine
+			}
+			return core.DetectionResult{
+				Found:   true,
+				Version: version,
+				Source:  configPath,
+				Line:    line,
+			}, nil
+		}
+	}
+
+	return core.DetectionResult{Found: false}, nil
+}
+
+// gitlabImage returns the image name node of a job or of the global/default section
+// The image is either a string or a mapping with a name
+func gitlabImage(section *yaml.Node) *yaml.Node {
+	image := yamlPath(section, "image")
+	if image != nil && image.Kind == yaml.MappingNode {
+		image = yamlPath(image, "name")
+	}
+	if image == nil || image.Kind != yaml.ScalarNode {
+		return nil
+	}
+	return image
+}
+
+// gitlabVariables returns inherited with the variables of a variables block added
+// Variables are either "NAME: value" or "NAME: {value: ..., description: ...}"
+func gitlabVariables(inherited map[string]dockerArg, block *yaml.Node) map[string]dockerArg {
+	variables := make(map[string]dockerArg, len(inherited))
+	for name, variable := range inherited {
+		variables[name] = variable
+	}
+
+	if block == nil || block.Kind != yaml.MappingNode {
+		return variables
+	}
+
+	for i := 0; i+1 < len(block.Content); i += 2 {
+		value := block.Content[i+1]
+		if value.Kind == yaml.MappingNode {
+			value = yamlPath(value, "value")
+		}
+		if value != nil && value.Kind == yaml.ScalarNode {
+			variables[block.Content[i].Value] = dockerArg{value: value.Value, line: value.Line}
+		}
+	}
+
+	return variables
+}
+
+// gitlabMatrixVariables sets each variable of a parallel:matrix to the entry chosen by policy
+// The matrix is a list of mappings from variable name to a value or a list of values
+func gitlabMatrixVariables(variables map[string]dockerArg, matrix *yaml.Node, policy string) map[string]dockerArg {
+	if matrix == nil || matrix.Kind != yaml.SequenceNode {
+		return variables
+	}
+
+	entries := make(map[string][]*yaml.Node)
+	var names []string
+	for _, item := range matrix.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			name := item.Content[i].Value
+			if _, seen := entries[name]; !seen {
+				names = append(names, name)
+			}
+			entries[name] = append(entries[name], yamlScalars(item.Content[i+1])...)
+		}
+	}
+
+	for _, name := range names {
+		if entry := pickMatrixEntry(entries[name], policy); entry != nil {
+			variables[name] = dockerArg{value: entry.Value, line: entry.Line}
+		}
+	}
+
+	return variables
+}
+
+// FixVersion replaces the version on the line it was read from: the image tag, or
+// the variable or matrix entry substituted into it
+func (d *GitLabCIDetector) FixVersion(result core.DetectionResult, version string) error {
+	return rewriteVersionOnLine(result.Source, result.Line, ciVersionPattern, version)
+}
+
+// GetPriority returns the priority of this detector (10 = after GitHub Actions)
+func (d *GitLabCIDetector) GetPriority() int {
+	return 10
+}
+
+// GetSourceName returns the name of the version source
+func (d *GitLabCIDetector) GetSourceName() string {
+	return ".gitlab-ci.yml"
+}
+
+// SourceFiles returns the files Detect reads in projectPath: the config and .autonode.yml, which tunes matrix handling
+func (d *GitLabCIDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, ".gitlab-ci.yml", projectSettingsFile)
+}
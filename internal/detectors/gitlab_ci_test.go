@@ -0,0 +1,166 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGitLabCIDetector_Detect(t *testing.T) {
+	detector := &GitLabCIDetector{releasesClient: newMockReleasesClient()}
+
+	tests := []struct {
+		name        string
+		config      string
+		settings    string
+		wantFound   bool
+		wantVersion string
+		wantLine    int
+	}{
+		{
+			name:        "global image",
+			config:      "image: node:20-alpine\n\ntest:\n  script: npm test\n",
+			wantFound:   true,
+			wantVersion: "20",
+			wantLine:    1,
+		},
+		{
+			name:        "default image mapping",
+			config:      "default:\n  image:\n    name: docker.io/library/node:18.17.0\n    entrypoint: [\"\"]\n",
+			wantFound:   true,
+			wantVersion: "18.17.0",
+			wantLine:    3,
+		},
+		{
+			name: "first job in file order",
+			config: `stages: [build, test]
+.template:
+  image: node:16
+lint:
+  image: python:3.12
+build:
+  image: node:iron
+test:
+  image: node:22
+`,
+			wantFound:   true,
+			wantVersion: "20",
+			wantLine:    7,
+		},
+		{
+			name: "global variable",
+			config: `variables:
+  NODE_VERSION: "18"
+image: node:${NODE_VERSION}-slim
+`,
+			wantFound:   true,
+			wantVersion: "18",
+			wantLine:    2,
+		},
+		{
+			name: "job variable overrides global",
+			config: `variables:
+  NODE_VERSION: "18"
+test:
+  variables:
+    NODE_VERSION:
+      value: "20.5.0"
+      description: Node.js version
+  image: node:$NODE_VERSION
+`,
+			wantFound:   true,
+			wantVersion: "20.5.0",
+			wantLine:    6,
+		},
+		{
+			name: "parallel matrix picks highest",
+			config: `test:
+  image: node:$NODE
+  parallel:
+    matrix:
+      - NODE: ["18", "20"]
+      - NODE: "22"
+`,
+			wantFound:   true,
+			wantVersion: "22",
+			wantLine:    6,
+		},
+		{
+			name: "parallel matrix policy first",
+			config: `test:
+  image: node:$NODE
+  parallel:
+    matrix:
+      - NODE:
+          - "18"
+          - "20"
+`,
+			settings:    "ciMatrix: first\n",
+			wantFound:   true,
+			wantVersion: "18",
+			wantLine:    6,
+		},
+		{
+			name:        "variable defined outside the file",
+			config:      "image: node:$NODE_VERSION\n\ntest:\n  image: node:18\n  script: npm test\n",
+			wantFound:   true,
+			wantVersion: "18",
+			wantLine:    4,
+		},
+		{
+			name:      "no node image",
+			config:    "image: ruby:3.3\ntest:\n  script: rake\n",
+			wantFound: false,
+		},
+		{
+			name:      "empty file",
+			config:    "",
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			files := map[string]string{".gitlab-ci.yml": tt.config}
+			if tt.settings != "" {
+				files[".autonode.yml"] = tt.settings
+			}
+			writeProjectFiles(t, tmpDir, files)
+
+			result, err := detector.Detect(tmpDir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Found != tt.wantFound {
+				t.Fatalf("Found = %v, want %v (%+v)", result.Found, tt.wantFound, result)
+			}
+			if !tt.wantFound {
+				return
+			}
+			configPath := filepath.Join(tmpDir, ".gitlab-ci.yml")
+			if result.Version != tt.wantVersion || result.Line != tt.wantLine || result.Source != configPath {
+				t.Errorf("Detect() = %+v, want %s at %s:%d", result, tt.wantVersion, configPath, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestGitLabCIDetector_InvalidYaml(t *testing.T) {
+	detector := &GitLabCIDetector{releasesClient: newMockReleasesClient()}
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, ".gitlab-ci.yml"), []byte("image: [\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	if _, err := detector.Detect(tmpDir); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
+
+func TestGitLabCIDetector_GetPriority(t *testing.T) {
+	detector := &GitLabCIDetector{releasesClient: newMockReleasesClient()}
+	if priority := detector.GetPriority(); priority != 10 {
+		t.Errorf("GetPriority() = %d, want 10 (after GitHub Actions)", priority)
+	}
+}
@@ -0,0 +1,129 @@
+package detectors
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// MiseTomlDetector detects Node.js version from the [tools] table of mise.toml / .mise.toml
+// Single Responsibility Principle: Only responsible for detecting version from mise config files
+// Open/Closed Principle: Implements VersionDetector interface
+// Liskov Substitution Principle: Can be used anywhere a VersionDetector is expected
+type MiseTomlDetector struct{}
+
+// miseConfigFiles lists the mise config file names in the order mise reads them
+var miseConfigFiles = []string{"mise.toml", ".mise.toml"}
+
+// miseToolVersion matches the value forms mise accepts for a tool:
+// node = "20", node = ["20", "18"], node = { version = "20" }
+var miseToolVersion = regexp.MustCompile(`^(?:\[\s*)?(?:\{[^}]*version\s*=\s*)?["']([^"']+)["']`)
+
+// miseToolVersionLine matches the first node version on the line of a tool entry
+var miseToolVersionLine = regexp.MustCompile(`^([^=]*=\s*(?:\[\s*)?(?:\{[^}]*version\s*=\s*)?["'])([^"']+)`)
+
+// NewMiseTomlDetector creates a new MiseTomlDetector instance
+func NewMiseTomlDetector() *MiseTomlDetector {
+	return &MiseTomlDetector{}
+}
+
+// Detect reads mise.toml (or .mise.toml) and extracts the node version from [tools]
+func (d *MiseTomlDetector) Detect(projectPath string) (core.DetectionResult, error) {
+	for _, fileName := range miseConfigFiles {
+		configPath := filepath.Join(projectPath, fileName)
+
+		version, line, err := d.readNodeVersion(configPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return core.DetectionResult{Found: false}, err
+		}
+
+		if version != "" {
+			return core.DetectionResult{
+				Found:   true,
+				Version: version,
+				Source:  configPath,
+				Line:    line,
+			}, nil
+		}
+	}
+
+	return core.DetectionResult{Found: false}, nil
+}
+
+// FixVersion replaces the node version in the config file with version
+// When several versions are listed, only the preferred (first) one is replaced.
+func (d *MiseTomlDetector) FixVersion(result core.DetectionResult, version string) error {
+	return rewriteVersionOnLine(result.Source, result.Line, miseToolVersionLine, version)
+}
+
+// readNodeVersion scans a mise config file for the node tool entry and returns
+// the version with its 1-based line number.
+// Only the subset of TOML used by mise tool declarations is understood:
+// keys inside [tools] and dotted "tools.node" keys at the top level
+func (d *MiseTomlDetector) readNodeVersion(configPath string) (string, int, error) {
+	file, err := os.Open(configPath)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	table := ""
+	lineNumber := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Table header: [tools], possibly followed by a comment
+		if strings.HasPrefix(line, "[") && !strings.HasPrefix(line, "[[") {
+			header, _, _ := strings.Cut(line, "#")
+			table = strings.TrimSpace(strings.Trim(strings.TrimSpace(header), "[]"))
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+
+		key = strings.Trim(strings.TrimSpace(key), `"'`)
+		if table != "" {
+			key = table + "." + key
+		}
+
+		if key != "tools.node" && key != "tools.nodejs" {
+			continue
+		}
+
+		if matches := miseToolVersion.FindStringSubmatch(strings.TrimSpace(value)); matches != nil {
+			return strings.TrimSpace(matches[1]), lineNumber, nil
+		}
+	}
+
+	return "", 0, scanner.Err()
+}
+
+// GetPriority returns the priority of this detector (3 = after .node-version, before .tool-versions)
+func (d *MiseTomlDetector) GetPriority() int {
+	return 3
+}
+
+// GetSourceName returns the name of the version source
+func (d *MiseTomlDetector) GetSourceName() string {
+	return "mise.toml"
+}
+
+// SourceFiles returns the files Detect reads in projectPath
+func (d *MiseTomlDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, miseConfigFiles...)
+}
@@ -0,0 +1,159 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMiseTomlDetector_Detect(t *testing.T) {
+	detector := NewMiseTomlDetector()
+
+	tests := []struct {
+		name        string
+		fileName    string
+		fileContent string
+		wantFound   bool
+		wantVersion string
+	}{
+		{
+			name:     "tools table",
+			fileName: "mise.toml",
+			fileContent: `[tools]
+node = "20.11.0"`,
+			wantFound:   true,
+			wantVersion: "20.11.0",
+		},
+		{
+			name:     "hidden config file",
+			fileName: ".mise.toml",
+			fileContent: `[tools]
+node = "22"`,
+			wantFound:   true,
+			wantVersion: "22",
+		},
+		{
+			name:     "nodejs key and single quotes",
+			fileName: "mise.toml",
+			fileContent: `[tools]
+nodejs = '18.17.0'`,
+			wantFound:   true,
+			wantVersion: "18.17.0",
+		},
+		{
+			name:     "array uses first version",
+			fileName: "mise.toml",
+			fileContent: `[tools]
+node = ["20", "18"]`,
+			wantFound:   true,
+			wantVersion: "20",
+		},
+		{
+			name:     "inline table with version",
+			fileName: "mise.toml",
+			fileContent: `[tools]
+node = { version = "20.11.0", postinstall = "corepack enable" }`,
+			wantFound:   true,
+			wantVersion: "20.11.0",
+		},
+		{
+			name:        "dotted key",
+			fileName:    "mise.toml",
+			fileContent: `tools.node = "21"`,
+			wantFound:   true,
+			wantVersion: "21",
+		},
+		{
+			name:     "other tables ignored",
+			fileName: "mise.toml",
+			fileContent: `[env]
+node = "not-a-tool"
+
+[tools]
+python = "3.12"
+node = "20" # pinned`,
+			wantFound:   true,
+			wantVersion: "20",
+		},
+		{
+			name:     "comment after table header",
+			fileName: "mise.toml",
+			fileContent: `[env] # project variables
+node = "not-a-tool"
+
+[tools] # node
+node = "22.12.0"`,
+			wantFound:   true,
+			wantVersion: "22.12.0",
+		},
+		{
+			name:     "no node tool",
+			fileName: "mise.toml",
+			fileContent: `[tools]
+python = "3.12"`,
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			filePath := filepath.Join(tmpDir, tt.fileName)
+
+			err := os.WriteFile(filePath, []byte(tt.fileContent), 0644)
+			if err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			result, err := detector.Detect(tmpDir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Found != tt.wantFound {
+				t.Errorf("Found = %v, want %v", result.Found, tt.wantFound)
+			}
+
+			if tt.wantFound {
+				if result.Version != tt.wantVersion {
+					t.Errorf("Version = %v, want %v", result.Version, tt.wantVersion)
+				}
+				if result.Source != filePath {
+					t.Errorf("Source = %v, want %v", result.Source, filePath)
+				}
+			}
+		})
+	}
+}
+
+func TestMiseTomlDetector_PrefersMiseToml(t *testing.T) {
+	detector := NewMiseTomlDetector()
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"mise.toml":  "[tools]\nnode = \"22\"",
+		".mise.toml": "[tools]\nnode = \"18\"",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	result, err := detector.Detect(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Version != "22" {
+		t.Errorf("Version = %v, want 22 from mise.toml", result.Version)
+	}
+}
+
+func TestMiseTomlDetector_GetPriority(t *testing.T) {
+	detector := NewMiseTomlDetector()
+
+	if priority := detector.GetPriority(); priority != 3 {
+		t.Errorf("GetPriority() = %d, want 3", priority)
+	}
+}
@@ -1,32 +1,47 @@
 package detectors
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
 
 // mockReleasesClient is a mock implementation for testing
 // It implements the same methods as NodeReleasesClient
 type mockReleasesClient struct {
-	codenameMap map[string]string
+	index   core.ReleaseIndex
+	offline bool // When true, GetReleaseIndex fails as if the release index were unreachable
 }
 
-// newMockReleasesClient creates a new mock with predefined codenames
+// newMockReleasesClient creates a new mock with a predefined release index, newest first
 func newMockReleasesClient() *mockReleasesClient {
-	return &mockReleasesClient{
-		codenameMap: map[string]string{
-			"krypton":  "24",
-			"jod":      "22",
-			"iron":     "20",
-			"hydrogen": "18",
-			"gallium":  "16",
-			"fermium":  "14",
-			"erbium":   "12",
-		},
+	releases := []struct {
+		version string
+		lts     interface{}
+	}{
+		{"24.1.0", "Krypton"},
+		{"22.12.0", "Jod"},
+		{"22.11.0", "Jod"},
+		{"21.7.3", false},
+		{"20.18.1", "Iron"},
+		{"20.5.0", "Iron"},
+		{"18.20.5", "Hydrogen"},
+		{"18.17.0", "Hydrogen"},
+		{"16.20.2", "Gallium"},
+		{"16.0.0", false},
+	}
+
+	index := make(core.ReleaseIndex, 0, len(releases))
+	for _, release := range releases {
+		index = append(index, core.NodeRelease{Version: "v" + release.version, LTS: release.lts})
 	}
+	return &mockReleasesClient{index: index}
 }
 
-// GetVersionForCodename returns the version for a given codename (mock implementation)
-func (m *mockReleasesClient) GetVersionForCodename(codename string) (string, error) {
-	if version, found := m.codenameMap[codename]; found {
-		return version, nil
+// GetReleaseIndex returns the predefined release index (mock implementation)
+func (m *mockReleasesClient) GetReleaseIndex() (core.ReleaseIndex, error) {
+	if m.offline {
+		return nil, fmt.Errorf("release index unavailable")
 	}
-	return "", fmt.Errorf("codename '%s' not found", codename)
+	return m.index, nil
 }
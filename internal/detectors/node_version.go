@@ -1,9 +1,9 @@
 package detectors
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/matutetandil/autonode/internal/core"
 )
@@ -34,7 +34,10 @@ func (d *NodeVersionDetector) Detect(projectPath string) (core.DetectionResult,
 		return core.DetectionResult{Found: false}, err
 	}
 
-	version := strings.TrimSpace(string(content))
+	version, line, err := parseVersionFile(content)
+	if err != nil {
+		return core.DetectionResult{Found: false}, fmt.Errorf("invalid %s: %w", nodeVersionPath, err)
+	}
 	if version == "" {
 		return core.DetectionResult{Found: false}, nil
 	}
@@ -42,10 +45,16 @@ func (d *NodeVersionDetector) Detect(projectPath string) (core.DetectionResult,
 	return core.DetectionResult{
 		Found:   true,
 		Version: version,
-		Source:  ".node-version",
+		Source:  nodeVersionPath,
+		Line:    line,
 	}, nil
 }
 
+// FixVersion replaces the version in the file with version
+func (d *NodeVersionDetector) FixVersion(result core.DetectionResult, version string) error {
+	return rewriteVersionOnLine(result.Source, result.Line, plainVersionPattern, version)
+}
+
 // GetPriority returns the priority of this detector (2 = third priority)
 func (d *NodeVersionDetector) GetPriority() int {
 	return 2
@@ -55,3 +64,8 @@ func (d *NodeVersionDetector) GetPriority() int {
 func (d *NodeVersionDetector) GetSourceName() string {
 	return ".node-version"
 }
+
+// SourceFiles returns the files Detect reads in projectPath
+func (d *NodeVersionDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, ".node-version")
+}
@@ -33,6 +33,12 @@ func TestNodeVersionDetector_Detect(t *testing.T) {
 			wantFound:   true,
 			wantVersion: "v20.0.0",
 		},
+		{
+			name:        "comments",
+			fileContent: "# pinned for the build image\n22.12.0 # jod\n",
+			wantFound:   true,
+			wantVersion: "22.12.0",
+		},
 		{
 			name:        "empty file",
 			fileContent: "",
@@ -1,9 +1,9 @@
 package detectors
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/matutetandil/autonode/internal/core"
 )
@@ -34,7 +34,10 @@ func (d *NvmrcDetector) Detect(projectPath string) (core.DetectionResult, error)
 		return core.DetectionResult{Found: false}, err
 	}
 
-	version := strings.TrimSpace(string(content))
+	version, line, err := parseVersionFile(content)
+	if err != nil {
+		return core.DetectionResult{Found: false}, fmt.Errorf("invalid %s: %w", nvmrcPath, err)
+	}
 	if version == "" {
 		return core.DetectionResult{Found: false}, nil
 	}
@@ -42,10 +45,16 @@ func (d *NvmrcDetector) Detect(projectPath string) (core.DetectionResult, error)
 	return core.DetectionResult{
 		Found:   true,
 		Version: version,
-		Source:  ".nvmrc",
+		Source:  nvmrcPath,
+		Line:    line,
 	}, nil
 }
 
+// FixVersion replaces the version in the file with version
+func (d *NvmrcDetector) FixVersion(result core.DetectionResult, version string) error {
+	return rewriteVersionOnLine(result.Source, result.Line, plainVersionPattern, version)
+}
+
 // GetPriority returns the priority of this detector (1 = second priority, after .autonode.yml)
 func (d *NvmrcDetector) GetPriority() int {
 	return 1
@@ -55,3 +64,8 @@ func (d *NvmrcDetector) GetPriority() int {
 func (d *NvmrcDetector) GetSourceName() string {
 	return ".nvmrc"
 }
+
+// SourceFiles returns the files Detect reads in projectPath
+func (d *NvmrcDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, ".nvmrc")
+}
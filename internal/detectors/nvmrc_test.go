@@ -15,6 +15,8 @@ func TestNvmrcDetector_Detect(t *testing.T) {
 		wantFound   bool
 		wantVersion string
 		wantSource  string
+		wantLine    int
+		wantErr     bool
 	}{
 		{
 			name:        "valid version",
@@ -44,6 +46,42 @@ func TestNvmrcDetector_Detect(t *testing.T) {
 			wantVersion: "lts/*",
 			wantSource:  ".nvmrc",
 		},
+		{
+			name:        "inline comment",
+			fileContent: "20.11.0 # matches the Docker image\n",
+			wantFound:   true,
+			wantVersion: "20.11.0",
+			wantLine:    1,
+		},
+		{
+			name:        "comment lines and blank lines",
+			fileContent: "# Node.js version for this project\n\n  lts/iron  \n",
+			wantFound:   true,
+			wantVersion: "lts/iron",
+			wantLine:    3,
+		},
+		{
+			name:        "settings are ignored",
+			fileContent: "22\nnpm=10 # reserved by nvm\n",
+			wantFound:   true,
+			wantVersion: "22",
+			wantLine:    1,
+		},
+		{
+			name:        "comments only",
+			fileContent: "# no version yet\n",
+			wantFound:   false,
+		},
+		{
+			name:        "more than one version",
+			fileContent: "18\n20\n",
+			wantErr:     true,
+		},
+		{
+			name:        "version set as a setting",
+			fileContent: "node=20\n",
+			wantErr:     true,
+		},
 		{
 			name:        "empty file",
 			fileContent: "",
@@ -70,6 +108,12 @@ func TestNvmrcDetector_Detect(t *testing.T) {
 
 			// Test detection
 			result, err := detector.Detect(tmpDir)
+			if tt.wantErr {
+				if err == nil || result.Found {
+					t.Fatalf("Detect() = %+v, %v, want error", result, err)
+				}
+				return
+			}
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -82,8 +126,11 @@ func TestNvmrcDetector_Detect(t *testing.T) {
 				if result.Version != tt.wantVersion {
 					t.Errorf("Version = %v, want %v", result.Version, tt.wantVersion)
 				}
-				if result.Source != tt.wantSource {
-					t.Errorf("Source = %v, want %v", result.Source, tt.wantSource)
+				if result.Source != nvmrcPath {
+					t.Errorf("Source = %v, want %v", result.Source, nvmrcPath)
+				}
+				if tt.wantLine != 0 && result.Line != tt.wantLine {
+					t.Errorf("Line = %d, want %d", result.Line, tt.wantLine)
 				}
 			}
 		})
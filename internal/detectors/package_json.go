@@ -2,32 +2,65 @@ package detectors
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/matutetandil/autonode/internal/core"
 )
 
-// PackageJsonDetector detects Node.js version from package.json engines.node field
+// PackageJsonDetector detects Node.js version from package.json: devEngines.runtime,
+// volta.node or engines.node
 // Single Responsibility Principle: Only responsible for detecting version from package.json
 // Open/Closed Principle: Implements VersionDetector interface
 // Liskov Substitution Principle: Can be used anywhere a VersionDetector is expected
-type PackageJsonDetector struct{}
+// Dependency Inversion Principle: Depends on releasesClient interface abstraction
+type PackageJsonDetector struct {
+	releasesClient releasesClient
+}
 
 // packageJSON represents the structure of package.json we care about
 type packageJSON struct {
 	Engines struct {
 		Node string `json:"node"`
 	} `json:"engines"`
+	Volta struct {
+		Node    string `json:"node"`
+		Extends string `json:"extends"`
+	} `json:"volta"`
+	DevEngines struct {
+		// Runtime is a single runtime object or a list of them
+		Runtime json.RawMessage `json:"runtime"`
+	} `json:"devEngines"`
+}
+
+// devEngine is a runtime or package manager entry of devEngines
+type devEngine struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
 }
 
+// maxVoltaExtends limits how many volta.extends links are followed, so a cycle can't loop forever
+const maxVoltaExtends = 8
+
+// packageJsonVersionPattern matches the version of engines.node, volta.node or
+// devEngines.runtime on its line
+var packageJsonVersionPattern = regexp.MustCompile(`^(.*"(?:node|version)"\s*:\s*")([^"]*)`)
+
 // NewPackageJsonDetector creates a new PackageJsonDetector instance
-func NewPackageJsonDetector() *PackageJsonDetector {
-	return &PackageJsonDetector{}
+// The releases client is used to resolve version ranges against published Node.js releases
+func NewPackageJsonDetector(releasesClient *core.NodeReleasesClient) *PackageJsonDetector {
+	return &PackageJsonDetector{
+		releasesClient: releasesClient,
+	}
 }
 
-// Detect reads the package.json file and extracts the Node.js version from engines.node
+// Detect reads the package.json file and extracts the Node.js version.
+// Fields are checked in the same order as actions/setup-node: devEngines.runtime (the
+// "node" entry), then volta.node (following volta.extends), then engines.node.
 func (d *PackageJsonDetector) Detect(projectPath string) (core.DetectionResult, error) {
 	packageJsonPath := filepath.Join(projectPath, "package.json")
 
@@ -48,58 +81,157 @@ func (d *PackageJsonDetector) Detect(projectPath string) (core.DetectionResult,
 		return core.DetectionResult{Found: false}, err
 	}
 
-	version := strings.TrimSpace(pkg.Engines.Node)
-	if version == "" {
+	if spec, path := devEngineRuntime(pkg.DevEngines.Runtime); spec != "" {
+		return d.resolveSpec(spec, packageJsonPath, jsonKeyLine(content, path...))
+	}
+
+	source, voltaContent, node, err := d.voltaSource(packageJsonPath, content, pkg)
+	if err != nil {
+		return core.DetectionResult{Found: false}, err
+	}
+	if node != "" {
+		return d.resolveSpec(node, source, jsonKeyLine(voltaContent, "volta", "node"))
+	}
+
+	spec := strings.TrimSpace(pkg.Engines.Node)
+	if spec == "" {
 		return core.DetectionResult{Found: false}, nil
 	}
 
-	// Clean up version specifiers (e.g., ">=16.0.0" -> "16.0.0")
-	version = cleanVersionSpecifier(version)
+	return d.resolveSpec(spec, packageJsonPath, jsonKeyLine(content, "engines", "node"))
+}
+
+// resolveSpec builds the detection result for a version spec read from source
+// Exact versions are used as-is; ranges (e.g., ">=18 <21") resolve to the highest matching release
+func (d *PackageJsonDetector) resolveSpec(spec, source string, line int) (core.DetectionResult, error) {
+	spec = strings.TrimSpace(spec)
+
+	// Exact versions are used as-is, no resolution needed
+	if core.IsExactVersion(spec) {
+		return core.DetectionResult{
+			Found:   true,
+			Version: strings.TrimPrefix(strings.TrimPrefix(spec, "="), "v"),
+			Source:  source,
+			Line:    line,
+		}, nil
+	}
+
+	// Resolve ranges (e.g., ">=18 <21") to the highest matching release
+	version, err := d.resolveRange(spec)
+	if err != nil {
+		return core.DetectionResult{Found: false}, err
+	}
 
 	return core.DetectionResult{
 		Found:   true,
 		Version: version,
-		Source:  "package.json (engines.node)",
+		Source:  source,
+		Range:   spec,
+		Line:    line,
 	}, nil
 }
 
-// GetPriority returns the priority of this detector (3 = fourth priority)
-func (d *PackageJsonDetector) GetPriority() int {
-	return 3
+// voltaSource follows volta.extends until a package.json with volta.node is found, the way
+// Volta does. Returns that file's path, content and volta.node, or an empty path if none pins node.
+func (d *PackageJsonDetector) voltaSource(path string, content []byte, pkg packageJSON) (string, []byte, string, error) {
+	for i := 0; i < maxVoltaExtends; i++ {
+		if node := strings.TrimSpace(pkg.Volta.Node); node != "" {
+			return path, content, node, nil
+		}
+		if pkg.Volta.Extends == "" {
+			return "", nil, "", nil
+		}
+
+		// extends is relative to the package.json that declares it
+		extends := filepath.FromSlash(pkg.Volta.Extends)
+		if !filepath.IsAbs(extends) {
+			extends = filepath.Join(filepath.Dir(path), extends)
+		}
+
+		var err error
+		if content, err = os.ReadFile(extends); err != nil {
+			return "", nil, "", fmt.Errorf("failed to read volta.extends of %s: %w", path, err)
+		}
+		pkg = packageJSON{}
+		if err := json.Unmarshal(content, &pkg); err != nil {
+			return "", nil, "", fmt.Errorf("failed to parse %s: %w", extends, err)
+		}
+		path = extends
+	}
+
+	return "", nil, "", fmt.Errorf("too many volta.extends links from %s", path)
 }
 
-// GetSourceName returns the name of the version source
-func (d *PackageJsonDetector) GetSourceName() string {
-	return "package.json"
+// devEngineRuntime returns the version of the "node" runtime in devEngines.runtime, which
+// is an object or a list of objects, along with its JSON path (to report its line)
+func devEngineRuntime(raw json.RawMessage) (string, []string) {
+	if len(raw) == 0 {
+		return "", nil
+	}
+
+	var single devEngine
+	if err := json.Unmarshal(raw, &single); err == nil {
+		if single.Name == "node" {
+			return strings.TrimSpace(single.Version), []string{"devEngines", "runtime", "version"}
+		}
+		return "", nil
+	}
+
+	var list []devEngine
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return "", nil
+	}
+	for i, runtime := range list {
+		if runtime.Name == "node" {
+			return strings.TrimSpace(runtime.Version), []string{"devEngines", "runtime", strconv.Itoa(i), "version"}
+		}
+	}
+	return "", nil
 }
 
-// cleanVersionSpecifier removes version range operators and extracts a specific version
-func cleanVersionSpecifier(version string) string {
-	// Trim spaces first
-	version = strings.TrimSpace(version)
+// FixVersion replaces the devEngines.runtime, volta.node or engines.node value with version
+func (d *PackageJsonDetector) FixVersion(result core.DetectionResult, version string) error {
+	return rewriteVersionOnLine(result.Source, result.Line, packageJsonVersionPattern, version)
+}
 
-	// Remove common prefixes
-	version = strings.TrimPrefix(version, ">=")
-	version = strings.TrimPrefix(version, "<=")
-	version = strings.TrimPrefix(version, ">")
-	version = strings.TrimPrefix(version, "<")
-	version = strings.TrimPrefix(version, "^")
-	version = strings.TrimPrefix(version, "~")
-	version = strings.TrimPrefix(version, "=")
-	version = strings.TrimSpace(version)
+// resolveRange returns the highest published Node.js version satisfying the range
+func (d *PackageJsonDetector) resolveRange(spec string) (string, error) {
+	versionRange, err := core.ParseVersionRange(spec)
+	if err != nil {
+		return "", err
+	}
 
-	// If it's a range (e.g., "16.0.0 - 18.0.0"), take the first version
-	if strings.Contains(version, " - ") {
-		parts := strings.Split(version, " - ")
-		version = strings.TrimSpace(parts[0])
+	if version := resolveVersionRange(d.releasesClient, versionRange); version != "" {
+		return version, nil
 	}
 
-	// If it contains ||, take the first alternative
-	if strings.Contains(version, "||") {
-		parts := strings.Split(version, "||")
-		version = strings.TrimSpace(parts[0])
-		version = cleanVersionSpecifier(version) // Recursively clean
+	return "", fmt.Errorf("could not resolve Node.js range '%s' to a version", spec)
+}
+
+// resolveVersionRange returns the highest published Node.js version satisfying the range
+// If the release index is unavailable (offline) or nothing matches, falls back to
+// the range's lower bound (e.g., ">=18 <21" -> "18"). Returns empty string for unbounded ranges.
+func resolveVersionRange(client releasesClient, versionRange *core.VersionRange) string {
+	if index, err := client.GetReleaseIndex(); err == nil {
+		if version, found := versionRange.MaxSatisfying(index.Versions()); found {
+			return version
+		}
 	}
 
-	return version
+	return versionRange.MinVersion()
+}
+
+// GetPriority returns the priority of this detector (5 = after version manager files)
+func (d *PackageJsonDetector) GetPriority() int {
+	return 5
+}
+
+// GetSourceName returns the name of the version source
+func (d *PackageJsonDetector) GetSourceName() string {
+	return "package.json"
+}
+
+// SourceFiles returns the files Detect reads in projectPath: package.json (a file it extends is the result's Source)
+func (d *PackageJsonDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, "package.json")
 }
@@ -0,0 +1,88 @@
+package detectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// PackageJsonPackageManagerDetector detects the pinned package manager from the
+// packageManager field of package.json (e.g. "pnpm@9.1.0").
+//
+// This detector adheres to:
+// - Single Responsibility Principle (SRP): Only handles the packageManager field
+// - Open/Closed Principle (OCP): Part of an extensible detection system
+// - Liskov Substitution Principle (LSP): Implements PackageManagerDetector interface
+type PackageJsonPackageManagerDetector struct{}
+
+// packageJsonWithPackageManager represents the relevant fields in package.json
+type packageJsonWithPackageManager struct {
+	PackageManager string `json:"packageManager"`
+}
+
+// packageManagerReference matches "<name>@<version>" with an optional "+<hash>", as Corepack accepts it
+var packageManagerReference = regexp.MustCompile(`^([a-z][a-z0-9-]*)@([^+\s]+)(?:\+(\S+))?$`)
+
+// NewPackageJsonPackageManagerDetector creates a new PackageJsonPackageManagerDetector instance.
+func NewPackageJsonPackageManagerDetector() *PackageJsonPackageManagerDetector {
+	return &PackageJsonPackageManagerDetector{}
+}
+
+// Detect reads the packageManager field of package.json in the project directory.
+// A value that isn't "<name>@<version>" is reported as an error.
+func (d *PackageJsonPackageManagerDetector) Detect(projectPath string) (core.PackageManagerDetectionResult, error) {
+	filePath := filepath.Join(projectPath, "package.json")
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return core.PackageManagerDetectionResult{Found: false}, nil
+		}
+		return core.PackageManagerDetectionResult{Found: false}, err
+	}
+
+	var pkg packageJsonWithPackageManager
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return core.PackageManagerDetectionResult{Found: false}, err
+	}
+
+	reference := strings.TrimSpace(pkg.PackageManager)
+	if reference == "" {
+		return core.PackageManagerDetectionResult{Found: false}, nil
+	}
+
+	matches := packageManagerReference.FindStringSubmatch(reference)
+	if matches == nil {
+		return core.PackageManagerDetectionResult{Found: false},
+			fmt.Errorf("invalid packageManager '%s' (expected <name>@<version>)", reference)
+	}
+
+	return core.PackageManagerDetectionResult{
+		Found:   true,
+		Name:    matches[1],
+		Version: matches[2],
+		Hash:    matches[3],
+		Source:  filePath,
+	}, nil
+}
+
+// GetPriority returns the priority of this detector.
+// Priority 1 means it is checked before .yarnrc.yml.
+func (d *PackageJsonPackageManagerDetector) GetPriority() int {
+	return 1
+}
+
+// GetSourceName returns a human-readable name of the source.
+func (d *PackageJsonPackageManagerDetector) GetSourceName() string {
+	return "package.json"
+}
+
+// SourceFiles returns the files Detect reads in projectPath
+func (d *PackageJsonPackageManagerDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, "package.json")
+}
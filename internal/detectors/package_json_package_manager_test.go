@@ -0,0 +1,86 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPackageJsonPackageManagerDetector_Detect(t *testing.T) {
+	detector := NewPackageJsonPackageManagerDetector()
+
+	tests := []struct {
+		name        string
+		fileContent string
+		wantFound   bool
+		wantName    string
+		wantVersion string
+		wantHash    string
+		wantErr     bool
+	}{
+		{
+			name:        "pnpm",
+			fileContent: `{"packageManager": "pnpm@9.1.0"}`,
+			wantFound:   true,
+			wantName:    "pnpm",
+			wantVersion: "9.1.0",
+		},
+		{
+			name:        "yarn with hash",
+			fileContent: `{"packageManager": "yarn@4.1.1+sha512.ec40d0639bb307441b945d9467139cbb88d14394baac760b52eca038b330d16542d66fef61574271534ace5a200518dabf3b53a85f1f9e4bfa37141b538a9590"}`,
+			wantFound:   true,
+			wantName:    "yarn",
+			wantVersion: "4.1.1",
+			wantHash:    "sha512.ec40d0639bb307441b945d9467139cbb88d14394baac760b52eca038b330d16542d66fef61574271534ace5a200518dabf3b53a85f1f9e4bfa37141b538a9590",
+		},
+		{
+			name:        "no packageManager",
+			fileContent: `{"name": "app"}`,
+			wantFound:   false,
+		},
+		{
+			name:        "missing version",
+			fileContent: `{"packageManager": "pnpm"}`,
+			wantErr:     true,
+		},
+		{
+			name:        "invalid JSON",
+			fileContent: `{invalid`,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			filePath := filepath.Join(tmpDir, "package.json")
+			if err := os.WriteFile(filePath, []byte(tt.fileContent), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			result, err := detector.Detect(tmpDir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Detect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result.Found != tt.wantFound {
+				t.Fatalf("Found = %v, want %v", result.Found, tt.wantFound)
+			}
+			if !tt.wantFound {
+				return
+			}
+			if result.Name != tt.wantName || result.Version != tt.wantVersion || result.Hash != tt.wantHash {
+				t.Errorf("Detect() = %+v, want %s@%s+%s", result, tt.wantName, tt.wantVersion, tt.wantHash)
+			}
+			if result.Source != filePath {
+				t.Errorf("Source = %s, want %s", result.Source, filePath)
+			}
+		})
+	}
+}
+
+func TestPackageJsonPackageManagerDetector_NoFile(t *testing.T) {
+	result, err := NewPackageJsonPackageManagerDetector().Detect(t.TempDir())
+	if err != nil || result.Found {
+		t.Errorf("Detect() = %+v, %v; want not found", result, err)
+	}
+}
@@ -73,3 +73,8 @@ func (d *PackageJsonProfileDetector) GetPriority() int {
 func (d *PackageJsonProfileDetector) GetSourceName() string {
 	return "package.json"
 }
+
+// SourceFiles returns the files Detect reads in projectPath
+func (d *PackageJsonProfileDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, "package.json")
+}
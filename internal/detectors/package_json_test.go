@@ -7,13 +7,14 @@ import (
 )
 
 func TestPackageJsonDetector_Detect(t *testing.T) {
-	detector := NewPackageJsonDetector()
+	detector := &PackageJsonDetector{releasesClient: newMockReleasesClient()}
 
 	tests := []struct {
 		name        string
 		fileContent string
 		wantFound   bool
 		wantVersion string
+		wantRange   string
 	}{
 		{
 			name: "exact version",
@@ -33,7 +34,8 @@ func TestPackageJsonDetector_Detect(t *testing.T) {
 				}
 			}`,
 			wantFound:   true,
-			wantVersion: "16.0.0",
+			wantVersion: "24.1.0",
+			wantRange:   ">=16.0.0",
 		},
 		{
 			name: "version with caret",
@@ -43,7 +45,8 @@ func TestPackageJsonDetector_Detect(t *testing.T) {
 				}
 			}`,
 			wantFound:   true,
-			wantVersion: "18.0.0",
+			wantVersion: "18.20.5",
+			wantRange:   "^18.0.0",
 		},
 		{
 			name: "version with tilde",
@@ -53,7 +56,8 @@ func TestPackageJsonDetector_Detect(t *testing.T) {
 				}
 			}`,
 			wantFound:   true,
-			wantVersion: "16.20.0",
+			wantVersion: "16.20.2",
+			wantRange:   "~16.20.0",
 		},
 		{
 			name: "version range",
@@ -63,17 +67,52 @@ func TestPackageJsonDetector_Detect(t *testing.T) {
 				}
 			}`,
 			wantFound:   true,
-			wantVersion: "16.0.0",
+			wantVersion: "16.20.2",
+			wantRange:   "16.0.0 - 18.0.0",
 		},
 		{
 			name: "version with OR",
 			fileContent: `{
 				"engines": {
-					"node": "16.0.0 || 18.0.0"
+					"node": "16.0.0 || 18.17.0"
 				}
 			}`,
 			wantFound:   true,
-			wantVersion: "16.0.0",
+			wantVersion: "18.17.0",
+			wantRange:   "16.0.0 || 18.17.0",
+		},
+		{
+			name: "range with upper bound",
+			fileContent: `{
+				"engines": {
+					"node": ">=18 <21"
+				}
+			}`,
+			wantFound:   true,
+			wantVersion: "20.18.1",
+			wantRange:   ">=18 <21",
+		},
+		{
+			name: "caret alternatives pick highest",
+			fileContent: `{
+				"engines": {
+					"node": "^20.5 || ^22"
+				}
+			}`,
+			wantFound:   true,
+			wantVersion: "22.12.0",
+			wantRange:   "^20.5 || ^22",
+		},
+		{
+			name: "major version only",
+			fileContent: `{
+				"engines": {
+					"node": "20.x"
+				}
+			}`,
+			wantFound:   true,
+			wantVersion: "20.18.1",
+			wantRange:   "20.x",
 		},
 		{
 			name: "no engines field",
@@ -91,6 +130,60 @@ func TestPackageJsonDetector_Detect(t *testing.T) {
 			}`,
 			wantFound: false,
 		},
+		{
+			name: "volta pin",
+			fileContent: `{
+				"volta": {
+					"node": "20.11.0",
+					"npm": "10.2.4"
+				}
+			}`,
+			wantFound:   true,
+			wantVersion: "20.11.0",
+		},
+		{
+			name: "volta wins over engines",
+			fileContent: `{
+				"engines": { "node": ">=16" },
+				"volta": { "node": "18.17.0" }
+			}`,
+			wantFound:   true,
+			wantVersion: "18.17.0",
+		},
+		{
+			name: "devEngines runtime",
+			fileContent: `{
+				"devEngines": {
+					"runtime": { "name": "node", "version": "^20.5.0", "onFail": "error" }
+				}
+			}`,
+			wantFound:   true,
+			wantVersion: "20.18.1",
+			wantRange:   "^20.5.0",
+		},
+		{
+			name: "devEngines runtime list wins over volta",
+			fileContent: `{
+				"volta": { "node": "18.17.0" },
+				"devEngines": {
+					"runtime": [
+						{ "name": "bun", "version": "1.1.0" },
+						{ "name": "node", "version": "22.12.0" }
+					]
+				}
+			}`,
+			wantFound:   true,
+			wantVersion: "22.12.0",
+		},
+		{
+			name: "devEngines without node falls back to engines",
+			fileContent: `{
+				"devEngines": { "runtime": { "name": "deno", "version": "2" } },
+				"engines": { "node": "18.17.0" }
+			}`,
+			wantFound:   true,
+			wantVersion: "18.17.0",
+		},
 		{
 			name:        "invalid JSON",
 			fileContent: `{invalid json`,
@@ -129,44 +222,94 @@ func TestPackageJsonDetector_Detect(t *testing.T) {
 			if tt.wantFound && result.Version != tt.wantVersion {
 				t.Errorf("Version = %v, want %v", result.Version, tt.wantVersion)
 			}
+
+			if result.Range != tt.wantRange {
+				t.Errorf("Range = %q, want %q", result.Range, tt.wantRange)
+			}
 		})
 	}
 }
 
-func TestPackageJsonDetector_GetPriority(t *testing.T) {
-	detector := NewPackageJsonDetector()
-
-	priority := detector.GetPriority()
-	if priority != 3 {
-		t.Errorf("GetPriority() = %d, want 3", priority)
-	}
-}
+func TestPackageJsonDetector_Detect_Offline(t *testing.T) {
+	client := newMockReleasesClient()
+	client.offline = true
+	detector := &PackageJsonDetector{releasesClient: client}
 
-func TestCleanVersionSpecifier(t *testing.T) {
 	tests := []struct {
-		name  string
-		input string
-		want  string
+		name        string
+		engines     string
+		wantVersion string
+		wantErr     bool
 	}{
-		{"exact version", "18.17.0", "18.17.0"},
-		{"caret", "^18.17.0", "18.17.0"},
-		{"tilde", "~18.17.0", "18.17.0"},
-		{"gte", ">=18.17.0", "18.17.0"},
-		{"lte", "<=18.17.0", "18.17.0"},
-		{"gt", ">18.17.0", "18.17.0"},
-		{"lt", "<18.17.0", "18.17.0"},
-		{"equals", "=18.17.0", "18.17.0"},
-		{"range", "16.0.0 - 18.0.0", "16.0.0"},
-		{"or", "16.0.0 || 18.0.0", "16.0.0"},
-		{"with spaces", "  >= 18.17.0  ", "18.17.0"},
+		{"lower bound of range", ">=18 <21", "18", false},
+		{"highest alternative", "^20.5 || ^22", "22", false},
+		{"full lower bound", ">=16.0.0", "16.0.0", false},
+		{"exact version needs no index", "18.17.0", "18.17.0", false},
+		{"unbounded range", "<21", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := cleanVersionSpecifier(tt.input)
-			if got != tt.want {
-				t.Errorf("cleanVersionSpecifier(%q) = %q, want %q", tt.input, got, tt.want)
+			tmpDir := t.TempDir()
+			content := `{"engines": {"node": "` + tt.engines + `"}}`
+			if err := os.WriteFile(filepath.Join(tmpDir, "package.json"), []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			result, err := detector.Detect(tmpDir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Detect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && result.Version != tt.wantVersion {
+				t.Errorf("Version = %v, want %v", result.Version, tt.wantVersion)
 			}
 		})
 	}
 }
+
+func TestPackageJsonDetector_GetPriority(t *testing.T) {
+	detector := &PackageJsonDetector{releasesClient: newMockReleasesClient()}
+
+	priority := detector.GetPriority()
+	if priority != 5 {
+		t.Errorf("GetPriority() = %d, want 5", priority)
+	}
+}
+
+func TestPackageJsonDetector_VoltaExtends(t *testing.T) {
+	detector := &PackageJsonDetector{releasesClient: newMockReleasesClient()}
+	root := t.TempDir()
+	project := filepath.Join(root, "packages", "app")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", project, err)
+	}
+
+	files := map[string]string{
+		filepath.Join(root, "package.json"):    "{\n  \"volta\": {\n    \"node\": \"20.11.0\"\n  }\n}\n",
+		filepath.Join(project, "package.json"): `{"volta": {"extends": "../../package.json"}}`,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	result, err := detector.Detect(project)
+	if err != nil || !result.Found {
+		t.Fatalf("Detect() = %+v, %v", result, err)
+	}
+	wantSource := filepath.Join(root, "package.json")
+	if result.Version != "20.11.0" || result.Source != wantSource || result.Line != 3 {
+		t.Errorf("Detect() = %+v, want 20.11.0 at %s:3", result, wantSource)
+	}
+
+	// A cycle is reported instead of looping forever
+	cycle := `{"volta": {"extends": "./package.json"}}`
+	if err := os.WriteFile(filepath.Join(project, "package.json"), []byte(cycle), 0644); err != nil {
+		t.Fatalf("failed to write package.json: %v", err)
+	}
+	if _, err := detector.Detect(project); err == nil {
+		t.Error("expected error for a volta.extends cycle")
+	}
+}
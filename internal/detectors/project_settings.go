@@ -0,0 +1,38 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// projectSettingsFile holds the settings; detectors that read it list it as a source file
+const projectSettingsFile = ".autonode.yml"
+
+// projectSettings are the .autonode.yml settings that tune how detectors read their sources
+type projectSettings struct {
+	// DockerStage is the Dockerfile stage to read the version from (empty = last node stage)
+	DockerStage string `yaml:"dockerStage"`
+	// CIMatrix picks the entry of a CI version matrix: highest (default), lowest, first or last
+	CIMatrix string `yaml:"ciMatrix"`
+}
+
+// readProjectSettings reads the settings from .autonode.yml in projectPath
+// A missing or unreadable file means no settings: .autonode.yml errors are reported by its own detectors
+func readProjectSettings(projectPath string) projectSettings {
+	var settings projectSettings
+
+	data, err := os.ReadFile(filepath.Join(projectPath, projectSettingsFile))
+	if err != nil {
+		return settings
+	}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return projectSettings{}
+	}
+
+	settings.DockerStage = strings.TrimSpace(settings.DockerStage)
+	settings.CIMatrix = strings.ToLower(strings.TrimSpace(settings.CIMatrix))
+	return settings
+}
@@ -0,0 +1,10 @@
+package detectors
+
+import "github.com/matutetandil/autonode/internal/core"
+
+// releasesClient interface for dependency injection
+// This allows us to mock the client in tests
+// Implemented by core.NodeReleasesClient
+type releasesClient interface {
+	GetReleaseIndex() (core.ReleaseIndex, error)
+}
@@ -0,0 +1,13 @@
+package detectors
+
+import "path/filepath"
+
+// sourceFiles joins file names (slash-separated, relative to projectPath) into the paths
+// a detector lists for the detection cache (see core.SourceFileLister)
+func sourceFiles(projectPath string, names ...string) []string {
+	files := make([]string, len(names))
+	for i, name := range names {
+		files[i] = filepath.Join(projectPath, filepath.FromSlash(name))
+	}
+	return files
+}
@@ -0,0 +1,57 @@
+package detectors
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+func TestSourceFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeProjectFiles(t, tmpDir, map[string]string{
+		".github/workflows/release.yaml": "jobs: {}\n",
+		".github/workflows/ci.yml":       "jobs: {}\n",
+	})
+
+	tests := []struct {
+		name   string
+		lister core.SourceFileLister
+		want   []string
+	}{
+		{
+			name:   "single file",
+			lister: NewNvmrcDetector(),
+			want:   []string{".nvmrc"},
+		},
+		{
+			name:   "candidate files",
+			lister: NewMiseTomlDetector(),
+			want:   []string{"mise.toml", ".mise.toml"},
+		},
+		{
+			name:   "settings tune the detector",
+			lister: &DockerfileDetector{releasesClient: newMockReleasesClient()},
+			want:   []string{"Dockerfile", ".autonode.yml"},
+		},
+		{
+			name:   "workflows directory and files",
+			lister: newGitHubActionsDetector(newMockReleasesClient()),
+			want:   []string{".github/workflows", ".autonode.yml", ".github/workflows/ci.yml", ".github/workflows/release.yaml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := sourceFiles(tmpDir, tt.want...)
+			if got := tt.lister.SourceFiles(tmpDir); !reflect.DeepEqual(got, want) {
+				t.Errorf("SourceFiles() = %v, want %v", got, want)
+			}
+		})
+	}
+
+	if got, want := sourceFiles("/project", ".circleci/config.yml"), filepath.Join("/project", ".circleci", "config.yml"); got[0] != want {
+		t.Errorf("sourceFiles() = %v, want %s", got, want)
+	}
+}
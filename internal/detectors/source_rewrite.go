@@ -0,0 +1,119 @@
+package detectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// plainVersionPattern matches the version in single-value files such as .nvmrc and .node-version
+var plainVersionPattern = regexp.MustCompile(`^(\s*)(\S+)`)
+
+// lineAt returns the 1-based line number of the byte offset in content
+func lineAt(content []byte, offset int) int {
+	return bytes.Count(content[:offset], []byte("\n")) + 1
+}
+
+// firstContentLine returns the 1-based line of the first non-blank character in content
+func firstContentLine(content []byte) int {
+	return lineAt(content, len(content)-len(bytes.TrimLeft(content, " \t\r\n")))
+}
+
+// jsonKeyLine returns the 1-based line of a key in a JSON document, following path
+// through nested objects (array elements are addressed by index, e.g. "0").
+// Returns 0 if the key is not found before the end of the document or a syntax error.
+func jsonKeyLine(content []byte, path ...string) int {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+
+	// One frame per open object or array: the key (or index) of the value being read
+	type frame struct {
+		object  bool
+		key     string
+		index   int
+		wantKey bool
+	}
+	var stack []*frame
+
+	// valueDone marks the end of a value, so the enclosing object expects a key next
+	valueDone := func() {
+		if len(stack) > 0 && stack[len(stack)-1].object {
+			stack[len(stack)-1].wantKey = true
+		}
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return 0
+		}
+
+		var top *frame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			valueDone()
+			continue
+		}
+
+		if top != nil && top.object && top.wantKey {
+			top.key, top.wantKey = token.(string), false
+			if len(stack) == len(path) {
+				matches := true
+				for i, f := range stack {
+					matches = matches && f.key == path[i]
+				}
+				if matches {
+					return lineAt(content, int(decoder.InputOffset()))
+				}
+			}
+			continue
+		}
+
+		if top != nil && !top.object {
+			top.key = strconv.Itoa(top.index)
+			top.index++
+		}
+
+		if delim, ok := token.(json.Delim); ok {
+			stack = append(stack, &frame{object: delim == '{', wantKey: delim == '{'})
+			continue
+		}
+		valueDone()
+	}
+}
+
+// rewriteVersionOnLine replaces the version on a 1-based line of the file at path.
+// pattern must capture two groups: the text before the version and the version itself;
+// everything else on the line (quotes, comments, image variants) is kept as written.
+func rewriteVersionOnLine(path string, line int, pattern *regexp.Regexp, version string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	if line < 1 || line > len(lines) {
+		return fmt.Errorf("%s has no line %d", path, line)
+	}
+
+	text := lines[line-1]
+	loc := pattern.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return fmt.Errorf("no version found at %s:%d", path, line)
+	}
+	lines[line-1] = text[:loc[4]] + version + text[loc[5]:]
+
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")), info.Mode().Perm())
+}
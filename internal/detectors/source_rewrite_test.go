@@ -0,0 +1,234 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// fixableDetector is a version detector that can rewrite its source
+type fixableDetector interface {
+	core.VersionDetector
+	core.VersionSourceFixer
+}
+
+func TestDetectors_LineAndFixVersion(t *testing.T) {
+	mockClient := newMockReleasesClient()
+
+	tests := []struct {
+		name     string
+		detector fixableDetector
+		fileName string
+		content  string
+		wantLine int
+		want     string
+	}{
+		{
+			name:     ".nvmrc",
+			detector: NewNvmrcDetector(),
+			fileName: ".nvmrc",
+			content:  "\n18\n",
+			wantLine: 2,
+			want:     "\n20.11.0\n",
+		},
+		{
+			name:     ".node-version",
+			detector: NewNodeVersionDetector(),
+			fileName: ".node-version",
+			content:  "v18.17.0\n",
+			wantLine: 1,
+			want:     "20.11.0\n",
+		},
+		{
+			name:     ".autonode.yml",
+			detector: NewAutonodeYmlVersionDetector(),
+			fileName: ".autonode.yml",
+			content:  "npmProfile: work\nnodeVersion: \"18\" # pinned\n",
+			wantLine: 2,
+			want:     "npmProfile: work\nnodeVersion: \"20.11.0\" # pinned\n",
+		},
+		{
+			name:     "package.json",
+			detector: &PackageJsonDetector{releasesClient: mockClient},
+			fileName: "package.json",
+			content:  "{\n  \"name\": \"app\",\n  \"engines\": {\n    \"npm\": \">=9\",\n    \"node\": \">=18 <19\"\n  }\n}\n",
+			wantLine: 5,
+			want:     "{\n  \"name\": \"app\",\n  \"engines\": {\n    \"npm\": \">=9\",\n    \"node\": \"20.11.0\"\n  }\n}\n",
+		},
+		{
+			name:     "package.json volta",
+			detector: &PackageJsonDetector{releasesClient: mockClient},
+			fileName: "package.json",
+			content:  "{\n  \"engines\": { \"node\": \">=16\" },\n  \"volta\": {\n    \"node\": \"18.17.0\"\n  }\n}\n",
+			wantLine: 4,
+			want:     "{\n  \"engines\": { \"node\": \">=16\" },\n  \"volta\": {\n    \"node\": \"20.11.0\"\n  }\n}\n",
+		},
+		{
+			name:     "package.json devEngines",
+			detector: &PackageJsonDetector{releasesClient: mockClient},
+			fileName: "package.json",
+			content:  "{\n  \"devEngines\": {\n    \"runtime\": { \"name\": \"node\", \"version\": \"^18\" }\n  }\n}\n",
+			wantLine: 3,
+			want:     "{\n  \"devEngines\": {\n    \"runtime\": { \"name\": \"node\", \"version\": \"20.11.0\" }\n  }\n}\n",
+		},
+		{
+			name:     "Dockerfile",
+			detector: &DockerfileDetector{releasesClient: mockClient},
+			fileName: "Dockerfile",
+			content:  "# build\nFROM node:18-alpine AS build\nRUN npm ci\n",
+			wantLine: 2,
+			want:     "# build\nFROM node:20.11.0-alpine AS build\nRUN npm ci\n",
+		},
+		{
+			name:     "Dockerfile ARG",
+			detector: &DockerfileDetector{releasesClient: mockClient},
+			fileName: "Dockerfile",
+			content:  "ARG NODE_VERSION=18\nFROM --platform=$BUILDPLATFORM node:${NODE_VERSION}-alpine\n",
+			wantLine: 1,
+			want:     "ARG NODE_VERSION=20.11.0\nFROM --platform=$BUILDPLATFORM node:${NODE_VERSION}-alpine\n",
+		},
+		{
+			name:     "Dockerfile registry image",
+			detector: &DockerfileDetector{releasesClient: mockClient},
+			fileName: "Dockerfile",
+			content:  "FROM --platform=linux/amd64 docker.io/library/node:18-slim\n",
+			wantLine: 1,
+			want:     "FROM --platform=linux/amd64 docker.io/library/node:20.11.0-slim\n",
+		},
+		{
+			name:     "docker-compose",
+			detector: &DockerComposeDetector{releasesClient: mockClient},
+			fileName: "docker-compose.yml",
+			content:  "services:\n  web:\n    image: \"node:18-alpine\"\n",
+			wantLine: 3,
+			want:     "services:\n  web:\n    image: \"node:20.11.0-alpine\"\n",
+		},
+		{
+			name:     "devcontainer.json",
+			detector: &DevcontainerDetector{releasesClient: mockClient},
+			fileName: ".devcontainer.json",
+			content:  "{\n  \"features\": {\n    \"ghcr.io/devcontainers/features/node:1\": { \"version\": \"18\" }\n  }\n}\n",
+			wantLine: 3,
+			want:     "{\n  \"features\": {\n    \"ghcr.io/devcontainers/features/node:1\": { \"version\": \"20.11.0\" }\n  }\n}\n",
+		},
+		{
+			name:     "GitHub Actions",
+			detector: newGitHubActionsDetector(mockClient),
+			fileName: ".github/workflows/ci.yml",
+			content:  "jobs:\n  test:\n    strategy:\n      matrix:\n        node:\n          - 16\n          - '18.x'\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version: ${{ matrix.node }}\n",
+			wantLine: 7,
+			want:     "jobs:\n  test:\n    strategy:\n      matrix:\n        node:\n          - 16\n          - '20.11.0'\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version: ${{ matrix.node }}\n",
+		},
+		{
+			name:     ".gitlab-ci.yml",
+			detector: &GitLabCIDetector{releasesClient: mockClient},
+			fileName: ".gitlab-ci.yml",
+			content:  "variables:\n  NODE_VERSION: \"18\"\ntest:\n  image: node:${NODE_VERSION}-alpine\n",
+			wantLine: 2,
+			want:     "variables:\n  NODE_VERSION: \"20.11.0\"\ntest:\n  image: node:${NODE_VERSION}-alpine\n",
+		},
+		{
+			name:     ".circleci/config.yml",
+			detector: &CircleCIDetector{releasesClient: mockClient},
+			fileName: ".circleci/config.yml",
+			content:  "jobs:\n  build:\n    docker:\n      - image: cimg/node:18.17-browsers\n",
+			wantLine: 4,
+			want:     "jobs:\n  build:\n    docker:\n      - image: cimg/node:20.11.0-browsers\n",
+		},
+		{
+			name:     "mise.toml",
+			detector: NewMiseTomlDetector(),
+			fileName: "mise.toml",
+			content:  "[env]\nFOO = \"1\"\n\n[tools]\nnode = [\"18\", \"16\"]\n",
+			wantLine: 5,
+			want:     "[env]\nFOO = \"1\"\n\n[tools]\nnode = [\"20.11.0\", \"16\"]\n",
+		},
+		{
+			name:     ".tool-versions",
+			detector: NewToolVersionsDetector(),
+			fileName: ".tool-versions",
+			content:  "python 3.12.0\nnodejs 18.17.0 16.20.0 # legacy\n",
+			wantLine: 2,
+			want:     "python 3.12.0\nnodejs 20.11.0 16.20.0 # legacy\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			path := filepath.Join(tmpDir, filepath.FromSlash(tt.fileName))
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatalf("Failed to create directory for %s: %v", tt.fileName, err)
+			}
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write %s: %v", tt.fileName, err)
+			}
+
+			result, err := tt.detector.Detect(tmpDir)
+			if err != nil || !result.Found {
+				t.Fatalf("Detect() = %+v, %v", result, err)
+			}
+			if result.Line != tt.wantLine {
+				t.Errorf("Detect() Line = %d, want %d", result.Line, tt.wantLine)
+			}
+
+			if err := tt.detector.FixVersion(result, "20.11.0"); err != nil {
+				t.Fatalf("FixVersion() error = %v", err)
+			}
+
+			got, _ := os.ReadFile(path)
+			if string(got) != tt.want {
+				t.Errorf("FixVersion() wrote %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteVersionOnLine_Errors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".nvmrc")
+	if err := os.WriteFile(path, []byte("18\n"), 0644); err != nil {
+		t.Fatalf("Failed to write .nvmrc: %v", err)
+	}
+
+	if err := rewriteVersionOnLine(path, 5, plainVersionPattern, "20"); err == nil {
+		t.Error("Expected error for a line past the end of the file")
+	}
+	if err := rewriteVersionOnLine(path, 2, plainVersionPattern, "20"); err == nil {
+		t.Error("Expected error for a line without a version")
+	}
+}
+
+func TestJsonKeyLine(t *testing.T) {
+	content := []byte(`{
+  "name": "app",
+  "engines": { "npm": ">=9", "node": ">=18" },
+  "devEngines": {
+    "runtime": [
+      { "name": "bun" },
+      {
+        "name": "node",
+        "version": "20"
+      }
+    ]
+  }
+}`)
+
+	tests := []struct {
+		path []string
+		want int
+	}{
+		{[]string{"name"}, 2},
+		{[]string{"engines", "node"}, 3},
+		{[]string{"devEngines", "runtime", "1", "version"}, 9},
+		{[]string{"devEngines", "runtime", "0", "version"}, 0},
+		{[]string{"node"}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := jsonKeyLine(content, tt.path...); got != tt.want {
+			t.Errorf("jsonKeyLine(%v) = %d, want %d", tt.path, got, tt.want)
+		}
+	}
+}
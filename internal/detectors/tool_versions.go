@@ -0,0 +1,92 @@
+package detectors
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// ToolVersionsDetector detects Node.js version from the asdf/mise .tool-versions file
+// Single Responsibility Principle: Only responsible for detecting version from .tool-versions
+// Open/Closed Principle: Implements VersionDetector interface
+// Liskov Substitution Principle: Can be used anywhere a VersionDetector is expected
+type ToolVersionsDetector struct{}
+
+// toolVersionsNodePattern matches the preferred (first) version on the nodejs line
+var toolVersionsNodePattern = regexp.MustCompile(`^(\s*(?:nodejs|node)\s+)(\S+)`)
+
+// NewToolVersionsDetector creates a new ToolVersionsDetector instance
+func NewToolVersionsDetector() *ToolVersionsDetector {
+	return &ToolVersionsDetector{}
+}
+
+// Detect reads the .tool-versions file and returns the version from the nodejs line
+// Format: "nodejs 20.11.0" (mise also accepts "node"). When several versions are
+// listed ("nodejs 20.11.0 18.17.0") the first one is the preferred version.
+func (d *ToolVersionsDetector) Detect(projectPath string) (core.DetectionResult, error) {
+	toolVersionsPath := filepath.Join(projectPath, ".tool-versions")
+
+	// Check if file exists
+	file, err := os.Open(toolVersionsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return core.DetectionResult{Found: false}, nil
+		}
+		return core.DetectionResult{Found: false}, err
+	}
+	defer file.Close()
+
+	lineNumber := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+
+		// Strip comments (whole-line and trailing)
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 || (fields[0] != "nodejs" && fields[0] != "node") {
+			continue
+		}
+
+		return core.DetectionResult{
+			Found:   true,
+			Version: fields[1],
+			Source:  toolVersionsPath,
+			Line:    lineNumber,
+		}, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return core.DetectionResult{Found: false}, err
+	}
+
+	return core.DetectionResult{Found: false}, nil
+}
+
+// FixVersion replaces the preferred nodejs version with version
+func (d *ToolVersionsDetector) FixVersion(result core.DetectionResult, version string) error {
+	return rewriteVersionOnLine(result.Source, result.Line, toolVersionsNodePattern, version)
+}
+
+// GetPriority returns the priority of this detector (4 = after mise.toml, before package.json)
+func (d *ToolVersionsDetector) GetPriority() int {
+	return 4
+}
+
+// GetSourceName returns the name of the version source
+func (d *ToolVersionsDetector) GetSourceName() string {
+	return ".tool-versions"
+}
+
+// SourceFiles returns the files Detect reads in projectPath
+func (d *ToolVersionsDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, ".tool-versions")
+}
@@ -0,0 +1,118 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestToolVersionsDetector_Detect(t *testing.T) {
+	detector := NewToolVersionsDetector()
+
+	tests := []struct {
+		name        string
+		fileContent string
+		wantFound   bool
+		wantVersion string
+	}{
+		{
+			name:        "nodejs line",
+			fileContent: "nodejs 20.11.0",
+			wantFound:   true,
+			wantVersion: "20.11.0",
+		},
+		{
+			name:        "mise node alias",
+			fileContent: "node 18.17.0",
+			wantFound:   true,
+			wantVersion: "18.17.0",
+		},
+		{
+			name: "among other tools",
+			fileContent: `python 3.12.1
+nodejs 20.11.0
+ruby 3.3.0`,
+			wantFound:   true,
+			wantVersion: "20.11.0",
+		},
+		{
+			name:        "multiple versions uses first",
+			fileContent: "nodejs 20.11.0 18.17.0",
+			wantFound:   true,
+			wantVersion: "20.11.0",
+		},
+		{
+			name: "comments ignored",
+			fileContent: `# nodejs 16.0.0
+nodejs 22.1.0 # current LTS`,
+			wantFound:   true,
+			wantVersion: "22.1.0",
+		},
+		{
+			name:        "no nodejs entry",
+			fileContent: "python 3.12.1",
+			wantFound:   false,
+		},
+		{
+			name:        "nodejs without version",
+			fileContent: "nodejs",
+			wantFound:   false,
+		},
+		{
+			name:        "empty file",
+			fileContent: "",
+			wantFound:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			filePath := filepath.Join(tmpDir, ".tool-versions")
+
+			err := os.WriteFile(filePath, []byte(tt.fileContent), 0644)
+			if err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			result, err := detector.Detect(tmpDir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Found != tt.wantFound {
+				t.Errorf("Found = %v, want %v", result.Found, tt.wantFound)
+			}
+
+			if tt.wantFound {
+				if result.Version != tt.wantVersion {
+					t.Errorf("Version = %v, want %v", result.Version, tt.wantVersion)
+				}
+				if result.Source != filePath {
+					t.Errorf("Source = %v, want %v", result.Source, filePath)
+				}
+			}
+		})
+	}
+}
+
+func TestToolVersionsDetector_NoFile(t *testing.T) {
+	detector := NewToolVersionsDetector()
+
+	result, err := detector.Detect(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Found {
+		t.Errorf("Found = true, want false when file doesn't exist")
+	}
+}
+
+func TestToolVersionsDetector_GetPriority(t *testing.T) {
+	detector := NewToolVersionsDetector()
+
+	if priority := detector.GetPriority(); priority != 4 {
+		t.Errorf("GetPriority() = %d, want 4", priority)
+	}
+}
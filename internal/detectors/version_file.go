@@ -0,0 +1,39 @@
+package detectors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseVersionFile reads a single-value version file (.nvmrc, .node-version) the way nvm
+// reads .nvmrc: "#" starts a comment, lines are trimmed and blank ones skipped, and
+// "key=value" lines are reserved for settings and ignored. The one remaining line is
+// the version; more than one is an error, and none means the file names no version.
+// Returns the version and its 1-based line.
+func parseVersionFile(content []byte) (string, int, error) {
+	version, line := "", 0
+
+	for i, text := range strings.Split(string(content), "\n") {
+		if comment := strings.Index(text, "#"); comment >= 0 {
+			text = text[:comment]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		if key, _, isSetting := strings.Cut(text, "="); isSetting {
+			if strings.TrimSpace(key) == "node" {
+				return "", 0, fmt.Errorf("line %d: the version must be on its own line, not set with node=", i+1)
+			}
+			continue
+		}
+
+		if version != "" {
+			return "", 0, fmt.Errorf("line %d: more than one version (%s on line %d, %s)", i+1, version, line, text)
+		}
+		version, line = text, i+1
+	}
+
+	return version, line, nil
+}
@@ -0,0 +1,79 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/matutetandil/autonode/internal/core"
+	"gopkg.in/yaml.v3"
+)
+
+// YarnrcPackageManagerDetector detects the pinned Yarn version from the yarnPath
+// setting of .yarnrc.yml (e.g. ".yarn/releases/yarn-4.1.1.cjs").
+//
+// This detector adheres to:
+// - Single Responsibility Principle (SRP): Only handles .yarnrc.yml yarnPath detection
+// - Open/Closed Principle (OCP): Part of an extensible detection system
+// - Liskov Substitution Principle (LSP): Implements PackageManagerDetector interface
+type YarnrcPackageManagerDetector struct{}
+
+// yarnrcConfig represents the structure of .yarnrc.yml we care about
+type yarnrcConfig struct {
+	YarnPath string `yaml:"yarnPath"`
+}
+
+// yarnReleaseFile matches the version in the name of a checked-in Yarn release
+var yarnReleaseFile = regexp.MustCompile(`^yarn-(\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?)\.c?js$`)
+
+// NewYarnrcPackageManagerDetector creates a new YarnrcPackageManagerDetector instance.
+func NewYarnrcPackageManagerDetector() *YarnrcPackageManagerDetector {
+	return &YarnrcPackageManagerDetector{}
+}
+
+// Detect reads .yarnrc.yml in the project directory and extracts the Yarn version
+// from the file name of yarnPath. Release files without a version are ignored.
+func (d *YarnrcPackageManagerDetector) Detect(projectPath string) (core.PackageManagerDetectionResult, error) {
+	filePath := filepath.Join(projectPath, ".yarnrc.yml")
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return core.PackageManagerDetectionResult{Found: false}, nil
+		}
+		return core.PackageManagerDetectionResult{Found: false}, err
+	}
+
+	var config yarnrcConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return core.PackageManagerDetectionResult{Found: false}, err
+	}
+
+	matches := yarnReleaseFile.FindStringSubmatch(filepath.Base(filepath.FromSlash(config.YarnPath)))
+	if matches == nil {
+		return core.PackageManagerDetectionResult{Found: false}, nil
+	}
+
+	return core.PackageManagerDetectionResult{
+		Found:   true,
+		Name:    "yarn",
+		Version: matches[1],
+		Source:  filePath,
+	}, nil
+}
+
+// GetPriority returns the priority of this detector.
+// Priority 2 means the packageManager field of package.json is checked first.
+func (d *YarnrcPackageManagerDetector) GetPriority() int {
+	return 2
+}
+
+// GetSourceName returns a human-readable name of the source.
+func (d *YarnrcPackageManagerDetector) GetSourceName() string {
+	return ".yarnrc.yml"
+}
+
+// SourceFiles returns the files Detect reads in projectPath
+func (d *YarnrcPackageManagerDetector) SourceFiles(projectPath string) []string {
+	return sourceFiles(projectPath, ".yarnrc.yml")
+}
@@ -0,0 +1,80 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestYarnrcPackageManagerDetector_Detect(t *testing.T) {
+	detector := NewYarnrcPackageManagerDetector()
+
+	tests := []struct {
+		name        string
+		fileContent string
+		wantFound   bool
+		wantVersion string
+		wantErr     bool
+	}{
+		{
+			name:        "checked-in release",
+			fileContent: "nodeLinker: node-modules\nyarnPath: .yarn/releases/yarn-4.1.1.cjs\n",
+			wantFound:   true,
+			wantVersion: "4.1.1",
+		},
+		{
+			name:        "yarn 2 js release",
+			fileContent: "yarnPath: \".yarn/releases/yarn-2.4.3.js\"\n",
+			wantFound:   true,
+			wantVersion: "2.4.3",
+		},
+		{
+			name:        "prerelease",
+			fileContent: "yarnPath: .yarn/releases/yarn-4.0.0-rc.53.cjs\n",
+			wantFound:   true,
+			wantVersion: "4.0.0-rc.53",
+		},
+		{
+			name:        "release without version",
+			fileContent: "yarnPath: .yarn/releases/yarn-berry.cjs\n",
+			wantFound:   false,
+		},
+		{
+			name:        "no yarnPath",
+			fileContent: "nodeLinker: pnp\n",
+			wantFound:   false,
+		},
+		{
+			name:        "invalid YAML",
+			fileContent: "yarnPath: [\n",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			filePath := filepath.Join(tmpDir, ".yarnrc.yml")
+			if err := os.WriteFile(filePath, []byte(tt.fileContent), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			result, err := detector.Detect(tmpDir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Detect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result.Found != tt.wantFound {
+				t.Fatalf("Found = %v, want %v", result.Found, tt.wantFound)
+			}
+			if tt.wantFound && (result.Name != "yarn" || result.Version != tt.wantVersion || result.Source != filePath) {
+				t.Errorf("Detect() = %+v, want yarn@%s from %s", result, tt.wantVersion, filePath)
+			}
+		})
+	}
+}
+
+func TestYarnrcPackageManagerDetector_GetPriority(t *testing.T) {
+	if priority := NewYarnrcPackageManagerDetector().GetPriority(); priority != 2 {
+		t.Errorf("GetPriority() = %d, want 2 (after package.json packageManager)", priority)
+	}
+}
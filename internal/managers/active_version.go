@@ -0,0 +1,28 @@
+package managers
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// versionOnPath returns the version of the node found first on PATH when it lives in a
+// version directory below root (<root>/<version>/...), or empty string otherwise
+func versionOnPath(root string) string {
+	if root == "" {
+		return ""
+	}
+
+	node, err := exec.LookPath("node")
+	if err != nil {
+		return ""
+	}
+
+	rel, err := filepath.Rel(root, node)
+	if err != nil || !strings.Contains(filepath.ToSlash(rel), "/") || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+
+	version, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+	return strings.TrimPrefix(version, "v")
+}
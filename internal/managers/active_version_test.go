@@ -0,0 +1,100 @@
+package managers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// installNode creates an executable node at dir/node and returns dir
+func installNode(t *testing.T, dir string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "node"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("failed to write node: %v", err)
+	}
+	return dir
+}
+
+func TestVersionOnPath(t *testing.T) {
+	root := t.TempDir()
+	versionBin := installNode(t, filepath.Join(root, "versions", "v20.11.0", "x64", "bin"))
+	otherBin := installNode(t, filepath.Join(root, "usr", "bin"))
+
+	tests := []struct {
+		name string
+		path []string
+		want string
+	}{
+		{"version directory first", []string{versionBin, otherBin}, "20.11.0"},
+		{"other node first", []string{otherBin, versionBin}, ""},
+		{"no node", []string{filepath.Join(root, "empty")}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PATH", strings.Join(tt.path, string(os.PathListSeparator)))
+			if got := versionOnPath(filepath.Join(root, "versions")); got != tt.want {
+				t.Errorf("versionOnPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := versionOnPath(""); got != "" {
+		t.Errorf("versionOnPath(\"\") = %q, want empty", got)
+	}
+}
+
+func TestGetActiveVersion(t *testing.T) {
+	root := t.TempDir()
+
+	// fnm links its multishell directory to the installation of the active version
+	fnmInstall := filepath.Join(root, "fnm", "node-versions", "v22.12.0", "installation")
+	if err := os.MkdirAll(fnmInstall, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", fnmInstall, err)
+	}
+	multishell := filepath.Join(root, "fnm_multishells", "1234_5678")
+	if err := os.MkdirAll(filepath.Dir(multishell), 0755); err != nil {
+		t.Fatalf("failed to create multishells: %v", err)
+	}
+	if err := os.Symlink(fnmInstall, multishell); err != nil {
+		t.Fatalf("failed to link multishell: %v", err)
+	}
+
+	nvsBin := installNode(t, filepath.Join(root, "nvs", "node", "18.20.5", "x64", "bin"))
+	standaloneBin := installNode(t, filepath.Join(root, "autonode", "versions", "20.11.0", "bin"))
+	installNode(t, filepath.Join(root, "asdf", "installs", "nodejs", "20.11.0", "bin"))
+	asdfDir := filepath.Join(root, "asdf")
+
+	tests := []struct {
+		name    string
+		manager interface{ GetActiveVersion() string }
+		env     map[string]string
+		want    string
+	}{
+		{"nvm from NVM_BIN", NewNvmManager(&MockShell{}), map[string]string{"NVM_BIN": "/home/user/.nvm/versions/node/v20.11.0/bin"}, "20.11.0"},
+		{"nvm inactive", NewNvmManager(&MockShell{}), map[string]string{"NVM_BIN": ""}, ""},
+		{"nvs from PATH", NewNvsManager(&MockShell{}), map[string]string{"NVS_HOME": filepath.Join(root, "nvs"), "PATH": nvsBin}, "18.20.5"},
+		{"fnm from multishell link", NewFnmManager(&MockShell{}), map[string]string{"FNM_MULTISHELL_PATH": multishell}, "22.12.0"},
+		{"fnm without env", NewFnmManager(&MockShell{}), map[string]string{"FNM_MULTISHELL_PATH": ""}, ""},
+		{"asdf override", NewAsdfManager(&MockShell{}), map[string]string{"ASDF_DATA_DIR": asdfDir, "ASDF_NODEJS_VERSION": "20.11.0"}, "20.11.0"},
+		{"asdf partial override", NewAsdfManager(&MockShell{}), map[string]string{"ASDF_DATA_DIR": asdfDir, "ASDF_NODEJS_VERSION": "20"}, "20.11.0"},
+		{"asdf override not installed", NewAsdfManager(&MockShell{}), map[string]string{"ASDF_DATA_DIR": asdfDir, "ASDF_NODEJS_VERSION": "22.12.0"}, ""},
+		{"mise override", NewMiseManager(&MockShell{}), map[string]string{"MISE_NODE_VERSION": "22"}, "22"},
+		{"standalone from PATH", NewStandaloneManager(filepath.Join(root, "autonode", "versions"), "", nil), map[string]string{"PATH": standaloneBin}, "20.11.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			if got := tt.manager.GetActiveVersion(); got != tt.want {
+				t.Errorf("GetActiveVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
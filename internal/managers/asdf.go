@@ -0,0 +1,160 @@
+package managers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// AsdfManager manages Node.js versions using asdf and its nodejs plugin
+// Single Responsibility Principle: Only responsible for asdf operations
+// Dependency Inversion Principle: Depends on ShellExecutor abstraction
+// Open/Closed Principle: Implements VersionManager interface
+type AsdfManager struct {
+	shell core.ShellExecutor
+}
+
+// NewAsdfManager creates a new AsdfManager with injected ShellExecutor
+func NewAsdfManager(shell core.ShellExecutor) *AsdfManager {
+	return &AsdfManager{
+		shell: shell,
+	}
+}
+
+// GetName returns the name of this version manager
+func (m *AsdfManager) GetName() string {
+	return "asdf"
+}
+
+// IsInstalled checks if asdf is installed on the system
+func (m *AsdfManager) IsInstalled() bool {
+	return m.shell.CommandExists("asdf")
+}
+
+// ListInstalledVersions returns the versions reported by 'asdf list nodejs'
+func (m *AsdfManager) ListInstalledVersions() ([]string, error) {
+	output, err := m.shell.Execute("asdf", "list", "nodejs")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list asdf versions: %w", err)
+	}
+
+	// Format: "  18.17.0\n *20.11.0" - the active version is marked with an asterisk
+	var versions []string
+	for _, line := range strings.Split(output, "\n") {
+		version := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+		if version == "" || strings.HasPrefix(version, "No ") {
+			continue
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+// IsVersionInstalled checks if a specific Node.js version is installed via asdf
+func (m *AsdfManager) IsVersionInstalled(version string) (bool, error) {
+	installed, err := m.ListInstalledVersions()
+	if err != nil {
+		return false, err
+	}
+
+	return containsVersion(installed, version), nil
+}
+
+// InstallVersion installs a specific Node.js version using asdf
+// asdf needs exact versions, so partial versions ("20") are installed as "latest:20"
+func (m *AsdfManager) InstallVersion(version string) error {
+	normalizedVersion := normalizeAsdfVersion(version)
+	_, err := m.shell.Execute("asdf", "install", "nodejs", normalizedVersion)
+	if err != nil {
+		return fmt.Errorf("failed to install version %s: %w", normalizedVersion, err)
+	}
+	return nil
+}
+
+// UseVersion switches to a specific Node.js version using asdf
+// Like Volta, asdf pins the version to the project (.tool-versions in the current directory).
+// 'asdf set' is asdf 0.16+, 'asdf local' covers older releases.
+func (m *AsdfManager) UseVersion(version string) error {
+	resolvedVersion, err := m.resolveVersion(version)
+	if err != nil {
+		return err
+	}
+
+	command := fmt.Sprintf("asdf set nodejs %[1]s 2>/dev/null || asdf local nodejs %[1]s", resolvedVersion)
+	if _, err := m.shell.ExecuteInShell(command); err != nil {
+		return fmt.Errorf("failed to use version %s: %w", resolvedVersion, err)
+	}
+	return nil
+}
+
+// ExecArgs returns a command line that runs args with asdf's per-process version override
+func (m *AsdfManager) ExecArgs(version string, args []string) ([]string, error) {
+	resolvedVersion, err := m.resolveVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{"env", "ASDF_NODEJS_VERSION=" + resolvedVersion}, args...), nil
+}
+
+// resolveVersion returns the installed version asdf needs for version: itself when exact,
+// the highest installed match for partial versions ("20") and ranges.
+// Fails when 'asdf list nodejs' has no match.
+func (m *AsdfManager) resolveVersion(version string) (string, error) {
+	installed, err := m.ListInstalledVersions()
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := resolveInstalledVersion(installed, version)
+	if err != nil {
+		return "", err
+	}
+	if !containsVersion(installed, resolved) {
+		return "", fmt.Errorf("Node.js %s is not installed", version)
+	}
+	return resolved, nil
+}
+
+// normalizeAsdfVersion formats a version for 'asdf install'
+// Exact versions are used as-is, partial versions become "latest:<prefix>"
+func normalizeAsdfVersion(version string) string {
+	version = strings.TrimPrefix(version, "v")
+	if core.IsExactVersion(version) {
+		return version
+	}
+	return "latest:" + version
+}
+
+// GetActiveVersion returns the installed version the per-shell override shell mode exports
+// (ASDF_NODEJS_VERSION) refers to, or empty string when it names none.
+// The installs directory is read instead of running asdf, since this runs on every prompt.
+func (m *AsdfManager) GetActiveVersion() string {
+	override := os.Getenv("ASDF_NODEJS_VERSION")
+	if override == "" {
+		return ""
+	}
+
+	installed, err := listVersionDirs(asdfInstallsDir())
+	if err != nil {
+		return ""
+	}
+	version, err := resolveInstalledVersion(installed, override)
+	if err != nil || !containsVersion(installed, version) {
+		return ""
+	}
+	return version
+}
+
+// asdfInstallsDir returns the directory asdf installs Node.js versions in
+func asdfInstallsDir() string {
+	dataDir := os.Getenv("ASDF_DATA_DIR")
+	if dataDir == "" {
+		home, _ := os.UserHomeDir()
+		dataDir = filepath.Join(home, ".asdf")
+	}
+	return filepath.Join(dataDir, "installs", "nodejs")
+}
@@ -0,0 +1,187 @@
+package managers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// Ensure AsdfManager implements the interfaces the service relies on
+var (
+	_ core.VersionManager         = (*AsdfManager)(nil)
+	_ core.InstalledVersionLister = (*AsdfManager)(nil)
+	_ core.ActiveVersionReader    = (*AsdfManager)(nil)
+)
+
+func TestAsdfManager_GetName(t *testing.T) {
+	manager := NewAsdfManager(&MockShell{})
+
+	if name := manager.GetName(); name != "asdf" {
+		t.Errorf("GetName() = %q, want %q", name, "asdf")
+	}
+}
+
+func TestAsdfManager_ListInstalledVersions(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{
+			name:   "active version marked",
+			output: "  18.17.0\n *20.11.0\n  22.12.0",
+			want:   []string{"18.17.0", "20.11.0", "22.12.0"},
+		},
+		{
+			name:   "nothing installed",
+			output: "  No versions installed",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shell := &MockShell{
+				ExecuteFunc: func(command string, args ...string) (string, error) {
+					if command == "asdf" && reflect.DeepEqual(args, []string{"list", "nodejs"}) {
+						return tt.output, nil
+					}
+					return "", fmt.Errorf("unexpected command: %s %v", command, args)
+				},
+			}
+
+			manager := NewAsdfManager(shell)
+			got, err := manager.ListInstalledVersions()
+			if err != nil {
+				t.Fatalf("ListInstalledVersions() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListInstalledVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAsdfManager_IsVersionInstalled(t *testing.T) {
+	shell := &MockShell{
+		ExecuteFunc: func(command string, args ...string) (string, error) {
+			return "  18.17.0\n *20.11.0", nil
+		},
+	}
+	manager := NewAsdfManager(shell)
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"20.11.0", true},
+		{"v18.17.0", true},
+		{"20", true},
+		{"22", false},
+	}
+
+	for _, tt := range tests {
+		got, err := manager.IsVersionInstalled(tt.version)
+		if err != nil {
+			t.Fatalf("IsVersionInstalled(%q) error = %v", tt.version, err)
+		}
+		if got != tt.want {
+			t.Errorf("IsVersionInstalled(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestAsdfManager_InstallVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    []string
+	}{
+		{"exact version", "v20.11.0", []string{"asdf", "install", "nodejs", "20.11.0"}},
+		{"partial version", "20", []string{"asdf", "install", "nodejs", "latest:20"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			shell := &MockShell{
+				ExecuteFunc: func(command string, args ...string) (string, error) {
+					gotArgs = append([]string{command}, args...)
+					return "", nil
+				},
+			}
+
+			manager := NewAsdfManager(shell)
+			if err := manager.InstallVersion(tt.version); err != nil {
+				t.Fatalf("InstallVersion() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(gotArgs, tt.want) {
+				t.Errorf("executed %v, want %v", gotArgs, tt.want)
+			}
+		})
+	}
+}
+
+func TestAsdfManager_UseVersion(t *testing.T) {
+	t.Run("partial version resolved to latest installed", func(t *testing.T) {
+		var gotCommand string
+		shell := &MockShell{
+			ExecuteFunc: func(command string, args ...string) (string, error) {
+				if command == "asdf" && reflect.DeepEqual(args, []string{"list", "nodejs"}) {
+					return "  18.17.0\n  20.5.0\n *20.11.0\n", nil
+				}
+				return "", fmt.Errorf("unexpected command: %s %v", command, args)
+			},
+			ExecuteInShellFunc: func(command string) (string, error) {
+				gotCommand = command
+				return "", nil
+			},
+		}
+
+		manager := NewAsdfManager(shell)
+		if err := manager.UseVersion("20"); err != nil {
+			t.Fatalf("UseVersion() error = %v", err)
+		}
+
+		if !strings.Contains(gotCommand, "asdf set nodejs 20.11.0") {
+			t.Errorf("command = %q, want asdf set nodejs 20.11.0", gotCommand)
+		}
+	})
+
+	t.Run("version not installed", func(t *testing.T) {
+		shell := &MockShell{
+			ExecuteFunc: func(command string, args ...string) (string, error) {
+				return "  18.17.0\n", nil
+			},
+			ExecuteInShellFunc: func(command string) (string, error) {
+				t.Errorf("unexpected command %q", command)
+				return "", nil
+			},
+		}
+
+		manager := NewAsdfManager(shell)
+		for _, version := range []string{"20", "20.11.0"} {
+			if err := manager.UseVersion(version); err == nil {
+				t.Errorf("UseVersion(%q) expected error", version)
+			}
+		}
+	})
+
+	t.Run("resolution fails", func(t *testing.T) {
+		shell := &MockShell{
+			ExecuteFunc: func(command string, args ...string) (string, error) {
+				return "", fmt.Errorf("plugin not installed")
+			},
+		}
+
+		manager := NewAsdfManager(shell)
+		if err := manager.UseVersion("20"); err == nil {
+			t.Error("UseVersion() expected error")
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package managers
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// Ensure every external manager can run commands for `autonode exec`
+var (
+	_ core.VersionExecutor = (*NvmManager)(nil)
+	_ core.VersionExecutor = (*NvsManager)(nil)
+	_ core.VersionExecutor = (*VoltaManager)(nil)
+	_ core.VersionExecutor = (*FnmManager)(nil)
+	_ core.VersionExecutor = (*AsdfManager)(nil)
+	_ core.VersionExecutor = (*MiseManager)(nil)
+)
+
+func TestManagers_ExecArgs(t *testing.T) {
+	t.Setenv("NVM_DIR", "/opt/nvm")
+	t.Setenv("NVS_HOME", "/opt/nvs")
+
+	shell := &MockShell{
+		ExecuteFunc: func(command string, args ...string) (string, error) {
+			// asdf resolves partial versions against `asdf list nodejs`
+			return "20.11.1", nil
+		},
+	}
+	args := []string{"npm", "test", "--", "--watch"}
+
+	tests := []struct {
+		name     string
+		executor core.VersionExecutor
+		want     []string
+	}{
+		{
+			name:     "nvm",
+			executor: NewNvmManager(shell),
+			want:     []string{"bash", "-c", `. "$0" --no-use && nvm exec --silent "$@"`, filepath.Join("/opt/nvm", "nvm.sh"), "20", "npm", "test", "--", "--watch"},
+		},
+		{
+			name:     "nvs",
+			executor: NewNvsManager(shell),
+			want:     []string{"bash", "-c", `. "$0" >/dev/null && nvs exec "$@"`, filepath.Join("/opt/nvs", "nvs.sh"), "20", "npm", "test", "--", "--watch"},
+		},
+		{
+			name:     "volta",
+			executor: NewVoltaManager(shell),
+			want:     []string{"volta", "run", "--node", "20", "npm", "test", "--", "--watch"},
+		},
+		{
+			name:     "fnm",
+			executor: NewFnmManager(shell),
+			want:     []string{"fnm", "exec", "--using=20", "--", "npm", "test", "--", "--watch"},
+		},
+		{
+			name:     "asdf",
+			executor: NewAsdfManager(shell),
+			want:     []string{"env", "ASDF_NODEJS_VERSION=20.11.1", "npm", "test", "--", "--watch"},
+		},
+		{
+			name:     "mise",
+			executor: NewMiseManager(shell),
+			want:     []string{"mise", "exec", "node@20", "--", "npm", "test", "--", "--watch"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.executor.ExecArgs("v20", args)
+			if err != nil {
+				t.Fatalf("ExecArgs() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExecArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package managers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// FnmManager manages Node.js versions using fnm (Fast Node Manager)
+// Single Responsibility Principle: Only responsible for fnm operations
+// Dependency Inversion Principle: Depends on ShellExecutor abstraction
+// Open/Closed Principle: Implements VersionManager interface
+type FnmManager struct {
+	shell core.ShellExecutor
+}
+
+// NewFnmManager creates a new FnmManager with injected ShellExecutor
+func NewFnmManager(shell core.ShellExecutor) *FnmManager {
+	return &FnmManager{
+		shell: shell,
+	}
+}
+
+// GetName returns the name of this version manager
+func (m *FnmManager) GetName() string {
+	return "fnm"
+}
+
+// IsInstalled checks if fnm is installed on the system
+// fnm is a standalone binary, so we look for it in PATH
+func (m *FnmManager) IsInstalled() bool {
+	return m.shell.CommandExists("fnm")
+}
+
+// ListInstalledVersions returns the versions reported by 'fnm list'
+func (m *FnmManager) ListInstalledVersions() ([]string, error) {
+	output, err := m.shell.Execute("fnm", "list")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fnm versions: %w", err)
+	}
+
+	return parseFnmList(output), nil
+}
+
+// IsVersionInstalled checks if a specific Node.js version is installed via fnm
+// Partial versions ("20", "20.11") match any installed version in that line
+func (m *FnmManager) IsVersionInstalled(version string) (bool, error) {
+	installed, err := m.ListInstalledVersions()
+	if err != nil {
+		return false, err
+	}
+
+	return containsVersion(installed, normalizeFnmVersion(version)), nil
+}
+
+// InstallVersion installs a specific Node.js version using fnm
+func (m *FnmManager) InstallVersion(version string) error {
+	normalizedVersion := normalizeFnmVersion(version)
+	_, err := m.shell.Execute("fnm", "install", normalizedVersion)
+	if err != nil {
+		return fmt.Errorf("failed to install version %s: %w", normalizedVersion, err)
+	}
+	return nil
+}
+
+// UseVersion switches to a specific Node.js version using fnm
+// 'fnm use' needs the environment set up by 'fnm env', so both run in the same shell
+func (m *FnmManager) UseVersion(version string) error {
+	normalizedVersion := normalizeFnmVersion(version)
+	command := fmt.Sprintf(`eval "$(fnm env --shell bash)" && fnm use %s`, normalizedVersion)
+	_, err := m.shell.ExecuteInShell(command)
+	if err != nil {
+		return fmt.Errorf("failed to use version %s: %w", normalizedVersion, err)
+	}
+	return nil
+}
+
+// ExecArgs returns a command line that runs args under version with 'fnm exec'
+func (m *FnmManager) ExecArgs(version string, args []string) ([]string, error) {
+	return append([]string{"fnm", "exec", "--using=" + normalizeFnmVersion(version), "--"}, args...), nil
+}
+
+// parseFnmList extracts versions from 'fnm list' output
+// Format: "* v20.11.0 default" - one version per line, "system" entry is skipped
+func parseFnmList(output string) []string {
+	var versions []string
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] == "system" {
+			continue
+		}
+		versions = append(versions, normalizeFnmVersion(fields[0]))
+	}
+
+	return versions
+}
+
+// normalizeFnmVersion ensures version has consistent format for fnm
+// fnm accepts versions with or without 'v' prefix; we compare without it
+func normalizeFnmVersion(version string) string {
+	return strings.TrimPrefix(version, "v")
+}
+
+// GetActiveVersion returns the version fnm activated in the calling shell: the multishell
+// directory fnm puts on PATH links to <fnm dir>/node-versions/v20.11.0/installation
+func (m *FnmManager) GetActiveVersion() string {
+	multishell := os.Getenv("FNM_MULTISHELL_PATH")
+	if multishell == "" {
+		return ""
+	}
+
+	target, err := os.Readlink(multishell)
+	if err != nil {
+		return ""
+	}
+	if filepath.Base(target) == "installation" {
+		target = filepath.Dir(target)
+	}
+
+	// "fnm use system" links elsewhere
+	version := strings.TrimPrefix(filepath.Base(target), "v")
+	if !core.IsExactVersion(version) {
+		return ""
+	}
+	return version
+}
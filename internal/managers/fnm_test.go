@@ -0,0 +1,173 @@
+package managers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// Ensure FnmManager implements the interfaces the service relies on
+var (
+	_ core.VersionManager         = (*FnmManager)(nil)
+	_ core.InstalledVersionLister = (*FnmManager)(nil)
+	_ core.ActiveVersionReader    = (*FnmManager)(nil)
+)
+
+const fnmListOutput = `* v18.17.0
+* v20.11.0 default
+* v22.12.0
+* system`
+
+func TestFnmManager_GetName(t *testing.T) {
+	manager := NewFnmManager(&MockShell{})
+
+	if name := manager.GetName(); name != "fnm" {
+		t.Errorf("GetName() = %q, want %q", name, "fnm")
+	}
+}
+
+func TestFnmManager_IsInstalled(t *testing.T) {
+	tests := []struct {
+		name      string
+		available bool
+	}{
+		{"fnm in PATH", true},
+		{"fnm missing", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shell := &MockShell{
+				CommandExistsFunc: func(command string) bool {
+					return command == "fnm" && tt.available
+				},
+			}
+
+			manager := NewFnmManager(shell)
+			if got := manager.IsInstalled(); got != tt.available {
+				t.Errorf("IsInstalled() = %v, want %v", got, tt.available)
+			}
+		})
+	}
+}
+
+func TestFnmManager_ListInstalledVersions(t *testing.T) {
+	shell := &MockShell{
+		ExecuteFunc: func(command string, args ...string) (string, error) {
+			if command == "fnm" && len(args) == 1 && args[0] == "list" {
+				return fnmListOutput, nil
+			}
+			return "", fmt.Errorf("unexpected command: %s %v", command, args)
+		},
+	}
+
+	manager := NewFnmManager(shell)
+	got, err := manager.ListInstalledVersions()
+	if err != nil {
+		t.Fatalf("ListInstalledVersions() error = %v", err)
+	}
+
+	want := []string{"18.17.0", "20.11.0", "22.12.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListInstalledVersions() = %v, want %v", got, want)
+	}
+}
+
+func TestFnmManager_IsVersionInstalled(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		listError error
+		want      bool
+		wantError bool
+	}{
+		{name: "exact version", version: "20.11.0", want: true},
+		{name: "with v prefix", version: "v18.17.0", want: true},
+		{name: "major version", version: "22", want: true},
+		{name: "major.minor version", version: "20.11", want: true},
+		{name: "not installed", version: "16.20.0", want: false},
+		{name: "prefix is not a partial match", version: "2", want: false},
+		{name: "list fails", version: "20", listError: fmt.Errorf("boom"), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shell := &MockShell{
+				ExecuteFunc: func(command string, args ...string) (string, error) {
+					return fnmListOutput, tt.listError
+				},
+			}
+
+			manager := NewFnmManager(shell)
+			got, err := manager.IsVersionInstalled(tt.version)
+
+			if (err != nil) != tt.wantError {
+				t.Fatalf("IsVersionInstalled() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if got != tt.want {
+				t.Errorf("IsVersionInstalled(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFnmManager_InstallVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		executeError error
+		wantError    bool
+	}{
+		{"install succeeds", nil, false},
+		{"install fails", fmt.Errorf("network error"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			shell := &MockShell{
+				ExecuteFunc: func(command string, args ...string) (string, error) {
+					gotArgs = append([]string{command}, args...)
+					return "", tt.executeError
+				},
+			}
+
+			manager := NewFnmManager(shell)
+			err := manager.InstallVersion("v20.11.0")
+
+			if (err != nil) != tt.wantError {
+				t.Errorf("InstallVersion() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			want := []string{"fnm", "install", "20.11.0"}
+			if !reflect.DeepEqual(gotArgs, want) {
+				t.Errorf("executed %v, want %v", gotArgs, want)
+			}
+		})
+	}
+}
+
+func TestFnmManager_UseVersion(t *testing.T) {
+	var gotCommand string
+	shell := &MockShell{
+		ExecuteInShellFunc: func(command string) (string, error) {
+			gotCommand = command
+			return "", nil
+		},
+	}
+
+	manager := NewFnmManager(shell)
+	if err := manager.UseVersion("20"); err != nil {
+		t.Fatalf("UseVersion() error = %v", err)
+	}
+
+	if !strings.Contains(gotCommand, `eval "$(fnm env`) {
+		t.Errorf("command should set up fnm env, got: %s", gotCommand)
+	}
+	if !strings.HasSuffix(gotCommand, "fnm use 20") {
+		t.Errorf("command should end with 'fnm use 20', got: %s", gotCommand)
+	}
+}
@@ -0,0 +1,72 @@
+package managers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// listVersionDirs returns the names of the version directories found in dir
+// (e.g., "v20.11.0" -> "20.11.0"). A missing directory means nothing is installed.
+func listVersionDirs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		// Hidden directories are work in progress (e.g. a download being unpacked)
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			versions = append(versions, strings.TrimPrefix(entry.Name(), "v"))
+		}
+	}
+
+	return versions, nil
+}
+
+// containsVersion reports whether version is among installed versions
+// Partial versions ("20", "20.11") match any installed version in that line
+func containsVersion(installed []string, version string) bool {
+	version = strings.TrimPrefix(version, "v")
+	for _, v := range installed {
+		if v == version || strings.HasPrefix(v, version+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveInstalledVersion returns the installed version version refers to: itself when
+// exact, the highest installed match for partial versions and ranges. Aliases are left
+// to the manager's own commands and reported as not installed.
+func resolveInstalledVersion(installed []string, version string) (string, error) {
+	resolved := strings.TrimPrefix(normalizeVersion(version), "=")
+	if core.IsExactVersion(resolved) {
+		return resolved, nil
+	}
+
+	versionRange, err := core.ParseVersionRange(resolved)
+	if err != nil {
+		return "", fmt.Errorf("Node.js %s is not installed", version)
+	}
+	match, found := versionRange.MaxSatisfying(installed)
+	if !found {
+		return "", fmt.Errorf("Node.js %s is not installed", version)
+	}
+	return match, nil
+}
+
+// checkInstallDir returns dir if it holds the node binary of version, an error otherwise
+func checkInstallDir(dir, version string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, "bin", "node")); err != nil {
+		return "", fmt.Errorf("Node.js %s is not installed", version)
+	}
+	return dir, nil
+}
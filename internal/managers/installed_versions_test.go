@@ -0,0 +1,91 @@
+package managers
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// Ensure the managers that keep versions in a known layout locate them on disk
+var (
+	_ core.InstallDirResolver = (*NvmManager)(nil)
+	_ core.InstallDirResolver = (*NvsManager)(nil)
+	_ core.InstallDirResolver = (*VoltaManager)(nil)
+)
+
+func TestResolveInstallDir(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("NVM_DIR", filepath.Join(root, "nvm"))
+	t.Setenv("NVS_HOME", filepath.Join(root, "nvs"))
+	t.Setenv("VOLTA_HOME", filepath.Join(root, "volta"))
+
+	// Any use of the shell means the manager's own commands were run
+	shell := &MockShell{
+		ExecuteFunc: func(command string, args ...string) (string, error) {
+			t.Errorf("unexpected command %s %v", command, args)
+			return "", nil
+		},
+	}
+
+	managers := []struct {
+		manager interface {
+			core.VersionManager
+			core.InstallDirResolver
+		}
+		dir func(version string) string
+	}{
+		{NewNvmManager(shell), func(v string) string { return filepath.Join(root, "nvm", "versions", "node", "v"+v) }},
+		{NewNvsManager(shell), func(v string) string { return filepath.Join(root, "nvs", "node", v, nvsArch(runtime.GOARCH)) }},
+		{NewVoltaManager(shell), func(v string) string { return filepath.Join(root, "volta", "tools", "image", "node", v) }},
+	}
+
+	for _, m := range managers {
+		for _, version := range []string{"18.17.0", "20.11.0", "20.11.1"} {
+			bin := filepath.Join(m.dir(version), "bin")
+			if err := os.MkdirAll(bin, 0755); err != nil {
+				t.Fatalf("failed to create %s: %v", bin, err)
+			}
+			if err := os.WriteFile(filepath.Join(bin, "node"), nil, 0755); err != nil {
+				t.Fatalf("failed to write node: %v", err)
+			}
+		}
+
+		tests := []struct {
+			version string
+			want    string // empty when the version can't be located
+		}{
+			{"20.11.0", "20.11.0"},
+			{"v18.17.0", "18.17.0"},
+			{"20", "20.11.1"},
+			{">=18 <20", "18.17.0"},
+			{"22.12.0", ""},
+			{"lts/iron", ""},
+		}
+
+		for _, tt := range tests {
+			t.Run(m.manager.GetName()+"/"+tt.version, func(t *testing.T) {
+				dir, err := m.manager.ResolveInstallDir(tt.version)
+				if tt.want == "" {
+					if err == nil {
+						t.Errorf("ResolveInstallDir() = %q, want error", dir)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("ResolveInstallDir() error = %v", err)
+				}
+				if want := m.dir(tt.want); dir != want {
+					t.Errorf("ResolveInstallDir() = %q, want %q", dir, want)
+				}
+
+				installed, err := m.manager.IsVersionInstalled(tt.version)
+				if err != nil || !installed {
+					t.Errorf("IsVersionInstalled() = %v, %v, want true", installed, err)
+				}
+			})
+		}
+	}
+}
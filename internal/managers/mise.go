@@ -0,0 +1,108 @@
+package managers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// MiseManager manages Node.js versions using mise (formerly rtx)
+// Single Responsibility Principle: Only responsible for mise operations
+// Dependency Inversion Principle: Depends on ShellExecutor abstraction
+// Open/Closed Principle: Implements VersionManager interface
+type MiseManager struct {
+	shell core.ShellExecutor
+}
+
+// miseInstalledVersion is one entry of 'mise ls --json' output
+type miseInstalledVersion struct {
+	Version string `json:"version"`
+}
+
+// NewMiseManager creates a new MiseManager with injected ShellExecutor
+func NewMiseManager(shell core.ShellExecutor) *MiseManager {
+	return &MiseManager{
+		shell: shell,
+	}
+}
+
+// GetName returns the name of this version manager
+func (m *MiseManager) GetName() string {
+	return "mise"
+}
+
+// IsInstalled checks if mise is installed on the system
+func (m *MiseManager) IsInstalled() bool {
+	return m.shell.CommandExists("mise")
+}
+
+// ListInstalledVersions returns the versions reported by 'mise ls --installed --json node'
+func (m *MiseManager) ListInstalledVersions() ([]string, error) {
+	output, err := m.shell.Execute("mise", "ls", "--installed", "--json", "node")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mise versions: %w", err)
+	}
+
+	var entries []miseInstalledVersion
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse mise output: %w", err)
+	}
+
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		versions = append(versions, entry.Version)
+	}
+
+	return versions, nil
+}
+
+// IsVersionInstalled checks if a specific Node.js version is installed via mise
+func (m *MiseManager) IsVersionInstalled(version string) (bool, error) {
+	installed, err := m.ListInstalledVersions()
+	if err != nil {
+		return false, err
+	}
+
+	return containsVersion(installed, version), nil
+}
+
+// InstallVersion installs a specific Node.js version using mise
+// mise resolves partial versions ("20") to the latest matching release itself
+func (m *MiseManager) InstallVersion(version string) error {
+	normalizedVersion := normalizeMiseVersion(version)
+	_, err := m.shell.Execute("mise", "install", "node@"+normalizedVersion)
+	if err != nil {
+		return fmt.Errorf("failed to install version %s: %w", normalizedVersion, err)
+	}
+	return nil
+}
+
+// UseVersion switches to a specific Node.js version using mise
+// Like Volta, mise pins the version to the project (mise.toml in the current directory)
+func (m *MiseManager) UseVersion(version string) error {
+	normalizedVersion := normalizeMiseVersion(version)
+	_, err := m.shell.Execute("mise", "use", "node@"+normalizedVersion)
+	if err != nil {
+		return fmt.Errorf("failed to use version %s: %w", normalizedVersion, err)
+	}
+	return nil
+}
+
+// ExecArgs returns a command line that runs args under version with 'mise exec'
+func (m *MiseManager) ExecArgs(version string, args []string) ([]string, error) {
+	return append([]string{"mise", "exec", "node@" + normalizeMiseVersion(version), "--"}, args...), nil
+}
+
+// normalizeMiseVersion ensures version has consistent format for mise
+// mise expects versions without 'v' prefix
+func normalizeMiseVersion(version string) string {
+	return strings.TrimPrefix(version, "v")
+}
+
+// GetActiveVersion returns the per-shell override shell mode exports (MISE_NODE_VERSION)
+func (m *MiseManager) GetActiveVersion() string {
+	return os.Getenv("MISE_NODE_VERSION")
+}
@@ -0,0 +1,120 @@
+package managers
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// Ensure MiseManager implements the interfaces the service relies on
+var (
+	_ core.VersionManager         = (*MiseManager)(nil)
+	_ core.InstalledVersionLister = (*MiseManager)(nil)
+	_ core.ActiveVersionReader    = (*MiseManager)(nil)
+)
+
+const miseListOutput = `[
+  {"version": "20.11.0", "install_path": "/home/user/.local/share/mise/installs/node/20.11.0", "installed": true, "active": true},
+  {"version": "22.12.0", "install_path": "/home/user/.local/share/mise/installs/node/22.12.0", "installed": true, "active": false}
+]`
+
+func TestMiseManager_GetName(t *testing.T) {
+	manager := NewMiseManager(&MockShell{})
+
+	if name := manager.GetName(); name != "mise" {
+		t.Errorf("GetName() = %q, want %q", name, "mise")
+	}
+}
+
+func TestMiseManager_ListInstalledVersions(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		want      []string
+		wantError bool
+	}{
+		{name: "installed versions", output: miseListOutput, want: []string{"20.11.0", "22.12.0"}},
+		{name: "nothing installed", output: "[]", want: []string{}},
+		{name: "invalid output", output: "not json", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shell := &MockShell{
+				ExecuteFunc: func(command string, args ...string) (string, error) {
+					if command == "mise" && reflect.DeepEqual(args, []string{"ls", "--installed", "--json", "node"}) {
+						return tt.output, nil
+					}
+					return "", fmt.Errorf("unexpected command: %s %v", command, args)
+				},
+			}
+
+			manager := NewMiseManager(shell)
+			got, err := manager.ListInstalledVersions()
+
+			if (err != nil) != tt.wantError {
+				t.Fatalf("ListInstalledVersions() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if !tt.wantError && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListInstalledVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiseManager_IsVersionInstalled(t *testing.T) {
+	shell := &MockShell{
+		ExecuteFunc: func(command string, args ...string) (string, error) {
+			return miseListOutput, nil
+		},
+	}
+	manager := NewMiseManager(shell)
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"20.11.0", true},
+		{"22", true},
+		{"18", false},
+	}
+
+	for _, tt := range tests {
+		got, err := manager.IsVersionInstalled(tt.version)
+		if err != nil {
+			t.Fatalf("IsVersionInstalled(%q) error = %v", tt.version, err)
+		}
+		if got != tt.want {
+			t.Errorf("IsVersionInstalled(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestMiseManager_InstallAndUseVersion(t *testing.T) {
+	var executed [][]string
+	shell := &MockShell{
+		ExecuteFunc: func(command string, args ...string) (string, error) {
+			executed = append(executed, append([]string{command}, args...))
+			return "", nil
+		},
+	}
+
+	manager := NewMiseManager(shell)
+	if err := manager.InstallVersion("v20"); err != nil {
+		t.Fatalf("InstallVersion() error = %v", err)
+	}
+	if err := manager.UseVersion("20"); err != nil {
+		t.Fatalf("UseVersion() error = %v", err)
+	}
+
+	want := [][]string{
+		{"mise", "install", "node@20"},
+		{"mise", "use", "node@20"},
+	}
+	if !reflect.DeepEqual(executed, want) {
+		t.Errorf("executed %v, want %v", executed, want)
+	}
+}
@@ -0,0 +1,37 @@
+package managers
+
+import "github.com/matutetandil/autonode/internal/core"
+
+// MockShell is a mock implementation of core.ShellExecutor for testing
+type MockShell struct {
+	CommandExistsFunc  func(command string) bool
+	ExecuteFunc        func(command string, args ...string) (string, error)
+	ExecuteInShellFunc func(command string) (string, error)
+}
+
+// Ensure MockShell implements core.ShellExecutor
+var _ core.ShellExecutor = (*MockShell)(nil)
+
+// CommandExists calls the mock function
+func (m *MockShell) CommandExists(command string) bool {
+	if m.CommandExistsFunc != nil {
+		return m.CommandExistsFunc(command)
+	}
+	return false
+}
+
+// Execute calls the mock function
+func (m *MockShell) Execute(command string, args ...string) (string, error) {
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc(command, args...)
+	}
+	return "", nil
+}
+
+// ExecuteInShell calls the mock function, falling back to Execute
+func (m *MockShell) ExecuteInShell(command string) (string, error) {
+	if m.ExecuteInShellFunc != nil {
+		return m.ExecuteInShellFunc(command)
+	}
+	return m.Execute(command)
+}
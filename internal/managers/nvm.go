@@ -67,6 +67,21 @@ func (m *NvmManager) sourceNvm() string {
 
 // IsVersionInstalled checks if a specific Node.js version is installed via nvm
 func (m *NvmManager) IsVersionInstalled(version string) (bool, error) {
+	// 'nvm list' shows aliases even when nothing they point to is installed;
+	// 'nvm version' resolves them against installed versions instead
+	if core.IsVersionAlias(version) || m.SupportsAlias(version) {
+		output, err := m.shell.ExecuteInShell(m.sourceNvm() + fmt.Sprintf("nvm version %s", version))
+		if err != nil {
+			return false, fmt.Errorf("failed to resolve nvm alias %s: %w", version, err)
+		}
+		return strings.TrimSpace(output) != "N/A", nil
+	}
+
+	// Sourcing nvm.sh is slow: look on disk first
+	if _, err := m.ResolveInstallDir(version); err == nil {
+		return true, nil
+	}
+
 	// List installed versions (need to source nvm.sh first)
 	command := m.sourceNvm() + "nvm list"
 	output, err := m.shell.ExecuteInShell(command)
@@ -80,6 +95,27 @@ func (m *NvmManager) IsVersionInstalled(version string) (bool, error) {
 	return strings.Contains(output, normalizedVersion), nil
 }
 
+// ListInstalledVersions returns the versions installed under $NVM_DIR/versions/node
+func (m *NvmManager) ListInstalledVersions() ([]string, error) {
+	return listVersionDirs(filepath.Join(m.getNvmDir(), "versions", "node"))
+}
+
+// ResolveInstallDir returns $NVM_DIR/versions/node/v<version>, found on disk so shell mode
+// can switch without sourcing nvm.sh. Aliases are an error: 'nvm use' resolves those.
+func (m *NvmManager) ResolveInstallDir(version string) (string, error) {
+	installed, err := m.ListInstalledVersions()
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := resolveInstalledVersion(installed, version)
+	if err != nil {
+		return "", err
+	}
+
+	return checkInstallDir(filepath.Join(m.getNvmDir(), "versions", "node", "v"+resolved), version)
+}
+
 // InstallVersion installs a specific Node.js version using nvm
 func (m *NvmManager) InstallVersion(version string) error {
 	normalizedVersion := normalizeVersion(version)
@@ -102,8 +138,32 @@ func (m *NvmManager) UseVersion(version string) error {
 	return nil
 }
 
+// SupportsAlias reports whether nvm understands the alias: lts/*, lts/-N, lts/<codename>,
+// node, stable, unstable, iojs and system (bare codenames and "latest" are not nvm syntax)
+func (m *NvmManager) SupportsAlias(alias string) bool {
+	alias = strings.ToLower(alias)
+	switch alias {
+	case "node", "stable", "unstable", "iojs", "system":
+		return true
+	}
+	return strings.HasPrefix(alias, "lts/")
+}
+
+// ExecArgs returns a command line that runs args under version with 'nvm exec'
+// nvm is a shell function, so bash sources nvm.sh first (passed as $0 to avoid quoting issues)
+func (m *NvmManager) ExecArgs(version string, args []string) ([]string, error) {
+	nvmScript := filepath.Join(m.getNvmDir(), "nvm.sh")
+	script := `. "$0" --no-use && nvm exec --silent "$@"`
+	return append([]string{"bash", "-c", script, nvmScript, normalizeVersion(version)}, args...), nil
+}
+
 // normalizeVersion ensures version has consistent format
 // Examples: "18" -> "18", "v18.17.0" -> "18.17.0", "18.17.0" -> "18.17.0"
 func normalizeVersion(version string) string {
 	return strings.TrimPrefix(version, "v")
 }
+
+// GetActiveVersion returns the version nvm activated in the calling shell, read from $NVM_BIN
+func (m *NvmManager) GetActiveVersion() string {
+	return core.NvmBinVersion()
+}
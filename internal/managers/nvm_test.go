@@ -0,0 +1,69 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// Ensure NvmManager receives the aliases it understands untouched
+var _ core.VersionAliasSupporter = (*NvmManager)(nil)
+
+func TestNvmManager_SupportsAlias(t *testing.T) {
+	manager := NewNvmManager(&MockShell{})
+
+	tests := map[string]bool{
+		"lts/*":    true,
+		"lts/-1":   true,
+		"lts/Iron": true,
+		"node":     true,
+		"stable":   true,
+		"iojs":     true,
+		"iron":     false,
+		"latest":   false,
+	}
+
+	for alias, want := range tests {
+		if got := manager.SupportsAlias(alias); got != want {
+			t.Errorf("SupportsAlias(%q) = %v, want %v", alias, got, want)
+		}
+	}
+}
+
+func TestNvmManager_IsVersionInstalled_Alias(t *testing.T) {
+	t.Setenv("NVM_DIR", "/opt/nvm")
+
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{"installed", "v20.18.1\n", true},
+		{"not installed", "N/A\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var command string
+			manager := NewNvmManager(&MockShell{
+				ExecuteInShellFunc: func(c string) (string, error) {
+					command = c
+					// 'nvm list' would mention the alias either way
+					if strings.HasSuffix(c, "nvm list") {
+						return "lts/iron -> v20.18.1 (-> N/A)", nil
+					}
+					return tt.output, nil
+				},
+			})
+
+			installed, err := manager.IsVersionInstalled("lts/iron")
+			if err != nil || installed != tt.want {
+				t.Errorf("IsVersionInstalled(lts/iron) = %v, %v, want %v", installed, err, tt.want)
+			}
+			if !strings.HasSuffix(command, "nvm version lts/iron") {
+				t.Errorf("command = %q, want nvm version", command)
+			}
+		})
+	}
+}
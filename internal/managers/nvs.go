@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/matutetandil/autonode/internal/core"
@@ -67,6 +68,11 @@ func (m *NvsManager) sourceNvs() string {
 
 // IsVersionInstalled checks if a specific Node.js version is installed via nvs
 func (m *NvsManager) IsVersionInstalled(version string) (bool, error) {
+	// Sourcing nvs.sh is slow: look on disk first
+	if _, err := m.ResolveInstallDir(version); err == nil {
+		return true, nil
+	}
+
 	// List installed versions (need to source nvs.sh first)
 	command := m.sourceNvs() + "nvs list"
 	output, err := m.shell.ExecuteInShell(command)
@@ -79,6 +85,39 @@ func (m *NvsManager) IsVersionInstalled(version string) (bool, error) {
 	return strings.Contains(output, normalizedVersion), nil
 }
 
+// ListInstalledVersions returns the versions installed under $NVS_HOME/node
+func (m *NvsManager) ListInstalledVersions() ([]string, error) {
+	return listVersionDirs(filepath.Join(m.getNvsHome(), "node"))
+}
+
+// ResolveInstallDir returns $NVS_HOME/node/<version>/<arch>, found on disk so shell mode
+// can switch without sourcing nvs.sh
+func (m *NvsManager) ResolveInstallDir(version string) (string, error) {
+	installed, err := m.ListInstalledVersions()
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := resolveInstalledVersion(installed, version)
+	if err != nil {
+		return "", err
+	}
+
+	return checkInstallDir(filepath.Join(m.getNvsHome(), "node", resolved, nvsArch(runtime.GOARCH)), version)
+}
+
+// nvsArch returns the name nvs gives the architecture in its install paths
+func nvsArch(goarch string) string {
+	switch goarch {
+	case "amd64":
+		return "x64"
+	case "386":
+		return "x86"
+	default:
+		return goarch
+	}
+}
+
 // InstallVersion installs a specific Node.js version using nvs
 func (m *NvsManager) InstallVersion(version string) error {
 	normalizedVersion := normalizeNvsVersion(version)
@@ -101,8 +140,22 @@ func (m *NvsManager) UseVersion(version string) error {
 	return nil
 }
 
+// ExecArgs returns a command line that runs args under version with 'nvs exec'
+// nvs is a shell function, so bash sources nvs.sh first (passed as $0 to avoid quoting issues)
+func (m *NvsManager) ExecArgs(version string, args []string) ([]string, error) {
+	nvsScript := filepath.Join(m.getNvsHome(), "nvs.sh")
+	script := `. "$0" >/dev/null && nvs exec "$@"`
+	return append([]string{"bash", "-c", script, nvsScript, normalizeNvsVersion(version)}, args...), nil
+}
+
 // normalizeNvsVersion ensures version has consistent format for nvs
 // nvs expects versions without 'v' prefix
 func normalizeNvsVersion(version string) string {
 	return strings.TrimPrefix(version, "v")
 }
+
+// GetActiveVersion returns the version of the nvs node first on PATH
+// (<NVS_HOME>/node/<version>/<arch>/bin/node)
+func (m *NvsManager) GetActiveVersion() string {
+	return versionOnPath(filepath.Join(m.getNvsHome(), "node"))
+}
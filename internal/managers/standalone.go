@@ -0,0 +1,377 @@
+package managers
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+const (
+	// standaloneDownloadTimeout bounds a single index or tarball download
+	standaloneDownloadTimeout = 10 * time.Minute
+)
+
+// StandaloneManager installs Node.js itself from a distribution mirror, so autonode
+// works on machines without nvm, nvs, volta, fnm, asdf or mise (fresh CI images, containers).
+// Versions live in <installDir>/<version>; shell mode switches by putting their bin on PATH.
+// Single Responsibility Principle: Only responsible for the built-in Node.js installs
+// Open/Closed Principle: Implements VersionManager interface
+type StandaloneManager struct {
+	installDir string
+	mirror     string
+	releases   core.ReleaseIndexProvider
+	client     *http.Client
+	platform   string
+}
+
+// NewStandaloneManager creates a StandaloneManager that installs into installDir
+// (usually ~/.autonode/versions) and downloads from mirror (e.g. https://nodejs.org/dist)
+// Partial versions and aliases are resolved with the release index of releases
+func NewStandaloneManager(installDir, mirror string, releases *core.NodeReleasesClient) *StandaloneManager {
+	return &StandaloneManager{
+		installDir: installDir,
+		mirror:     strings.TrimRight(mirror, "/"),
+		releases:   releases,
+		client:     &http.Client{Timeout: standaloneDownloadTimeout},
+		platform:   nodePlatform(runtime.GOOS, runtime.GOARCH),
+	}
+}
+
+// GetName returns the name of this version manager
+func (m *StandaloneManager) GetName() string {
+	return "autonode"
+}
+
+// IsInstalled always reports true: the built-in installer needs nothing on the system,
+// which makes it the fallback when no other version manager is found
+func (m *StandaloneManager) IsInstalled() bool {
+	return true
+}
+
+// ListInstalledVersions returns the versions installed under the install directory
+func (m *StandaloneManager) ListInstalledVersions() ([]string, error) {
+	return listVersionDirs(m.installDir)
+}
+
+// IsVersionInstalled checks if a version (or, for partial versions, any matching one) is installed
+func (m *StandaloneManager) IsVersionInstalled(version string) (bool, error) {
+	if _, err := m.ResolveInstallDir(version); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// ResolveInstallDir returns the directory of the installed version matching version
+// Partial versions and ranges resolve to the highest installed match
+func (m *StandaloneManager) ResolveInstallDir(version string) (string, error) {
+	installed, err := m.ListInstalledVersions()
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := resolveInstalledVersion(installed, version)
+	if err != nil {
+		return "", err
+	}
+
+	return checkInstallDir(filepath.Join(m.installDir, resolved), version)
+}
+
+// GetActiveVersion returns the version whose bin directory shell mode put first on PATH
+func (m *StandaloneManager) GetActiveVersion() string {
+	return versionOnPath(m.installDir)
+}
+
+// InstallVersion downloads the Node.js tarball for this platform, verifies it
+// against the release's SHASUMS256.txt and unpacks it into the install directory
+func (m *StandaloneManager) InstallVersion(version string) error {
+	if m.platform == "" {
+		return fmt.Errorf("the built-in installer does not support %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+
+	resolved, err := m.resolveRemoteVersion(version)
+	if err != nil {
+		return err
+	}
+
+	archiveName := fmt.Sprintf("node-v%s-%s.tar.gz", resolved, m.platform)
+	releaseURL := fmt.Sprintf("%s/v%s", m.mirror, resolved)
+
+	expected, err := m.fetchChecksum(releaseURL+"/SHASUMS256.txt", archiveName)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.installDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", m.installDir, err)
+	}
+
+	archive, err := os.CreateTemp(m.installDir, ".download-*.tar.gz")
+	if err != nil {
+		return fmt.Errorf("failed to create download file: %w", err)
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	actual, err := m.download(releaseURL+"/"+archiveName, archive)
+	if err != nil {
+		return err
+	}
+
+	if actual != expected {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", archiveName, expected, actual)
+	}
+
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read %s: %w", archiveName, err)
+	}
+
+	// Unpack next to the final location and rename, so an interrupted install
+	// never leaves a half-extracted version behind
+	staging, err := os.MkdirTemp(m.installDir, ".extract-*")
+	if err != nil {
+		return fmt.Errorf("failed to create extraction directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	if err := extractTarGz(archive, staging); err != nil {
+		return fmt.Errorf("failed to extract %s: %w", archiveName, err)
+	}
+
+	target := filepath.Join(m.installDir, resolved)
+	if err := os.RemoveAll(target); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", target, err)
+	}
+	if err := os.Rename(staging, target); err != nil {
+		return fmt.Errorf("failed to install Node.js %s: %w", resolved, err)
+	}
+
+	return nil
+}
+
+// UseVersion checks that the version is installed
+// The switch itself happens in shell mode, which puts the version's bin directory on PATH
+func (m *StandaloneManager) UseVersion(version string) error {
+	if _, err := m.ResolveInstallDir(version); err != nil {
+		return fmt.Errorf("failed to use version %s: %w", normalizeVersion(version), err)
+	}
+	return nil
+}
+
+// resolveRemoteVersion turns a partial version, range or alias into the newest
+// matching release listed in the release index
+func (m *StandaloneManager) resolveRemoteVersion(version string) (string, error) {
+	version = strings.TrimPrefix(normalizeVersion(version), "=")
+	if core.IsExactVersion(version) {
+		return version, nil
+	}
+
+	index, err := m.releases.GetReleaseIndex()
+	if err != nil {
+		return "", err
+	}
+
+	if release, found := index.Resolve(version); found {
+		return release.Number(), nil
+	}
+
+	return "", fmt.Errorf("no Node.js release matches %s", version)
+}
+
+// fetchChecksum returns the SHA-256 listed for fileName in a SHASUMS256.txt file
+func (m *StandaloneManager) fetchChecksum(url, fileName string) (string, error) {
+	resp, err := m.get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// Each line is "<sha256>  <file name>"
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == fileName {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read checksums: %w", err)
+	}
+
+	return "", fmt.Errorf("no checksum for %s in %s", fileName, url)
+}
+
+// download writes the body of url to dst and returns its SHA-256 as hex
+func (m *StandaloneManager) download(url string, dst io.Writer) (string, error) {
+	resp, err := m.get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(dst, hash), resp.Body); err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", url, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// get performs a GET request and fails on non-200 responses
+func (m *StandaloneManager) get(url string) (*http.Response, error) {
+	resp, err := m.client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: HTTP %d", url, resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// extractTarGz unpacks a Node.js tarball into dst, dropping the top-level
+// "node-vX-platform/" directory every entry is nested under
+func extractTarGz(r io.Reader, dst string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		_, rel, found := strings.Cut(header.Name, "/")
+		if !found || rel == "" {
+			continue
+		}
+
+		target := filepath.Join(dst, filepath.FromSlash(rel))
+		if !withinDir(dst, target) {
+			return fmt.Errorf("invalid path in archive: %s", header.Name)
+		}
+		// Never write through a symlink an earlier entry created
+		if err := checkNoSymlinks(dst, filepath.Dir(target)); err != nil {
+			return fmt.Errorf("invalid path in archive: %s: %w", header.Name, err)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(tr, target, os.FileMode(header.Mode).Perm()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			// Links must stay inside the install directory, e.g. bin/npm -> ../lib/...
+			link := filepath.FromSlash(header.Linkname)
+			if filepath.IsAbs(link) || !withinDir(dst, filepath.Join(filepath.Dir(target), link)) {
+				return fmt.Errorf("invalid symlink in archive: %s -> %s", header.Name, header.Linkname)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// withinDir reports whether path is dir or lies below it
+func withinDir(dir, path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator))
+}
+
+// checkNoSymlinks fails if any existing directory between root and dir is a symlink
+func checkNoSymlinks(root, dir string) error {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return err
+	}
+	if rel == "." {
+		return nil
+	}
+
+	path := root
+	for _, part := range strings.Split(rel, string(os.PathSeparator)) {
+		path = filepath.Join(path, part)
+		info, err := os.Lstat(path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("%s is a symlink", path)
+		}
+	}
+	return nil
+}
+
+// writeArchiveFile copies one regular file out of the archive
+// The file must not exist yet, so an existing symlink is never followed
+func writeArchiveFile(r io.Reader, target string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// nodePlatform maps Go's OS/architecture to the suffix used in Node.js tarball names
+// (e.g. "linux-x64"). Returns empty string for platforms without a tarball.
+func nodePlatform(goos, goarch string) string {
+	if goos != "linux" && goos != "darwin" {
+		return ""
+	}
+
+	arch := map[string]string{
+		"amd64":   "x64",
+		"arm64":   "arm64",
+		"arm":     "armv7l",
+		"ppc64le": "ppc64le",
+		"s390x":   "s390x",
+	}[goarch]
+	if arch == "" {
+		return ""
+	}
+
+	return goos + "-" + arch
+}
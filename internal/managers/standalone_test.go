@@ -0,0 +1,362 @@
+package managers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// Ensure StandaloneManager implements the interfaces the service relies on
+var (
+	_ core.VersionManager         = (*StandaloneManager)(nil)
+	_ core.InstalledVersionLister = (*StandaloneManager)(nil)
+	_ core.ActiveVersionReader    = (*StandaloneManager)(nil)
+	_ core.InstallDirResolver     = (*StandaloneManager)(nil)
+)
+
+const testMirrorIndex = `[
+  {"version": "v22.12.0", "date": "2024-12-03", "lts": "Jod"},
+  {"version": "v21.7.3", "date": "2024-04-10", "lts": false},
+  {"version": "v20.11.1", "date": "2024-02-14", "lts": "Iron"},
+  {"version": "v20.11.0", "date": "2024-01-09", "lts": "Iron"}
+]`
+
+// tarEntry describes one entry of a test tarball
+type tarEntry struct {
+	name     string
+	body     string
+	linkname string
+}
+
+// buildTarball returns a gzipped tarball containing entries
+func buildTarball(t *testing.T, entries []tarEntry) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0755}
+		switch {
+		case entry.linkname != "":
+			header.Typeflag = tar.TypeSymlink
+			header.Linkname = entry.linkname
+		case strings.HasSuffix(entry.name, "/"):
+			header.Typeflag = tar.TypeDir
+		default:
+			header.Typeflag = tar.TypeReg
+			header.Size = int64(len(entry.body))
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write tar header: %v", err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte(entry.body)); err != nil {
+				t.Fatalf("failed to write tar body: %v", err)
+			}
+		}
+	}
+
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+// nodeTarball returns a minimal Node.js release tarball for version
+func nodeTarball(t *testing.T, version string) []byte {
+	root := fmt.Sprintf("node-v%s-linux-x64/", version)
+	return buildTarball(t, []tarEntry{
+		{name: root},
+		{name: root + "bin/"},
+		{name: root + "bin/node", body: "#!/bin/sh\necho v" + version},
+		{name: root + "lib/node_modules/npm/bin/npm-cli.js", body: "// npm"},
+		{name: root + "bin/npm", linkname: "../lib/node_modules/npm/bin/npm-cli.js"},
+	})
+}
+
+// newTestMirror starts a stand-in Node.js distribution mirror serving the given
+// tarballs (version -> archive). checksums overrides the published SHA-256 per version.
+func newTestMirror(t *testing.T, tarballs map[string][]byte, checksums map[string]string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/index.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testMirrorIndex)
+	})
+
+	for version, archive := range tarballs {
+		archive := archive
+		name := fmt.Sprintf("node-v%s-linux-x64.tar.gz", version)
+
+		sum := sha256.Sum256(archive)
+		checksum := hex.EncodeToString(sum[:])
+		if override, ok := checksums[version]; ok {
+			checksum = override
+		}
+
+		mux.HandleFunc("/v"+version+"/SHASUMS256.txt", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "%s  node-v%s-darwin-arm64.tar.gz\n", strings.Repeat("0", 64), version)
+			fmt.Fprintf(w, "%s  %s\n", checksum, name)
+		})
+		mux.HandleFunc("/v"+version+"/"+name, func(w http.ResponseWriter, r *http.Request) {
+			w.Write(archive)
+		})
+	}
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestStandaloneManager creates a StandaloneManager for linux-x64 backed by mirror
+func newTestStandaloneManager(t *testing.T, mirror string) *StandaloneManager {
+	t.Setenv("HOME", t.TempDir())
+	cache, err := core.NewCacheManager()
+	if err != nil {
+		t.Fatalf("failed to create cache manager: %v", err)
+	}
+
+	releases := core.NewNodeReleasesClient(cache, core.NewNullLogger(), mirror)
+	manager := NewStandaloneManager(filepath.Join(t.TempDir(), "versions"), mirror+"/", releases)
+	manager.platform = "linux-x64"
+	return manager
+}
+
+func TestStandaloneManager_GetName(t *testing.T) {
+	manager := NewStandaloneManager(t.TempDir(), core.DefaultNodeMirror, nil)
+
+	if name := manager.GetName(); name != "autonode" {
+		t.Errorf("GetName() = %q, want %q", name, "autonode")
+	}
+	if !manager.IsInstalled() {
+		t.Error("IsInstalled() = false, want true")
+	}
+}
+
+func TestStandaloneManager_InstallVersion(t *testing.T) {
+	mirror := newTestMirror(t, map[string][]byte{
+		"20.11.1": nodeTarball(t, "20.11.1"),
+		"22.12.0": nodeTarball(t, "22.12.0"),
+	}, nil)
+
+	tests := []struct {
+		name    string
+		version string
+		want    string
+	}{
+		{"exact version", "v22.12.0", "22.12.0"},
+		{"partial version", "20", "20.11.1"},
+		{"range", ">=20 <21", "20.11.1"},
+		{"lts codename", "lts/iron", "20.11.1"},
+		{"latest", "node", "22.12.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := newTestStandaloneManager(t, mirror.URL)
+
+			if err := manager.InstallVersion(tt.version); err != nil {
+				t.Fatalf("InstallVersion(%q) error = %v", tt.version, err)
+			}
+
+			dir, err := manager.ResolveInstallDir(tt.want)
+			if err != nil {
+				t.Fatalf("ResolveInstallDir(%q) error = %v", tt.want, err)
+			}
+			if want := filepath.Join(manager.installDir, tt.want); dir != want {
+				t.Errorf("ResolveInstallDir() = %q, want %q", dir, want)
+			}
+
+			if _, err := os.Stat(filepath.Join(dir, "bin", "node")); err != nil {
+				t.Errorf("bin/node not installed: %v", err)
+			}
+			if link, err := os.Readlink(filepath.Join(dir, "bin", "npm")); err != nil || link != "../lib/node_modules/npm/bin/npm-cli.js" {
+				t.Errorf("bin/npm symlink = %q (%v)", link, err)
+			}
+
+			versions, err := manager.ListInstalledVersions()
+			if err != nil || !reflect.DeepEqual(versions, []string{tt.want}) {
+				t.Errorf("ListInstalledVersions() = %v, %v; want [%s]", versions, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStandaloneManager_InstallVersion_Errors(t *testing.T) {
+	evil := buildTarball(t, []tarEntry{
+		{name: "node-v21.7.3-linux-x64/../../escaped", body: "boom"},
+	})
+
+	mirror := newTestMirror(t, map[string][]byte{
+		"20.11.1": nodeTarball(t, "20.11.1"),
+		"21.7.3":  evil,
+	}, map[string]string{
+		"20.11.1": strings.Repeat("a", 64),
+	})
+
+	tests := []struct {
+		name    string
+		version string
+		wantErr string
+	}{
+		{"checksum mismatch", "20.11.1", "checksum mismatch"},
+		{"path traversal", "21.7.3", "invalid path"},
+		{"unknown release", "19.0.0", "HTTP 404"},
+		{"no matching release", "^18", "no Node.js release matches"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := newTestStandaloneManager(t, mirror.URL)
+
+			err := manager.InstallVersion(tt.version)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("InstallVersion(%q) error = %v, want containing %q", tt.version, err, tt.wantErr)
+			}
+
+			// Failed installs must not leave anything behind
+			versions, _ := manager.ListInstalledVersions()
+			if len(versions) != 0 {
+				t.Errorf("installed versions after failure = %v, want none", versions)
+			}
+			if _, err := os.Stat(filepath.Join(filepath.Dir(manager.installDir), "escaped")); err == nil {
+				t.Error("archive entry escaped the install directory")
+			}
+		})
+	}
+}
+
+func TestStandaloneManager_IsVersionInstalled(t *testing.T) {
+	manager := newTestStandaloneManager(t, "http://127.0.0.1:0")
+	for _, version := range []string{"18.17.0", "20.11.0", "20.11.1"} {
+		bin := filepath.Join(manager.installDir, version, "bin")
+		if err := os.MkdirAll(bin, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(bin, "node"), nil, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+		wantDir string
+	}{
+		{"20.11.0", true, "20.11.0"},
+		{"v18.17.0", true, "18.17.0"},
+		{"20", true, "20.11.1"},
+		{"^18", true, "18.17.0"},
+		{"22", false, ""},
+		{"lts/iron", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := manager.IsVersionInstalled(tt.version)
+			if err != nil || got != tt.want {
+				t.Errorf("IsVersionInstalled(%q) = %v, %v; want %v", tt.version, got, err, tt.want)
+			}
+
+			dir, err := manager.ResolveInstallDir(tt.version)
+			if tt.want && dir != filepath.Join(manager.installDir, tt.wantDir) {
+				t.Errorf("ResolveInstallDir(%q) = %q, %v", tt.version, dir, err)
+			}
+			if !tt.want && err == nil {
+				t.Errorf("ResolveInstallDir(%q) expected error", tt.version)
+			}
+
+			if useErr := manager.UseVersion(tt.version); (useErr == nil) != tt.want {
+				t.Errorf("UseVersion(%q) error = %v", tt.version, useErr)
+			}
+		})
+	}
+}
+
+func TestExtractTarGz_Symlinks(t *testing.T) {
+	const root = "node-v20.11.1-linux-x64/"
+
+	tests := []struct {
+		name    string
+		entries []tarEntry
+		wantErr string
+	}{
+		{
+			name:    "absolute link",
+			entries: []tarEntry{{name: root + "bin/npm", linkname: "/etc/passwd"}},
+			wantErr: "invalid symlink",
+		},
+		{
+			name:    "link leaving the install directory",
+			entries: []tarEntry{{name: root + "bin/npm", linkname: "../../outside"}},
+			wantErr: "invalid symlink",
+		},
+		{
+			name: "file written through a linked directory",
+			entries: []tarEntry{
+				{name: root + "lib", linkname: "."},
+				{name: root + "lib/node", body: "boom"},
+			},
+			wantErr: "is a symlink",
+		},
+		{
+			name: "file written over a link",
+			entries: []tarEntry{
+				{name: root + "bin/node", linkname: "npm"},
+				{name: root + "bin/node", body: "boom"},
+			},
+			wantErr: "exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := filepath.Join(t.TempDir(), "staging")
+			if err := os.Mkdir(dst, 0755); err != nil {
+				t.Fatalf("failed to create staging directory: %v", err)
+			}
+
+			err := extractTarGz(bytes.NewReader(buildTarball(t, tt.entries)), dst)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("extractTarGz() error = %v, want containing %q", err, tt.wantErr)
+			}
+			if _, err := os.Stat(filepath.Join(dst, "node")); err == nil {
+				t.Error("archive entry was written through a symlink")
+			}
+		})
+	}
+}
+
+func TestNodePlatform(t *testing.T) {
+	tests := []struct {
+		goos, goarch string
+		want         string
+	}{
+		{"linux", "amd64", "linux-x64"},
+		{"linux", "arm64", "linux-arm64"},
+		{"darwin", "arm64", "darwin-arm64"},
+		{"linux", "arm", "linux-armv7l"},
+		{"windows", "amd64", ""},
+		{"linux", "mips", ""},
+	}
+
+	for _, tt := range tests {
+		if got := nodePlatform(tt.goos, tt.goarch); got != tt.want {
+			t.Errorf("nodePlatform(%q, %q) = %q, want %q", tt.goos, tt.goarch, got, tt.want)
+		}
+	}
+}
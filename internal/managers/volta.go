@@ -2,6 +2,8 @@ package managers
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/matutetandil/autonode/internal/core"
@@ -34,6 +36,10 @@ func (m *VoltaManager) IsInstalled() bool {
 
 // IsVersionInstalled checks if a specific Node.js version is installed via Volta
 func (m *VoltaManager) IsVersionInstalled(version string) (bool, error) {
+	if _, err := m.ResolveInstallDir(version); err == nil {
+		return true, nil
+	}
+
 	// List installed versions
 	output, err := m.shell.Execute("volta", "list", "node")
 	if err != nil {
@@ -45,6 +51,41 @@ func (m *VoltaManager) IsVersionInstalled(version string) (bool, error) {
 	return strings.Contains(output, normalizedVersion), nil
 }
 
+// ListInstalledVersions returns the versions in Volta's node image directory
+func (m *VoltaManager) ListInstalledVersions() ([]string, error) {
+	return listVersionDirs(filepath.Join(m.getVoltaHome(), "tools", "image", "node"))
+}
+
+// ResolveInstallDir returns the version's directory in Volta's node image directory
+func (m *VoltaManager) ResolveInstallDir(version string) (string, error) {
+	installed, err := m.ListInstalledVersions()
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := resolveInstalledVersion(installed, version)
+	if err != nil {
+		return "", err
+	}
+
+	return checkInstallDir(filepath.Join(m.getVoltaHome(), "tools", "image", "node", resolved), version)
+}
+
+// getVoltaHome returns the Volta installation directory
+// Checks VOLTA_HOME environment variable, otherwise defaults to ~/.volta
+func (m *VoltaManager) getVoltaHome() string {
+	if voltaHome := os.Getenv("VOLTA_HOME"); voltaHome != "" {
+		return voltaHome
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(homeDir, ".volta")
+}
+
 // InstallVersion installs a specific Node.js version using Volta
 // Note: Volta automatically installs when you use 'volta install node@version'
 func (m *VoltaManager) InstallVersion(version string) error {
@@ -67,6 +108,11 @@ func (m *VoltaManager) UseVersion(version string) error {
 	return nil
 }
 
+// ExecArgs returns a command line that runs args under version with 'volta run'
+func (m *VoltaManager) ExecArgs(version string, args []string) ([]string, error) {
+	return append([]string{"volta", "run", "--node", normalizeVoltaVersion(version)}, args...), nil
+}
+
 // normalizeVoltaVersion ensures version has consistent format for Volta
 // Volta expects versions without 'v' prefix
 func normalizeVoltaVersion(version string) string {
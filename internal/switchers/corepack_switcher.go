@@ -0,0 +1,54 @@
+package switchers
+
+import (
+	"fmt"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+// CorepackSwitcher activates pinned package managers using Corepack, which ships with Node.js.
+// Documentation: https://nodejs.org/api/corepack.html
+//
+// This implementation adheres to:
+// - Single Responsibility Principle (SRP): Only handles Corepack activation
+// - Dependency Inversion Principle (DIP): Depends on ShellExecutor abstraction
+// - Liskov Substitution Principle (LSP): Implements PackageManagerSwitcher interface
+type CorepackSwitcher struct {
+	shell core.ShellExecutor
+}
+
+// corepackManagers are the package managers Corepack can install and activate
+var corepackManagers = map[string]bool{"npm": true, "pnpm": true, "yarn": true}
+
+// NewCorepackSwitcher creates a new CorepackSwitcher instance.
+// Follows Dependency Injection pattern (DIP).
+func NewCorepackSwitcher(shell core.ShellExecutor) *CorepackSwitcher {
+	return &CorepackSwitcher{
+		shell: shell,
+	}
+}
+
+// GetName returns the name of this package manager switcher.
+func (s *CorepackSwitcher) GetName() string {
+	return "corepack"
+}
+
+// IsInstalled checks if corepack is available in PATH.
+func (s *CorepackSwitcher) IsInstalled() bool {
+	return s.shell.CommandExists("corepack")
+}
+
+// ActivationCommands returns the Corepack commands that activate the package manager:
+// "corepack enable <name>" installs its shim next to node, and
+// "corepack prepare <name>@<version>[+<hash>] --activate" makes that version the default,
+// checked against the integrity hash when package.json pins one.
+func (s *CorepackSwitcher) ActivationCommands(packageManager core.PackageManagerDetectionResult) ([][]string, error) {
+	if !corepackManagers[packageManager.Name] {
+		return nil, fmt.Errorf("corepack does not support package manager '%s'", packageManager.Name)
+	}
+
+	return [][]string{
+		{"corepack", "enable", packageManager.Name},
+		{"corepack", "prepare", packageManager.Reference(), "--activate"},
+	}, nil
+}
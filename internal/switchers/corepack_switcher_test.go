@@ -0,0 +1,74 @@
+package switchers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/matutetandil/autonode/internal/core"
+)
+
+func TestCorepackSwitcher_GetName(t *testing.T) {
+	switcher := NewCorepackSwitcher(&MockShell{})
+
+	if name := switcher.GetName(); name != "corepack" {
+		t.Errorf("GetName() = %q, want %q", name, "corepack")
+	}
+}
+
+func TestCorepackSwitcher_IsInstalled(t *testing.T) {
+	installed := NewCorepackSwitcher(&MockShell{
+		CommandExistsFunc: func(command string) bool { return command == "corepack" },
+	})
+	if !installed.IsInstalled() {
+		t.Error("IsInstalled() = false, want true when corepack is in PATH")
+	}
+
+	if NewCorepackSwitcher(&MockShell{}).IsInstalled() {
+		t.Error("IsInstalled() = true, want false when corepack is not in PATH")
+	}
+}
+
+func TestCorepackSwitcher_ActivationCommands(t *testing.T) {
+	switcher := NewCorepackSwitcher(&MockShell{})
+
+	tests := []struct {
+		name           string
+		packageManager core.PackageManagerDetectionResult
+		want           [][]string
+		wantError      bool
+	}{
+		{
+			name:           "pnpm",
+			packageManager: core.PackageManagerDetectionResult{Found: true, Name: "pnpm", Version: "9.1.0"},
+			want: [][]string{
+				{"corepack", "enable", "pnpm"},
+				{"corepack", "prepare", "pnpm@9.1.0", "--activate"},
+			},
+		},
+		{
+			name:           "yarn with hash",
+			packageManager: core.PackageManagerDetectionResult{Found: true, Name: "yarn", Version: "4.1.1", Hash: "sha512.abc"},
+			want: [][]string{
+				{"corepack", "enable", "yarn"},
+				{"corepack", "prepare", "yarn@4.1.1+sha512.abc", "--activate"},
+			},
+		},
+		{
+			name:           "unsupported package manager",
+			packageManager: core.PackageManagerDetectionResult{Found: true, Name: "bun", Version: "1.1.0"},
+			wantError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := switcher.ActivationCommands(tt.packageManager)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("ActivationCommands() error = %v, wantError %v", err, tt.wantError)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ActivationCommands() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
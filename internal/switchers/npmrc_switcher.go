@@ -104,6 +104,29 @@ func (s *NpmrcSwitcher) ProfileExists(profileName string) (bool, error) {
 	return false, nil
 }
 
+// GetActiveProfile returns the profile npmrc marks as active (the line with an asterisk).
+// Implements core.ActiveProfileReader.
+func (s *NpmrcSwitcher) GetActiveProfile() (string, error) {
+	npmrcPath, err := s.findExecutable()
+	if err != nil {
+		return "", fmt.Errorf("failed to find npmrc: %w", err)
+	}
+
+	output, err := s.shell.Execute(npmrcPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to list npmrc profiles: %w", err)
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		profileLine := strings.TrimSpace(line)
+		if strings.HasPrefix(profileLine, "*") {
+			return strings.TrimSpace(strings.TrimPrefix(profileLine, "*")), nil
+		}
+	}
+
+	return "", nil
+}
+
 // SwitchProfile switches to the specified npm profile using npmrc.
 // Command: npmrc <profile-name>
 func (s *NpmrcSwitcher) SwitchProfile(profileName string) error {
@@ -107,6 +107,56 @@ func TestNpmrcSwitcher_ProfileExists(t *testing.T) {
 	}
 }
 
+func TestNpmrcSwitcher_GetActiveProfile(t *testing.T) {
+	tests := []struct {
+		name       string
+		listOutput string
+		listError  error
+		want       string
+		wantError  bool
+	}{
+		{
+			name:       "active profile marked",
+			listOutput: "default\n   * work\npersonal",
+			want:       "work",
+		},
+		{
+			name:       "no active marker",
+			listOutput: "default\nwork",
+			want:       "",
+		},
+		{
+			name:      "list command fails",
+			listError: fmt.Errorf("command failed"),
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shell := &MockShell{
+				CommandExistsFunc: func(command string) bool {
+					return command == "npmrc"
+				},
+				ExecuteFunc: func(command string, args ...string) (string, error) {
+					return tt.listOutput, tt.listError
+				},
+			}
+
+			switcher := NewNpmrcSwitcher(shell)
+			got, err := switcher.GetActiveProfile()
+
+			if (err != nil) != tt.wantError {
+				t.Fatalf("GetActiveProfile() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if got != tt.want {
+				t.Errorf("GetActiveProfile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNpmrcSwitcher_SwitchProfile(t *testing.T) {
 	tests := []struct {
 		name         string